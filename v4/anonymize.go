@@ -0,0 +1,46 @@
+package semver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// anonymizedHashLength is the number of bytes of the HMAC in a token of
+// Anonymize.
+const anonymizedHashLength = 8
+
+// Anonymize returns a token of v for privacy preserving telemetry, which
+// allows to analyze the distribution of client versions without reporting
+// exact versions. The token keeps the major and minor version in an order
+// preserving encoding and replaces the rest of v, including prerelease and
+// build meta data, by a truncated HMAC-SHA256 keyed with salt:
+//
+//	b12.a3.5f0c8a31d2e4b7a9
+//
+// Tokens of different major or minor versions sort like the versions when
+// compared as strings, tokens within a major.minor bucket sort in an
+// arbitrary but fixed order. Equal versions and salts yield equal tokens, so
+// they can be counted. Without the salt the exact version can not be
+// recovered, keep it secret and rotate it to unlink tokens over time.
+func Anonymize(v Version, salt []byte) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(v.String()))
+	sum := mac.Sum(nil)
+
+	b := make([]byte, 0, 2*22)
+	b = appendOrderedUint(b, v.Major)
+	b = append(b, '.')
+	b = appendOrderedUint(b, v.Minor)
+	b = append(b, '.')
+	return string(b) + hex.EncodeToString(sum[:anonymizedHashLength])
+}
+
+// appendOrderedUint appends n prefixed by its number of digits as a letter,
+// 'a' for one digit, so that the encodings sort like the numbers.
+func appendOrderedUint(b []byte, n uint64) []byte {
+	digits := strconv.FormatUint(n, 10)
+	b = append(b, byte('a'+len(digits)-1))
+	return append(b, digits...)
+}
@@ -0,0 +1,52 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	salt := []byte("secret")
+	tests := []struct {
+		v      string
+		prefix string
+	}{
+		{"0.0.1", "a0.a0."},
+		{"1.2.3", "a1.a2."},
+		{"12.3.0-beta.1+build.5", "b12.a3."},
+		{"18446744073709551615.10.0", "t18446744073709551615.b10."},
+	}
+	for _, tc := range tests {
+		token := Anonymize(MustParse(tc.v), salt)
+		if !strings.HasPrefix(token, tc.prefix) || len(token) != len(tc.prefix)+16 {
+			t.Errorf("Invalid for case %q: Expected prefix %q and 16 hex digits, got: %q", tc.v, tc.prefix, token)
+		}
+		if strings.Contains(token, MustParse(tc.v).String()) {
+			t.Errorf("Invalid for case %q: token %q contains the version", tc.v, token)
+		}
+	}
+}
+
+func TestAnonymizeOrder(t *testing.T) {
+	salt := []byte("secret")
+	versions := []string{"0.9.0", "1.0.0", "1.2.0", "1.10.0", "2.0.0", "10.0.0", "100.0.0"}
+	for i := 1; i < len(versions); i++ {
+		a, b := Anonymize(MustParse(versions[i-1]), salt), Anonymize(MustParse(versions[i]), salt)
+		if a >= b {
+			t.Errorf("Invalid for case %q < %q: tokens %q and %q are not ordered", versions[i-1], versions[i], a, b)
+		}
+	}
+}
+
+func TestAnonymizeKeyed(t *testing.T) {
+	v := MustParse("1.2.3+build.1")
+	if Anonymize(v, []byte("a")) != Anonymize(v, []byte("a")) {
+		t.Errorf("Tokens of equal versions and salts must be equal")
+	}
+	if Anonymize(v, []byte("a")) == Anonymize(v, []byte("b")) {
+		t.Errorf("Tokens of different salts must differ")
+	}
+	if Anonymize(v, []byte("a")) == Anonymize(MustParse("1.2.3+build.2"), []byte("a")) {
+		t.Errorf("Tokens of different builds must differ")
+	}
+}
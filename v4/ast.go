@@ -0,0 +1,80 @@
+package semver
+
+import "fmt"
+
+// Comparator is a single expanded comparator of Constraints, e.g. ">=1.2.0".
+// Wildcard, tilde, caret and hyphen ranges are expanded to plain comparators
+// when parsing.
+type Comparator struct {
+	Op      Operator
+	Version Version
+}
+
+// String returns the comparator in range notation, the "=" of exact versions
+// is omitted.
+func (c Comparator) String() string {
+	if c.Op == OpEQ {
+		return c.Version.String()
+	}
+	return c.Op.String() + c.Version.String()
+}
+
+// Sets returns the structure of the constraints: a version satisfies c if it
+// satisfies all comparators of any of the sets. The comparators of a set are
+// in the order of String. The result is a copy, it can be transformed and
+// passed to NewConstraints together with the prerelease rule of NPMCompat.
+func (c *Constraints) Sets() [][]Comparator {
+	sets := make([][]Comparator, len(c.sets))
+	for i, set := range c.sets {
+		sets[i] = make([]Comparator, len(set))
+		for j, vr := range normalOrder(set) {
+			sets[i][j] = Comparator{Op: vr.op, Version: vr.v}
+		}
+	}
+	return sets
+}
+
+// NPMCompat reports whether prerelease versions satisfy c by the prerelease
+// rule of RangeOptions.NPMCompat, which IncludePrerelease disables.
+func (c *Constraints) NPMCompat() bool {
+	return c.npm
+}
+
+// NewConstraints builds Constraints from sets of comparators as returned by
+// Constraints.Sets. Every set must contain at least one comparator and there
+// must be at least one set, like in a range string. Of opts, only NPMCompat
+// and IncludePrerelease are used, they select the prerelease rule. Pass
+// RangeOptions{NPMCompat: c.NPMCompat()} to rebuild Constraints c.
+func NewConstraints(sets [][]Comparator, opts RangeOptions) (*Constraints, error) {
+	if len(sets) == 0 {
+		return nil, fmt.Errorf("no comparator sets")
+	}
+	c := &Constraints{sets: make([][]versionRange, len(sets)), npm: opts.NPMCompat && !opts.IncludePrerelease}
+	for i, set := range sets {
+		if len(set) == 0 {
+			return nil, fmt.Errorf("set %d: no comparators", i)
+		}
+		c.sets[i] = make([]versionRange, len(set))
+		for j, cmp := range set {
+			if cmp.Op < OpEQ || cmp.Op > OpLTE {
+				return nil, fmt.Errorf("set %d, comparator %d: invalid operator %s", i, j, cmp.Op)
+			}
+			if err := cmp.Version.Validate(); err != nil {
+				return nil, fmt.Errorf("set %d, comparator %d: %s", i, j, err)
+			}
+			c.sets[i][j] = versionRange{v: cmp.Version, c: cmp.Op.comparator(), op: cmp.Op}
+		}
+	}
+	return c, nil
+}
+
+// ConstraintsOf returns the Constraints a Range was created from, e.g. by
+// ParseRange or by combining parsed ranges with AND and OR. It returns
+// ErrRangeNotInspectable if r was not created by this package.
+func ConstraintsOf(r Range) (*Constraints, error) {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return nil, ErrRangeNotInspectable
+	}
+	return c, nil
+}
@@ -0,0 +1,112 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConstraintsSets(t *testing.T) {
+	tests := []struct {
+		s    string
+		sets string
+	}{
+		{"1.2.3", "[[1.2.3]]"},
+		{"^1.2", "[[>=1.2.0 <2.0.0]]"},
+		{">=1.0.0 !=1.5.0 || 3.x", "[[>=1.0.0 !=1.5.0] [>=3.0.0 <4.0.0]]"},
+		{"<=1.0.0-beta.1 || >2.0.0", "[[<=1.0.0-beta.1] [>2.0.0]]"},
+	}
+	for _, tc := range tests {
+		c := MustParseConstraints(tc.s)
+		sets := c.Sets()
+		if s := fmt.Sprint(sets); s != tc.sets {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.sets, s)
+		}
+		rebuilt, err := NewConstraints(sets, RangeOptions{NPMCompat: c.NPMCompat()})
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.s, err)
+		} else if rebuilt.String() != c.String() {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, c, rebuilt)
+		}
+	}
+
+	// The result is a copy
+	c := MustParseConstraints(">=1.0.0")
+	c.Sets()[0][0].Op = OpLT
+	if s := c.String(); s != ">=1.0.0" {
+		t.Errorf("Sets did not return a copy: %q", s)
+	}
+}
+
+func TestConstraintsSetsNPMCompat(t *testing.T) {
+	// The prerelease rule of NPMCompat survives the round trip
+	tests := []struct {
+		opts RangeOptions
+		npm  bool
+	}{
+		{RangeOptions{}, false},
+		{RangeOptions{NPMCompat: true}, true},
+		{RangeOptions{NPMCompat: true, IncludePrerelease: true}, false},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions("^1.2.3", tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.NPMCompat() != tc.npm {
+			t.Errorf("Invalid for case %+v: Expected NPMCompat %t, got: %t", tc.opts, tc.npm, c.NPMCompat())
+		}
+		rebuilt, err := NewConstraints(c.Sets(), RangeOptions{NPMCompat: c.NPMCompat()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := MustParse("1.5.0-beta.1"); rebuilt.Check(v) != c.Check(v) {
+			t.Errorf("Invalid for case %+v: Expected %t for %q, got: %t", tc.opts, c.Check(v), v, rebuilt.Check(v))
+		}
+	}
+}
+
+func TestNewConstraints(t *testing.T) {
+	c, err := NewConstraints([][]Comparator{
+		{{Op: OpGTE, Version: MustParse("1.2.0")}, {Op: OpLT, Version: MustParse("2.0.0")}},
+		{{Op: OpEQ, Version: MustParse("3.0.0")}},
+	}, RangeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := c.String(); s != ">=1.2.0 <2.0.0 || 3.0.0" {
+		t.Errorf("Expected %q, got: %q", ">=1.2.0 <2.0.0 || 3.0.0", s)
+	}
+	r := c.Range()
+	for v, expected := range map[string]bool{"1.5.0": true, "2.0.0": false, "3.0.0": true} {
+		if r(MustParse(v)) != expected {
+			t.Errorf("Invalid for case %q: Expected %t", v, expected)
+		}
+	}
+
+	invalid := [][][]Comparator{
+		nil,
+		{{}},
+		{{{Op: Operator(-1), Version: MustParse("1.2.3")}}},
+		{{{Op: OpLTE + 1, Version: MustParse("1.2.3")}}},
+		{{{Op: OpGTE, Version: Version{Major: 1, Pre: []PRVersion{{VersionStr: "a b"}}}}}},
+	}
+	for _, sets := range invalid {
+		if _, err := NewConstraints(sets, RangeOptions{}); err == nil {
+			t.Errorf("Expected error for %v", sets)
+		}
+	}
+}
+
+func TestExportedConstraintsOf(t *testing.T) {
+	r := MustParseRange(">=1.0.0 <2.0.0").OR(MustParseRange(">=3.0.0"))
+	c, err := ConstraintsOf(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := c.String(); s != ">=1.0.0 <2.0.0 || >=3.0.0" {
+		t.Errorf("Expected %q, got: %q", ">=1.0.0 <2.0.0 || >=3.0.0", s)
+	}
+	if _, err := ConstraintsOf(func(Version) bool { return true }); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package semver
+
+import "fmt"
+
+// RangeBetween returns an idiomatic range string matching the versions
+// between min and max, e.g. for tools generating manifests from concrete
+// bounds. If the bounds allow it, the shorthand of style is used: "^1.2.3"
+// for >=1.2.3 <2.0.0 and "^0.2.3" for >=0.2.3 <0.3.0 with StyleCaret,
+// "~1.2.3" for >=1.2.3 <1.3.0 with StyleTilde. Otherwise an inclusive min
+// and max become a hyphen range "1.2.3 - 1.5.0", all other bounds plain
+// comparators. Equal bounds which are both included yield the exact
+// version. It is an error if no version lies between the bounds.
+func RangeBetween(min, max Version, includeMin, includeMax bool, style ConstraintStyle) (string, error) {
+	min = Version{Major: min.Major, Minor: min.Minor, Patch: min.Patch, Pre: min.Pre}
+	max = Version{Major: max.Major, Minor: max.Minor, Patch: max.Patch, Pre: max.Pre}
+
+	switch c := min.Compare(max); {
+	case c > 0, c == 0 && !(includeMin && includeMax):
+		return "", fmt.Errorf("no version between %s and %s", min, max)
+	case c == 0:
+		return min.String(), nil
+	}
+
+	if includeMin && !includeMax {
+		switch style {
+		case StyleCaret:
+			if upper, err := caretUpperBound(min, 3); err == nil && max.Equals(upper) {
+				return "^" + min.String(), nil
+			}
+		case StyleTilde:
+			if max.Equals(Version{Major: min.Major, Minor: min.Minor + 1}) && max.Minor != 0 {
+				return "~" + min.String(), nil
+			}
+		}
+	}
+	if includeMin && includeMax {
+		return min.String() + " - " + max.String(), nil
+	}
+
+	lower, upper := ">", "<"
+	if includeMin {
+		lower = ">="
+	}
+	if includeMax {
+		upper = "<="
+	}
+	return lower + min.String() + " " + upper + max.String(), nil
+}
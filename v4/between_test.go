@@ -0,0 +1,48 @@
+package semver
+
+import "testing"
+
+func TestRangeBetween(t *testing.T) {
+	tests := []struct {
+		min, max               string
+		includeMin, includeMax bool
+		style                  ConstraintStyle
+		r                      string
+	}{
+		{"1.2.3", "2.0.0", true, false, StyleCaret, "^1.2.3"},
+		{"1.2.3", "2.0.0", true, false, StyleTilde, ">=1.2.3 <2.0.0"},
+		{"1.2.3", "1.3.0", true, false, StyleTilde, "~1.2.3"},
+		{"1.2.3", "1.3.0", true, false, StyleCaret, ">=1.2.3 <1.3.0"},
+		{"1.2.3-beta.1", "2.0.0", true, false, StyleCaret, "^1.2.3-beta.1"},
+		{"0.2.3", "0.3.0", true, false, StyleCaret, "^0.2.3"},
+		{"0.0.3", "0.0.4", true, false, StyleCaret, "^0.0.3"},
+		{"0.2.3", "1.0.0", true, false, StyleCaret, ">=0.2.3 <1.0.0"},
+		{"1.2.3-beta.1", "1.5.0", true, false, StyleCaret, ">=1.2.3-beta.1 <1.5.0"},
+		{"1.2.3", "2.0.0-0", true, false, StyleCaret, ">=1.2.3 <2.0.0-0"},
+		{"1.2.3", "2.0.0", true, true, StyleCaret, "1.2.3 - 2.0.0"},
+		{"1.2.3-beta.1", "1.5.0", true, true, StyleCaret, "1.2.3-beta.1 - 1.5.0"},
+		{"1.2.3", "2.0.0", false, false, StyleExact, ">1.2.3 <2.0.0"},
+		{"1.2.3+build", "1.2.3", true, true, StyleExact, "1.2.3"},
+	}
+	for _, tc := range tests {
+		min, max := MustParse(tc.min), MustParse(tc.max)
+		r, err := RangeBetween(min, max, tc.includeMin, tc.includeMax, tc.style)
+		if err != nil {
+			t.Errorf("Invalid for case %q %q: %s", tc.min, tc.max, err)
+			continue
+		}
+		if r != tc.r {
+			t.Errorf("Invalid for case %q %q: Expected %q, got: %q", tc.min, tc.max, tc.r, r)
+		}
+		rng := MustParseRange(r)
+		if rng(min) != tc.includeMin || rng(max) != tc.includeMax {
+			t.Errorf("Invalid for case %q %q: %q does not match the bounds", tc.min, tc.max, r)
+		}
+	}
+
+	for _, tc := range []struct{ min, max string }{{"2.0.0", "1.0.0"}, {"1.0.0", "1.0.0"}} {
+		if r, err := RangeBetween(MustParse(tc.min), MustParse(tc.max), true, false, StyleCaret); err == nil {
+			t.Errorf("Expected error for case %q %q, got: %q", tc.min, tc.max, r)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package semver
+
+// BoundaryVersions returns the versions at the bounds of r and their nearest
+// neighbors in ascending order, so that test generators can target off-by-one
+// bugs of comparators: "^1.2.3" yields 1.2.2, 1.2.3, 1.2.4,
+// 1.18446744073709551615.18446744073709551615, 2.0.0 and 2.0.1. The
+// neighbors of a bound are the greatest release below it and the least
+// release above it, a prerelease bound is also followed by its immediate
+// successor, e.g. 1.0.0-beta.0 for 1.0.0-beta. Build meta data is dropped.
+// A half-bounded range like ">=1.2.3" yields the versions around its one
+// bound. The result is nil only if r has no finite bound, e.g. if r is
+// empty, or if r can not be inspected.
+func BoundaryVersions(r Range) []Version {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return nil
+	}
+	var versions []Version
+	addBound := func(b Endpoint) {
+		if b.Unbounded {
+			return
+		}
+		v := withoutBuild(b.Version)
+		if prev, ok := PrevVersion(v, ReleasePatch); ok {
+			versions = append(versions, prev)
+		}
+		versions = append(versions, v)
+		if len(v.Pre) > 0 {
+			next := v
+			next.Pre = append(v.Pre[:len(v.Pre):len(v.Pre)], PRVersion{VersionNum: 0, IsNum: true})
+			versions = append(versions, next)
+		}
+		if next, ok := nextVersion(v, ReleasePatch); ok {
+			versions = append(versions, next)
+		}
+	}
+	for _, i := range c.intervals() {
+		addBound(i.Lower)
+		addBound(i.Upper)
+	}
+	Sort(versions)
+
+	var unique []Version
+	for _, v := range versions {
+		if n := len(unique); n == 0 || !unique[n-1].Equals(v) {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,29 @@
+package semver
+
+import "testing"
+
+func TestBoundaryVersions(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{"^1.2.3", "[1.2.2 1.2.3 1.2.4 1.18446744073709551615.18446744073709551615 2.0.0 2.0.1]"},
+		{"<0.1.0", "[0.0.18446744073709551615 0.1.0 0.1.1]"},
+		{">=0.0.0", "[0.0.0 0.0.1]"},
+		{">=1.2.3", "[1.2.2 1.2.3 1.2.4]"},
+		{"<2.0.0", "[1.18446744073709551615.18446744073709551615 2.0.0 2.0.1]"},
+		{"1.0.0-beta", "[0.18446744073709551615.18446744073709551615 1.0.0-beta 1.0.0-beta.0 1.0.0]"},
+		{"1.0.0 || 1.0.1", "[0.18446744073709551615.18446744073709551615 1.0.0 1.0.1 1.0.2]"},
+		{"*", "[0.0.0 0.0.1]"},
+		{"!=1.2.3", "[1.2.2 1.2.3 1.2.4]"},
+		{">2.0.0 <1.0.0", "[]"},
+	}
+	for _, tc := range tests {
+		if s := fmtVersions(BoundaryVersions(MustParseRange(tc.r))); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, s)
+		}
+	}
+	if versions := BoundaryVersions(func(Version) bool { return true }); versions != nil {
+		t.Errorf("Expected nil, got: %q", versions)
+	}
+}
@@ -0,0 +1,73 @@
+package semver
+
+// BuildPair is a key/value pair encoded in build meta data as two
+// consecutive identifiers, e.g. "sha.abc123".
+type BuildPair struct {
+	Key   string
+	Value string
+}
+
+// SameBuild checks if a and b are equal including their build meta data.
+// Compare ignores build meta data, SameBuild can be used to verify that two
+// versions were produced by the same (reproducible) build.
+func SameBuild(a, b Version) bool {
+	if a.Compare(b) != 0 || len(a.Build) != len(b.Build) {
+		return false
+	}
+	for i := range a.Build {
+		if a.Build[i] != b.Build[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildPairs interprets the build meta data of v as consecutive key/value
+// identifiers, e.g. "1.0.0+sha.abc123.date.20240101" yields sha=abc123 and
+// date=20240101. ok is false if the build meta data is empty or has an odd
+// number of identifiers.
+func (v Version) BuildPairs() (pairs []BuildPair, ok bool) {
+	if len(v.Build) == 0 || len(v.Build)%2 != 0 {
+		return nil, false
+	}
+	pairs = make([]BuildPair, 0, len(v.Build)/2)
+	for i := 0; i < len(v.Build); i += 2 {
+		pairs = append(pairs, BuildPair{Key: v.Build[i], Value: v.Build[i+1]})
+	}
+	return pairs, true
+}
+
+// BuildMetadata returns a copy of the build meta data identifiers of v.
+func (v Version) BuildMetadata() []string {
+	if len(v.Build) == 0 {
+		return nil
+	}
+	build := make([]string, len(v.Build))
+	copy(build, v.Build)
+	return build
+}
+
+// HasBuildTag checks if key is one of the build meta data identifiers of v,
+// e.g. "1.0.0+dirty" has the build tag "dirty".
+func (v Version) HasBuildTag(key string) bool {
+	for _, build := range v.Build {
+		if build == key {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildValue returns the value of key in the build meta data of v read as
+// key/value pairs like BuildPairs: "1.0.0+sha.5114f85.date.20240101" has the
+// value "5114f85" for key "sha". ok is false if the key is missing or the
+// build meta data are not key/value pairs, like "1.0.0+exp.sha.5114f85".
+func (v Version) BuildValue(key string) (value string, ok bool) {
+	pairs, _ := v.BuildPairs()
+	for _, p := range pairs {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSameBuild(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"1.0.0", "1.0.0", true},
+		{"1.0.0+sha.abc123", "1.0.0+sha.abc123", true},
+		{"1.0.0+sha.abc123", "1.0.0+sha.def456", false},
+		{"1.0.0+sha.abc123", "1.0.0", false},
+		{"1.0.0+sha", "1.0.0+sha.abc123", false},
+		{"1.0.0-beta+sha.abc123", "1.0.0+sha.abc123", false},
+	}
+	for _, tc := range tests {
+		if same := SameBuild(MustParse(tc.a), MustParse(tc.b)); same != tc.same {
+			t.Errorf("SameBuild(%q, %q): expected %t, got %t", tc.a, tc.b, tc.same, same)
+		}
+	}
+}
+
+func TestBuildPairs(t *testing.T) {
+	tests := []struct {
+		v     string
+		pairs []BuildPair
+		ok    bool
+	}{
+		{"1.0.0+sha.abc123.date.20240101", []BuildPair{{"sha", "abc123"}, {"date", "20240101"}}, true},
+		{"1.0.0+sha.abc123", []BuildPair{{"sha", "abc123"}}, true},
+		{"1.0.0+sha.abc123.dirty", nil, false},
+		{"1.0.0", nil, false},
+	}
+	for _, tc := range tests {
+		pairs, ok := MustParse(tc.v).BuildPairs()
+		if ok != tc.ok || !reflect.DeepEqual(pairs, tc.pairs) {
+			t.Errorf("Invalid for case %q: Expected %v (%t), got: %v (%t)", tc.v, tc.pairs, tc.ok, pairs, ok)
+		}
+	}
+}
+
+func TestBuildMetadata(t *testing.T) {
+	v := MustParse("1.0.0+exp.sha.5114f85.dirty")
+	build := v.BuildMetadata()
+	if !reflect.DeepEqual(build, []string{"exp", "sha", "5114f85", "dirty"}) {
+		t.Errorf("Invalid build meta data: %q", build)
+	}
+	build[0] = "changed"
+	if v.Build[0] != "exp" {
+		t.Errorf("BuildMetadata must return a copy")
+	}
+	if MustParse("1.0.0").BuildMetadata() != nil {
+		t.Errorf("Expected nil build meta data")
+	}
+
+	if !v.HasBuildTag("dirty") || !v.HasBuildTag("sha") {
+		t.Errorf("Expected build tags dirty and sha in %q", v)
+	}
+	if v.HasBuildTag("clean") {
+		t.Errorf("Unexpected build tag clean in %q", v)
+	}
+
+}
+
+func TestBuildValue(t *testing.T) {
+	tests := []struct {
+		v     string
+		key   string
+		value string
+		ok    bool
+	}{
+		{"1.0.0+sha.5114f85.date.20240101", "sha", "5114f85", true},
+		{"1.0.0+sha.5114f85.date.20240101", "date", "20240101", true},
+		{"1.0.0+sha.5114f85.date.20240101", "5114f85", "", false},
+		{"1.0.0+sha.5114f85", "missing", "", false},
+		// Not key/value pairs, like for BuildPairs
+		{"1.0.0+exp.sha.5114f85", "sha", "", false},
+		{"1.0.0+exp.sha.5114f85", "exp", "", false},
+		{"1.0.0", "sha", "", false},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if value, ok := v.BuildValue(tc.key); value != tc.value || ok != tc.ok {
+			t.Errorf("Invalid for case %q key %q: Expected %q (%t), got: %q (%t)", tc.v, tc.key, tc.value, tc.ok, value, ok)
+		}
+	}
+}
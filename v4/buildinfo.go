@@ -0,0 +1,94 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// ErrDevelBuild is returned by FromBuildInfo for binaries built without a
+// module version, e.g. by "go run" or "go build" in the module's own working
+// tree, which Go reports as "(devel)".
+var ErrDevelBuild = errors.New("main module has no version (devel build)")
+
+// FromBuildInfo returns the version of the main module of bi, e.g. of
+// debug.ReadBuildInfo, so that services can report their own version. The
+// "v" prefix of the Go module version is removed. Pseudo-versions like
+// "v1.2.4-0.20240102150405-abcdef123456" are valid versions ordered between
+// their base and the next release, see ParsePseudoVersion to take them
+// apart. Build meta data like "+incompatible" or "+dirty" is kept.
+func FromBuildInfo(bi *debug.BuildInfo) (Version, error) {
+	if bi == nil {
+		return Version{}, errors.New("no build info")
+	}
+	s := bi.Main.Version
+	if s == "" || s == "(devel)" {
+		return Version{}, ErrDevelBuild
+	}
+	if !strings.HasPrefix(s, "v") {
+		return Version{}, fmt.Errorf("module version %s has no \"v\" prefix", quote(s))
+	}
+	return ParseStrict(s[1:])
+}
+
+// PseudoVersion is a Go pseudo-version taken apart, see ParsePseudoVersion.
+type PseudoVersion struct {
+	// Base is the latest tagged version before the revision, or 0.0.0 if
+	// there is none, see HasBase.
+	Base Version
+	// HasBase is false for pseudo-versions of the form
+	// "vX.0.0-yyyymmddhhmmss-abcdefabcdef".
+	HasBase bool
+	// Time is the UTC commit time of the revision.
+	Time time.Time
+	// Revision is the 12 character prefix of the commit hash.
+	Revision string
+}
+
+// pseudoTimeLayout is the layout of the commit time of pseudo-versions.
+const pseudoTimeLayout = "20060102150405"
+
+// ParsePseudoVersion takes the Go pseudo-version v apart, ok is false if v is
+// not a pseudo-version. The three forms are:
+//
+// vX.0.0-yyyymmddhhmmss-abcdefabcdef            without base
+// vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef      base vX.Y.Z-pre
+// vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef      base vX.Y.Z
+func ParsePseudoVersion(v Version) (p PseudoVersion, ok bool) {
+	n := len(v.Pre)
+	if n == 0 || v.Pre[n-1].IsNum {
+		return PseudoVersion{}, false
+	}
+	last := v.Pre[n-1].VersionStr
+	i := strings.IndexByte(last, '-')
+	if i < 0 || !isPseudoRevision(last[i+1:]) {
+		return PseudoVersion{}, false
+	}
+	t, err := time.Parse(pseudoTimeLayout, last[:i])
+	if err != nil || len(last[:i]) != len(pseudoTimeLayout) {
+		return PseudoVersion{}, false
+	}
+	p = PseudoVersion{Time: t, Revision: last[i+1:]}
+
+	switch {
+	case n == 1 && v.Minor == 0 && v.Patch == 0:
+		return p, true
+	case n == 2 && v.Pre[0].IsNum && v.Pre[0].VersionNum == 0 && v.Patch > 0:
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1}
+	case n > 2 && v.Pre[n-2].IsNum && v.Pre[n-2].VersionNum == 0:
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		p.Base.Pre = append([]PRVersion(nil), v.Pre[:n-2]...)
+	default:
+		return PseudoVersion{}, false
+	}
+	p.HasBase = true
+	return p, true
+}
+
+// isPseudoRevision checks if s is the 12 character lower case hex commit
+// hash prefix of a pseudo-version.
+func isPseudoRevision(s string) bool {
+	return len(s) == 12 && containsOnly(s, "0123456789abcdef")
+}
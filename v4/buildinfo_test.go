@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+func TestFromBuildInfo(t *testing.T) {
+	tests := []struct {
+		version string
+		v       string
+		err     bool
+	}{
+		{"v1.2.3", "1.2.3", false},
+		{"v1.2.4-0.20240102150405-abcdef123456", "1.2.4-0.20240102150405-abcdef123456", false},
+		{"v2.0.0+incompatible", "2.0.0+incompatible", false},
+		{"v1.2.3+dirty", "1.2.3+dirty", false},
+		{"1.2.3", "", true},
+		{"v1.2", "", true},
+	}
+	for _, tc := range tests {
+		v, err := FromBuildInfo(&debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: tc.version}})
+		if tc.err {
+			if err == nil {
+				t.Errorf("Invalid for case %q: Expected an error, got: %q", tc.version, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.version, err)
+		} else if v.String() != tc.v {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.version, tc.v, v)
+		}
+	}
+
+	for _, version := range []string{"", "(devel)"} {
+		if _, err := FromBuildInfo(&debug.BuildInfo{Main: debug.Module{Version: version}}); err != ErrDevelBuild {
+			t.Errorf("Invalid for case %q: Expected ErrDevelBuild, got: %v", version, err)
+		}
+	}
+	if _, err := FromBuildInfo(nil); err == nil {
+		t.Errorf("Expected an error for nil build info")
+	}
+}
+
+func TestParsePseudoVersion(t *testing.T) {
+	tests := []struct {
+		v    string
+		base string
+		ok   bool
+	}{
+		{"2.0.0-20240102150405-abcdef123456", "", true},
+		{"1.2.4-0.20240102150405-abcdef123456", "1.2.3", true},
+		{"1.2.3-rc.1.0.20240102150405-abcdef123456", "1.2.3-rc.1", true},
+		{"1.2.4-0.20240102150405-abcdef123456+incompatible", "1.2.3", true},
+		{"1.2.0-20240102150405-abcdef123456", "", false},
+		{"1.2.0-0.20240102150405-abcdef123456", "", false},
+		{"1.2.4-0.20240102150405-ABCDEF123456", "", false},
+		{"1.2.4-0.20241302150405-abcdef123456", "", false},
+		{"1.2.4-0.2024010215-abcdef123456", "", false},
+		{"1.2.4-1.20240102150405-abcdef123456", "", false},
+		{"1.2.3-rc.1", "", false},
+		{"1.2.3", "", false},
+	}
+	for _, tc := range tests {
+		p, ok := ParsePseudoVersion(MustParse(tc.v))
+		if ok != tc.ok {
+			t.Errorf("Invalid for case %q: Expected ok %t, got: %t", tc.v, tc.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if p.HasBase != (tc.base != "") || p.HasBase && p.Base.String() != tc.base {
+			t.Errorf("Invalid for case %q: Expected base %q, got: %q (%t)", tc.v, tc.base, p.Base, p.HasBase)
+		}
+		if want := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC); !p.Time.Equal(want) {
+			t.Errorf("Invalid for case %q: Expected time %s, got: %s", tc.v, want, p.Time)
+		}
+		if p.Revision != "abcdef123456" {
+			t.Errorf("Invalid for case %q: Expected revision %q, got: %q", tc.v, "abcdef123456", p.Revision)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package semver
+
+import (
+	"runtime"
+	"sync"
+)
+
+// minShardSize is the minimal number of versions parsed by a single
+// goroutine in ParseAll, smaller inputs don't benefit from sharding.
+const minShardSize = 1024
+
+// ParseAll parses all version strings, sharding the work across up to
+// parallelism goroutines (GOMAXPROCS if parallelism <= 0). versions[i] is
+// the parsed version of ss[i]. errs is nil if all versions were parsed,
+// otherwise errs[i] is the error of ss[i] or nil. Every goroutine writes its
+// results directly into the returned slices, so no intermediate buffers are
+// allocated.
+func ParseAll(ss []string, parallelism int) (versions []Version, errs []error) {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	if max := (len(ss) + minShardSize - 1) / minShardSize; parallelism > max {
+		parallelism = max
+	}
+	versions = make([]Version, len(ss))
+	errs = make([]error, len(ss))
+	if len(ss) == 0 {
+		return versions, nil
+	}
+
+	failed := make([]bool, parallelism)
+	shard := (len(ss) + parallelism - 1) / parallelism
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		start, end := w*shard, (w+1)*shard
+		if end > len(ss) {
+			end = len(ss)
+		}
+		wg.Add(1)
+		go func(w, start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				if versions[i], errs[i] = Parse(ss[i]); errs[i] != nil {
+					failed[w] = true
+				}
+			}
+		}(w, start, end)
+	}
+	wg.Wait()
+
+	for _, f := range failed {
+		if f {
+			return versions, errs
+		}
+	}
+	return versions, nil
+}
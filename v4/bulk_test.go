@@ -0,0 +1,63 @@
+package semver
+
+import (
+	"strconv"
+	"testing"
+)
+
+func bulkVersions(n int) []string {
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = "1." + strconv.Itoa(i%100) + "." + strconv.Itoa(i) + "-beta.1+build." + strconv.Itoa(i)
+	}
+	return ss
+}
+
+func TestParseAll(t *testing.T) {
+	ss := bulkVersions(5000)
+	for _, parallelism := range []int{0, 1, 3, 16} {
+		versions, errs := ParseAll(ss, parallelism)
+		if errs != nil {
+			t.Fatalf("Unexpected errors with parallelism %d", parallelism)
+		}
+		if len(versions) != len(ss) {
+			t.Fatalf("Expected %d versions, got %d", len(ss), len(versions))
+		}
+		for i, v := range versions {
+			if v.String() != ss[i] {
+				t.Fatalf("Expected %q at %d, got %q", ss[i], i, v)
+			}
+		}
+	}
+
+	ss[4321] = "invalid"
+	versions, errs := ParseAll(ss, 4)
+	if len(errs) != len(ss) || errs[4321] == nil || errs[0] != nil {
+		t.Fatalf("Expected error for invalid version only")
+	}
+	if versions[4322].String() != ss[4322] {
+		t.Errorf("Expected %q, got %q", ss[4322], versions[4322])
+	}
+
+	if versions, errs := ParseAll(nil, 4); len(versions) != 0 || errs != nil {
+		t.Errorf("Unexpected result for empty input")
+	}
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	ss := bulkVersions(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = ParseAll(ss, 0)
+	}
+}
+
+func BenchmarkParseAllSequential(b *testing.B) {
+	ss := bulkVersions(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = ParseAll(ss, 1)
+	}
+}
@@ -0,0 +1,162 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// BumpType is a kind of version increment of Bump, named like the release
+// types of `npm version`.
+type BumpType int
+
+const (
+	// BumpMajor increments the major version, 1.2.3 becomes 2.0.0. A
+	// prerelease of a major version becomes its release, 2.0.0-rc.1
+	// becomes 2.0.0.
+	BumpMajor BumpType = iota
+	// BumpMinor increments the minor version, 1.2.3 becomes 1.3.0. A
+	// prerelease of a minor version becomes its release.
+	BumpMinor
+	// BumpPatch increments the patch version, 1.2.3 becomes 1.2.4. A
+	// prerelease becomes its release.
+	BumpPatch
+	// BumpPremajor increments the major version and starts a prerelease,
+	// 1.2.3 becomes 2.0.0-0.
+	BumpPremajor
+	// BumpPreminor increments the minor version and starts a prerelease,
+	// 1.2.3 becomes 1.3.0-0.
+	BumpPreminor
+	// BumpPrepatch increments the patch version and starts a prerelease,
+	// 1.2.3 becomes 1.2.4-0.
+	BumpPrepatch
+	// BumpPrerelease increments the last numeric prerelease identifier,
+	// 1.2.3-beta.1 becomes 1.2.3-beta.2. A release starts a prerelease of
+	// the next patch version like BumpPrepatch.
+	BumpPrerelease
+)
+
+func (t BumpType) String() string {
+	switch t {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	case BumpPatch:
+		return "patch"
+	case BumpPremajor:
+		return "premajor"
+	case BumpPreminor:
+		return "preminor"
+	case BumpPrepatch:
+		return "prepatch"
+	case BumpPrerelease:
+		return "prerelease"
+	}
+	return fmt.Sprintf("BumpType(%d)", int(t))
+}
+
+// Bump returns the version following v by the increment t, like
+// `npm version`. Lower components are reset and build meta data is
+// dropped, v is not modified.
+//
+// The prerelease of the pre increments is started on the channel
+// identifier, e.g. "beta" makes 1.2.3 1.2.4-beta.0 with BumpPrepatch. With
+// BumpPrerelease, a prerelease on the channel is continued, 1.2.3-beta.1
+// becomes 1.2.3-beta.2, and a prerelease on another channel is restarted,
+// 1.2.3-alpha.4 becomes 1.2.3-beta.0. An empty identifier yields plain
+// counters like 1.2.4-0, a dotted identifier like "beta.x" is a channel of
+// several identifiers.
+//
+// It is an error if the identifier is not valid or a number overflows.
+func (v Version) Bump(t BumpType, identifier string) (Version, error) {
+	var channel []PRVersion
+	if identifier != "" {
+		for _, s := range strings.Split(identifier, ".") {
+			pr, err := NewPRVersion(s)
+			if err != nil {
+				return Version{}, err
+			}
+			channel = append(channel, pr)
+		}
+	}
+
+	next := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	var err error
+	switch t {
+	case BumpMajor:
+		if v.Minor != 0 || v.Patch != 0 || len(v.Pre) == 0 {
+			err = next.IncrementMajor()
+		}
+		return next, err
+	case BumpMinor:
+		if v.Patch != 0 || len(v.Pre) == 0 {
+			err = next.IncrementMinor()
+		}
+		return next, err
+	case BumpPatch:
+		if len(v.Pre) == 0 {
+			err = next.IncrementPatch()
+		}
+		return next, err
+	case BumpPremajor:
+		err = next.IncrementMajor()
+	case BumpPreminor:
+		err = next.IncrementMinor()
+	case BumpPrepatch:
+		err = next.IncrementPatch()
+	case BumpPrerelease:
+		if len(v.Pre) == 0 {
+			err = next.IncrementPatch()
+		} else {
+			next.Pre = append([]PRVersion(nil), v.Pre...)
+		}
+	default:
+		return Version{}, fmt.Errorf("invalid bump type %s", t)
+	}
+	if err != nil {
+		return Version{}, err
+	}
+	if err := bumpPrerelease(&next, channel); err != nil {
+		return Version{}, err
+	}
+	return next, nil
+}
+
+// IncrementPrerelease increments the prerelease of v on the channel
+// identifier like Bump with BumpPrerelease, e.g. 1.2.3-beta.1 becomes
+// 1.2.3-beta.2 and 1.2.3 becomes 1.2.4-beta.0 with "beta". Build meta data
+// is dropped. On error v is left unchanged.
+func (v *Version) IncrementPrerelease(identifier string) error {
+	next, err := v.Bump(BumpPrerelease, identifier)
+	if err != nil {
+		return err
+	}
+	*v = next
+	return nil
+}
+
+// bumpPrerelease increments the last numeric prerelease identifier of v,
+// or appends a counter 0, and moves it to channel if it is not on channel.
+func bumpPrerelease(v *Version, channel []PRVersion) error {
+	incremented := false
+	for i := len(v.Pre) - 1; i >= 0; i-- {
+		if v.Pre[i].IsNum {
+			if v.Pre[i].VersionNum == math.MaxUint64 {
+				return &OverflowError{Component: "prerelease"}
+			}
+			v.Pre[i].VersionNum++
+			incremented = true
+			break
+		}
+	}
+	if !incremented {
+		v.Pre = append(v.Pre, PRVersion{IsNum: true})
+	}
+
+	onChannel := len(v.Pre) > len(channel) && samePrerelease(v.Pre[:len(channel)], channel) && v.Pre[len(channel)].IsNum
+	if len(channel) > 0 && !onChannel {
+		v.Pre = append(append([]PRVersion(nil), channel...), PRVersion{IsNum: true})
+	}
+	return nil
+}
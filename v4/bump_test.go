@@ -0,0 +1,98 @@
+package semver
+
+import "testing"
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		v          string
+		t          BumpType
+		identifier string
+		expected   string
+	}{
+		{"1.2.3", BumpMajor, "", "2.0.0"},
+		{"1.2.3-rc.1+build", BumpMajor, "", "2.0.0"},
+		{"2.0.0-rc.1", BumpMajor, "", "2.0.0"},
+		{"1.2.3", BumpMinor, "", "1.3.0"},
+		{"1.3.0-rc.1", BumpMinor, "", "1.3.0"},
+		{"1.2.3-rc.1", BumpMinor, "", "1.3.0"},
+		{"1.2.3", BumpPatch, "", "1.2.4"},
+		{"1.2.3-rc.1", BumpPatch, "", "1.2.3"},
+		{"1.2.3+build", BumpPatch, "", "1.2.4"},
+		{"1.2.3", BumpPremajor, "", "2.0.0-0"},
+		{"1.2.3", BumpPremajor, "alpha", "2.0.0-alpha.0"},
+		{"1.2.3", BumpPreminor, "beta", "1.3.0-beta.0"},
+		{"1.2.3", BumpPrepatch, "rc", "1.2.4-rc.0"},
+		{"1.2.3-rc.4", BumpPrepatch, "", "1.2.4-0"},
+		{"1.2.3", BumpPrerelease, "", "1.2.4-0"},
+		{"1.2.3", BumpPrerelease, "beta", "1.2.4-beta.0"},
+		{"1.2.3-0", BumpPrerelease, "", "1.2.3-1"},
+		{"1.2.3-beta.1", BumpPrerelease, "", "1.2.3-beta.2"},
+		{"1.2.3-beta.1", BumpPrerelease, "beta", "1.2.3-beta.2"},
+		{"1.2.3-alpha.4", BumpPrerelease, "beta", "1.2.3-beta.0"},
+		{"1.2.3-beta", BumpPrerelease, "", "1.2.3-beta.0"},
+		{"1.2.3-beta", BumpPrerelease, "beta", "1.2.3-beta.0"},
+		{"1.2.3-beta.1.x", BumpPrerelease, "", "1.2.3-beta.2.x"},
+		{"1.2.3-beta.x.1", BumpPrerelease, "beta.x", "1.2.3-beta.x.2"},
+		{"1.2.3-beta.1", BumpPrerelease, "beta.x", "1.2.3-beta.x.0"},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		next, err := v.Bump(tc.t, tc.identifier)
+		if err != nil {
+			t.Errorf("Invalid for case %q %s %q: Unexpected error: %s", tc.v, tc.t, tc.identifier, err)
+			continue
+		}
+		if next.String() != tc.expected {
+			t.Errorf("Invalid for case %q %s %q: Expected %q, got: %q", tc.v, tc.t, tc.identifier, tc.expected, next)
+		}
+		if v.String() != tc.v {
+			t.Errorf("Invalid for case %q %s %q: Modified to %q", tc.v, tc.t, tc.identifier, v)
+		}
+	}
+}
+
+func TestBumpInvalid(t *testing.T) {
+	tests := []struct {
+		v          string
+		t          BumpType
+		identifier string
+	}{
+		{"1.2.3", BumpPrerelease, "01"},
+		{"1.2.3", BumpPrerelease, "beta..1"},
+		{"1.2.3", BumpPremajor, "be$ta"},
+		{"18446744073709551615.0.0", BumpMajor, ""},
+		{"1.2.18446744073709551615", BumpPrerelease, ""},
+		{"1.2.3-18446744073709551615", BumpPrerelease, ""},
+		{"1.2.3", BumpType(42), ""},
+	}
+	for _, tc := range tests {
+		if next, err := MustParse(tc.v).Bump(tc.t, tc.identifier); err == nil {
+			t.Errorf("Invalid for case %q %s %q: Expected an error, got: %q", tc.v, tc.t, tc.identifier, next)
+		}
+	}
+}
+
+func TestIncrementPrerelease(t *testing.T) {
+	v := MustParse("1.2.3-beta.1+build")
+	if err := v.IncrementPrerelease("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "1.2.3-beta.2" {
+		t.Errorf("Expected %q, got: %q", "1.2.3-beta.2", v)
+	}
+	if err := v.IncrementPrerelease("01"); err == nil {
+		t.Errorf("Expected an error")
+	}
+	if v.String() != "1.2.3-beta.2" {
+		t.Errorf("Expected v to be unchanged, got: %q", v)
+	}
+}
+
+func TestBumpTypeString(t *testing.T) {
+	if s := BumpPrepatch.String(); s != "prepatch" {
+		t.Errorf("Expected %q, got: %q", "prepatch", s)
+	}
+	if s := BumpType(42).String(); s != "BumpType(42)" {
+		t.Errorf("Expected %q, got: %q", "BumpType(42)", s)
+	}
+}
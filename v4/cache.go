@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// rangeCacheMagic starts the RangeCache snapshot format, the last byte is
+// the format version.
+const rangeCacheMagic = "SRC\x01"
+
+// RangeCache caches parsed ranges by their string, e.g. for package managers
+// parsing the same constraints thousands of times across a dependency graph.
+// It keeps the most recently used ranges up to its size. Ranges which fail
+// to parse are not cached. A RangeCache is safe for concurrent use.
+type RangeCache struct {
+	opts RangeOptions
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List // of *rangeCacheEntry, most recently used first
+}
+
+type rangeCacheEntry struct {
+	s string
+	c *Constraints
+	r Range
+}
+
+// NewRangeCache creates a RangeCache of at most size ranges, parsed with
+// opts like ParseRangeWithOptions. It panics if size is less than 1.
+func NewRangeCache(size int, opts RangeOptions) *RangeCache {
+	if size < 1 {
+		panic(fmt.Sprintf("semver: invalid RangeCache size %d", size))
+	}
+	return &RangeCache{opts: opts, size: size, entries: make(map[string]*list.Element)}
+}
+
+// ParseRange returns the cached Range of s, parsing and caching it first if
+// it is not cached.
+func (rc *RangeCache) ParseRange(s string) (Range, error) {
+	rc.mu.Lock()
+	if e, ok := rc.entries[s]; ok {
+		rc.lru.MoveToFront(e)
+		r := e.Value.(*rangeCacheEntry).r
+		rc.mu.Unlock()
+		return r, nil
+	}
+	rc.mu.Unlock()
+
+	// Parse outside of the lock, concurrent misses of the same range may
+	// parse it more than once
+	c, err := ParseConstraintsWithOptions(s, rc.opts)
+	if err != nil {
+		return nil, err
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.add(s, c), nil
+}
+
+// add caches c as the range of s and returns its Range, rc.mu must be held.
+func (rc *RangeCache) add(s string, c *Constraints) Range {
+	if e, ok := rc.entries[s]; ok {
+		rc.lru.MoveToFront(e)
+		return e.Value.(*rangeCacheEntry).r
+	}
+	entry := &rangeCacheEntry{s: s, c: c, r: c.Range()}
+	rc.entries[s] = rc.lru.PushFront(entry)
+	if rc.lru.Len() > rc.size {
+		oldest := rc.lru.Back()
+		rc.lru.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*rangeCacheEntry).s)
+	}
+	return entry.r
+}
+
+// Len returns the number of cached ranges.
+func (rc *RangeCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.lru.Len()
+}
+
+// rangeCacheFlags encodes the options which affect parsing.
+func rangeCacheFlags(opts RangeOptions) byte {
+	var flags byte
+	for i, set := range []bool{opts.Tolerant, opts.NPMCompat, opts.IncludePrerelease, opts.SemVerOnly} {
+		if set {
+			flags |= 1 << i
+		}
+	}
+	if opts.Loose {
+		flags |= 1 << 6
+	}
+	return flags | byte(opts.Precedence)<<4
+}
+
+// Snapshot writes the cached ranges to w, so that a restarted process can
+// Restore a warm cache without parsing.
+//
+// The format is the magic "SRC" followed by the format version 1, a byte
+// encoding the options of the cache, the number of ranges, and per range
+// from the least to the most recently used its string and its comparators
+// in the format of MarshalCompiled, all lengths as uvarints.
+func (rc *RangeCache) Snapshot(w io.Writer) error {
+	rc.mu.Lock()
+	b := append([]byte(rangeCacheMagic), rangeCacheFlags(rc.opts))
+	b = appendUvarint(b, uint64(rc.lru.Len()))
+	for e := rc.lru.Back(); e != nil; e = e.Prev() {
+		entry := e.Value.(*rangeCacheEntry)
+		b = appendString(b, entry.s)
+		b = appendString(b, string(entry.c.appendCompiled(nil)))
+	}
+	rc.mu.Unlock()
+	_, err := w.Write(b)
+	return err
+}
+
+// Restore adds the ranges of a snapshot written by Snapshot to the cache as
+// the most recently used ones. The snapshot must have been written by a
+// cache with the same options. The cache is unchanged if the snapshot is
+// invalid.
+func (rc *RangeCache) Restore(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(data) < len(rangeCacheMagic) || string(data[:len(rangeCacheMagic)-1]) != rangeCacheMagic[:len(rangeCacheMagic)-1] {
+		return errors.New("not a range cache snapshot")
+	}
+	if data[len(rangeCacheMagic)-1] != rangeCacheMagic[len(rangeCacheMagic)-1] {
+		return fmt.Errorf("unsupported range cache snapshot format version %d", data[len(rangeCacheMagic)-1])
+	}
+	d := compiledDecoder{data: data[len(rangeCacheMagic):]}
+	if flags := d.byte(); d.err == nil && flags != rangeCacheFlags(rc.opts) {
+		return errors.New("range cache snapshot was written with different options")
+	}
+	var entries []rangeCacheEntry
+	for i, n := 0, d.count(); i < n && d.err == nil; i++ {
+		s, compiled := d.string(), d.string()
+		if d.err != nil {
+			break
+		}
+		c, err := unmarshalCompiledConstraints([]byte(compiled))
+		if err != nil {
+			return fmt.Errorf("range %s: %w", quote(s), err)
+		}
+		entries = append(entries, rangeCacheEntry{s: s, c: c})
+	}
+	if d.err == nil && len(d.data) > 0 {
+		d.err = fmt.Errorf("range cache snapshot has %d trailing bytes", len(d.data))
+	}
+	if d.err == errCompiledTruncated {
+		return errors.New("range cache snapshot is truncated")
+	}
+	if d.err != nil {
+		return d.err
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, e := range entries {
+		rc.add(e.s, e.c)
+	}
+	return nil
+}
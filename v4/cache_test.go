@@ -0,0 +1,164 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRangeCache(t *testing.T) {
+	rc := NewRangeCache(2, RangeOptions{})
+	r1, err := rc.ParseRange("^1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r1(MustParse("1.5.0")) || r1(MustParse("2.0.0")) {
+		t.Errorf("Unexpected range %s", r1)
+	}
+	if _, err := rc.ParseRange("~2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	// Using ^1.2.3 makes ~2.0.0 the least recently used range
+	again, _ := rc.ParseRange("^1.2.3")
+	if c1, _ := constraintsOf(r1); c1 != rc.entries["^1.2.3"].Value.(*rangeCacheEntry).c {
+		t.Errorf("Expected the cached Range")
+	}
+	if c2, _ := constraintsOf(again); c2 != rc.entries["^1.2.3"].Value.(*rangeCacheEntry).c {
+		t.Errorf("Expected the cached Range")
+	}
+	if _, err := rc.ParseRange(">=3.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if rc.Len() != 2 {
+		t.Errorf("Expected 2 cached ranges, got: %d", rc.Len())
+	}
+	if _, ok := rc.entries["~2.0.0"]; ok {
+		t.Errorf("Expected ~2.0.0 to be evicted")
+	}
+	if _, ok := rc.entries["^1.2.3"]; !ok {
+		t.Errorf("Expected ^1.2.3 to be cached")
+	}
+
+	if _, err := rc.ParseRange(">=a"); err == nil {
+		t.Errorf("Expected an error")
+	}
+	if rc.Len() != 2 {
+		t.Errorf("Expected errors not to be cached, got: %d ranges", rc.Len())
+	}
+}
+
+func TestRangeCacheOptions(t *testing.T) {
+	rc := NewRangeCache(8, RangeOptions{NPMCompat: true})
+	r, err := rc.ParseRange("^1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r(MustParse("1.5.0-beta")) {
+		t.Errorf("Expected the NPMCompat prerelease rule")
+	}
+}
+
+func TestRangeCacheConcurrent(t *testing.T) {
+	rc := NewRangeCache(16, RangeOptions{})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				s := fmt.Sprintf("^%d.%d.0", (g+i)%32+1, i%3)
+				r, err := rc.ParseRange(s)
+				if err != nil || !r(MustParse(fmt.Sprintf("%d.%d.1", (g+i)%32+1, i%3))) {
+					t.Errorf("Invalid for case %q: %v", s, err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if rc.Len() > 16 {
+		t.Errorf("Expected at most 16 cached ranges, got: %d", rc.Len())
+	}
+}
+
+func TestRangeCacheSnapshot(t *testing.T) {
+	rc := NewRangeCache(4, RangeOptions{})
+	for _, s := range []string{"^1.2.3", "1.x || >=3.0.0-beta", "!=2.0.0"} {
+		if _, err := rc.ParseRange(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := rc.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := buf.Bytes()
+
+	restored := NewRangeCache(4, RangeOptions{})
+	if err := restored.Restore(bytes.NewReader(snapshot)); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Len() != 3 {
+		t.Errorf("Expected 3 restored ranges, got: %d", restored.Len())
+	}
+	for s, e := range rc.entries {
+		re, ok := restored.entries[s]
+		if !ok {
+			t.Errorf("Invalid for case %q: Not restored", s)
+			continue
+		}
+		if want, got := e.Value.(*rangeCacheEntry).c.String(), re.Value.(*rangeCacheEntry).c.String(); want != got {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", s, want, got)
+		}
+	}
+	// The order of use is restored: ^1.2.3 is evicted first
+	if _, err := restored.ParseRange("1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := restored.ParseRange("2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := restored.entries["^1.2.3"]; ok {
+		t.Errorf("Expected ^1.2.3 to be evicted")
+	}
+
+	for _, opts := range []RangeOptions{{NPMCompat: true}, {Precedence: PrecedenceOR}} {
+		if err := NewRangeCache(4, opts).Restore(bytes.NewReader(snapshot)); err == nil {
+			t.Errorf("Expected an error for different options %+v", opts)
+		}
+	}
+	invalid := []string{
+		"",
+		"SRC\x02",
+		string(snapshot[:len(snapshot)-1]),
+		string(snapshot) + "x",
+	}
+	for _, s := range invalid {
+		rc := NewRangeCache(4, RangeOptions{})
+		if err := rc.Restore(strings.NewReader(s)); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error", s)
+		}
+		if rc.Len() != 0 {
+			t.Errorf("Invalid for case %q: Expected an unchanged cache", s)
+		}
+	}
+}
+
+func TestNewRangeCacheInvalidSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked")
+		}
+	}()
+	NewRangeCache(0, RangeOptions{})
+}
+
+func BenchmarkRangeCacheHit(b *testing.B) {
+	rc := NewRangeCache(128, RangeOptions{})
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_, _ = rc.ParseRange("^1.2.3")
+	}
+}
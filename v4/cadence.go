@@ -0,0 +1,107 @@
+package semver
+
+import (
+	"sort"
+	"time"
+)
+
+// VersionAt is a version published at a point in time, e.g. an entry of a
+// registry's release history.
+type VersionAt struct {
+	Version Version
+	Time    time.Time
+}
+
+// CadenceStats describes the release cadence of a package. Durations are
+// zero if the timeline contains too few releases to compute them.
+type CadenceStats struct {
+	Releases int // number of releases, including prereleases
+
+	// MinorInterval is the average time between the first releases of
+	// successive minor versions, e.g. 1.2.0 and 1.3.0. Only stable releases
+	// count, patches and backports to older minor versions are ignored.
+	MinorInterval time.Duration
+	// MajorInterval is the average time between the first releases of
+	// successive major versions.
+	MajorInterval time.Duration
+	// PrereleaseLeadTime is the average time between the first prerelease of
+	// a version and its stable release, e.g. 2.0.0-rc.1 and 2.0.0, over all
+	// stable releases with prereleases.
+	PrereleaseLeadTime time.Duration
+
+	LastRelease    time.Time     // time of the latest release
+	LastReleaseAge time.Duration // time since the latest release
+}
+
+// Cadence computes release cadence statistics of timeline, e.g. for scoring
+// the health of a dependency. The order of timeline does not matter, it is
+// not modified. LastReleaseAge is relative to the current time.
+func Cadence(timeline []VersionAt) CadenceStats {
+	return cadenceAt(timeline, time.Now())
+}
+
+func cadenceAt(timeline []VersionAt, now time.Time) CadenceStats {
+	stats := CadenceStats{Releases: len(timeline)}
+	if len(timeline) == 0 {
+		return stats
+	}
+	sorted := make([]VersionAt, len(timeline))
+	copy(sorted, timeline)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	type minorKey struct{ major, minor uint64 }
+	majors := map[uint64]bool{}
+	minors := map[minorKey]bool{}
+	var majorTimes, minorTimes []time.Time
+	firstPrerelease := map[minorKey]map[uint64]time.Time{}
+	var leadTotal time.Duration
+	var leads int
+	for _, r := range sorted {
+		v := r.Version
+		key := minorKey{v.Major, v.Minor}
+		if len(v.Pre) > 0 {
+			patches := firstPrerelease[key]
+			if patches == nil {
+				patches = map[uint64]time.Time{}
+				firstPrerelease[key] = patches
+			}
+			if _, ok := patches[v.Patch]; !ok {
+				patches[v.Patch] = r.Time
+			}
+			continue
+		}
+		if first, ok := firstPrerelease[key][v.Patch]; ok {
+			leadTotal += r.Time.Sub(first)
+			leads++
+			delete(firstPrerelease[key], v.Patch)
+		}
+		if !majors[v.Major] {
+			majors[v.Major] = true
+			majorTimes = append(majorTimes, r.Time)
+		}
+		if !minors[key] {
+			minors[key] = true
+			minorTimes = append(minorTimes, r.Time)
+		}
+	}
+
+	stats.MinorInterval = meanInterval(minorTimes)
+	stats.MajorInterval = meanInterval(majorTimes)
+	if leads > 0 {
+		stats.PrereleaseLeadTime = leadTotal / time.Duration(leads)
+	}
+	stats.LastRelease = sorted[len(sorted)-1].Time
+	stats.LastReleaseAge = now.Sub(stats.LastRelease)
+	return stats
+}
+
+// meanInterval returns the average time between successive times, which
+// must be sorted.
+func meanInterval(times []time.Time) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+	return times[len(times)-1].Sub(times[0]) / time.Duration(len(times)-1)
+}
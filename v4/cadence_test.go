@@ -0,0 +1,53 @@
+package semver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCadence(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s string, days int) VersionAt {
+		return VersionAt{Version: MustParse(s), Time: start.Add(time.Duration(days) * day)}
+	}
+	timeline := []VersionAt{
+		at("1.1.0", 30),
+		at("1.0.0", 0),
+		at("1.0.1", 10),
+		at("1.2.0-beta.1", 50),
+		at("1.2.0-beta.2", 55),
+		at("1.2.0", 60),
+		at("2.0.0-rc.1", 80),
+		at("2.0.0", 100),
+		at("1.2.1", 110),
+		at("2.1.0", 120),
+	}
+	stats := cadenceAt(timeline, start.Add(130*day))
+	want := CadenceStats{
+		Releases:           10,
+		MinorInterval:      30 * day,  // 1.0.0, 1.1.0, 1.2.0, 2.0.0, 2.1.0
+		MajorInterval:      100 * day, // 1.0.0, 2.0.0
+		PrereleaseLeadTime: 15 * day,  // 10 days for 1.2.0, 20 days for 2.0.0
+		LastRelease:        start.Add(120 * day),
+		LastReleaseAge:     10 * day,
+	}
+	if stats != want {
+		t.Errorf("Expected %+v, got: %+v", want, stats)
+	}
+	if timeline[0].Version.String() != "1.1.0" {
+		t.Errorf("timeline was modified")
+	}
+}
+
+func TestCadenceSparse(t *testing.T) {
+	if stats := Cadence(nil); stats != (CadenceStats{}) {
+		t.Errorf("Expected zero stats, got: %+v", stats)
+	}
+	released := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats := cadenceAt([]VersionAt{{MustParse("1.0.0-alpha"), released}}, released.Add(time.Hour))
+	want := CadenceStats{Releases: 1, LastRelease: released, LastReleaseAge: time.Hour}
+	if stats != want {
+		t.Errorf("Expected %+v, got: %+v", want, stats)
+	}
+}
@@ -0,0 +1,70 @@
+package semver
+
+import "sort"
+
+// CatalogDiff is the difference between two registry catalogs as returned by
+// CompareCatalogs, e.g. between a registry and its mirror.
+type CatalogDiff struct {
+	// Packages holds the packages which differ, sorted by name.
+	Packages []PackageDiff
+}
+
+// Consistent checks if the catalogs hold the same versions of every package.
+func (d CatalogDiff) Consistent() bool {
+	return len(d.Packages) == 0
+}
+
+// PackageDiff is the difference of a package between two catalogs a and b.
+// Versions are compared by precedence, build meta data is ignored.
+type PackageDiff struct {
+	Name string
+	// Missing holds the versions in a which b lacks, in ascending order.
+	Missing []Version
+	// Extra holds the versions in b which a lacks, in ascending order.
+	Extra []Version
+
+	// LatestA and LatestB are the latest versions of the package in a and
+	// b: the highest release, or the highest prerelease if there is no
+	// release at all. They are the zero Version if the catalog has no
+	// version of the package.
+	LatestA Version
+	LatestB Version
+	// LatestDrift is set if the latest versions differ, so that clients of
+	// a and b resolve different versions for the "latest" tag, or if only
+	// one catalog has versions of the package.
+	LatestDrift bool
+}
+
+// CompareCatalogs compares the catalogs a and b, which map package names to
+// their published versions, e.g. a registry and its mirror: for every
+// package it reports the versions missing in b, the extra versions of b and
+// whether the latest version drifted. Packages with the same versions in
+// both catalogs are omitted. Duplicates and the order of the versions do not
+// matter, the catalogs are not modified.
+func CompareCatalogs(a, b map[string][]Version) CatalogDiff {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diff CatalogDiff
+	for _, name := range names {
+		versionsA, versionsB := a[name], b[name]
+		p := PackageDiff{Name: name}
+		p.Extra, p.Missing = DiffVersionSets(versionsA, versionsB)
+		latestA, okA := latestVersion(versionsA)
+		latestB, okB := latestVersion(versionsB)
+		p.LatestA, p.LatestB = latestA, latestB
+		p.LatestDrift = okA != okB || !latestA.Equals(latestB)
+		if len(p.Missing) > 0 || len(p.Extra) > 0 || p.LatestDrift {
+			diff.Packages = append(diff.Packages, p)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,54 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func formatPackageDiff(p PackageDiff) string {
+	return fmt.Sprintf("%s missing [%s] extra [%s] latest %s/%s drift %t",
+		p.Name, joinVersions(p.Missing), joinVersions(p.Extra), p.LatestA, p.LatestB, p.LatestDrift)
+}
+
+func TestCompareCatalogs(t *testing.T) {
+	registry := map[string][]Version{
+		"left-pad": parseVersions("1.0.0", "1.1.0", "1.2.0"),
+		"lodash":   parseVersions("4.17.21", "4.17.20", "5.0.0-beta.1"),
+		"react":    parseVersions("18.2.0", "18.3.0-rc.1", "17.0.2"),
+		"tiny":     parseVersions("0.1.0-alpha.1"),
+		"removed":  parseVersions("1.0.0"),
+	}
+	mirror := map[string][]Version{
+		"left-pad": parseVersions("1.0.0", "1.1.0"),
+		"lodash":   parseVersions("4.17.20", "4.17.21+mirror", "4.17.21"),
+		"react":    parseVersions("17.0.2", "18.2.0", "18.3.0-rc.1"),
+		"tiny":     parseVersions("0.1.0-alpha.1", "0.1.0-alpha.2"),
+		"extra":    parseVersions("2.0.0"),
+	}
+	want := []string{
+		"extra missing [] extra [2.0.0] latest 0.0.0/2.0.0 drift true",
+		"left-pad missing [1.2.0] extra [] latest 1.2.0/1.1.0 drift true",
+		"lodash missing [5.0.0-beta.1] extra [] latest 4.17.21/4.17.21+mirror drift false",
+		"removed missing [1.0.0] extra [] latest 1.0.0/0.0.0 drift true",
+		"tiny missing [] extra [0.1.0-alpha.2] latest 0.1.0-alpha.1/0.1.0-alpha.2 drift true",
+	}
+
+	diff := CompareCatalogs(registry, mirror)
+	var got []string
+	for _, p := range diff.Packages {
+		got = append(got, formatPackageDiff(p))
+	}
+	if g, w := strings.Join(got, "\n"), strings.Join(want, "\n"); g != w {
+		t.Errorf("Expected:\n%s\ngot:\n%s", w, g)
+	}
+	if diff.Consistent() {
+		t.Errorf("Expected the catalogs to be inconsistent")
+	}
+	if d := CompareCatalogs(registry, registry); !d.Consistent() {
+		t.Errorf("Expected a catalog to be consistent with itself, got: %v", d.Packages)
+	}
+	if d := CompareCatalogs(nil, nil); !d.Consistent() {
+		t.Errorf("Expected empty catalogs to be consistent, got: %v", d.Packages)
+	}
+}
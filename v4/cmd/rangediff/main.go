@@ -0,0 +1,66 @@
+// Command rangediff reports ranges which the legacy and the current range
+// parser read differently. It reads one range per line from the given files,
+// or from standard input:
+//
+//	rangediff ranges.txt
+//	jq -r '.dependencies[]' package.json | rangediff
+//
+// Every divergence is printed on its own line followed by a summary. The exit
+// status is 1 if any range diverges.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+func main() {
+	var inputs []io.Reader
+	for _, name := range os.Args[1:] {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		defer f.Close()
+		inputs = append(inputs, f)
+	}
+	if len(inputs) == 0 {
+		inputs = append(inputs, os.Stdin)
+	}
+
+	total, diverged, err := report(io.MultiReader(inputs...), os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Printf("%d of %d ranges diverge\n", diverged, total)
+	if diverged > 0 {
+		os.Exit(1)
+	}
+}
+
+// report compares the parsers for every non-empty line of r and writes the
+// divergences to w.
+func report(r io.Reader, w io.Writer) (total, diverged int, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		total++
+		if d := semver.CompareParsers(line); d != nil {
+			diverged++
+			if _, err := fmt.Fprintln(w, d); err != nil {
+				return total, diverged, err
+			}
+		}
+	}
+	return total, diverged, scanner.Err()
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	var out bytes.Buffer
+	total, diverged, err := report(strings.NewReader("^1.2.3\n\n1.2.3.4\n>=1.0.0 <2.0.0\n"), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || diverged != 1 {
+		t.Errorf("Expected 1 of 3 ranges to diverge, got: %d of %d", diverged, total)
+	}
+	if !strings.HasPrefix(out.String(), `"1.2.3.4": legacy "1.2.3", current error`) {
+		t.Errorf("Unexpected report: %s", out.String())
+	}
+}
@@ -0,0 +1,131 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CoerceOptions changes how CoerceWithOptions extracts a version.
+type CoerceOptions struct {
+	// RightMost extracts the right-most version instead of the left-most
+	// one: "1.2.3.4" coerces to 2.3.4 instead of 1.2.3, and
+	// "tool-1.2.3/lib-4.5.6" to 4.5.6.
+	RightMost bool
+	// IncludePrerelease keeps the prerelease and build meta data following
+	// a version, "v1.2.3-beta.1+42" coerces to 1.2.3-beta.1+42 instead of
+	// 1.2.3.
+	IncludePrerelease bool
+}
+
+// Coerce extracts a plausible version from messy input like node-semver's
+// coerce, e.g. from the names of upstream tarballs: the first run of up to
+// three dot separated numbers becomes the version, missing components are
+// zero. "v1.2" coerces to 1.2.0, "release-3.4.1.9000" to 3.4.1. Numbers
+// which do not fit into an uint64 are skipped. It is an error if s contains
+// no number.
+func Coerce(s string) (Version, error) {
+	return CoerceWithOptions(s, CoerceOptions{})
+}
+
+// CoerceWithOptions extracts a version like Coerce using opts.
+func CoerceWithOptions(s string, opts CoerceOptions) (Version, error) {
+	var found Version
+	foundEnd := -1
+	for start := 0; start < len(s); start++ {
+		if !isDigit(s[start]) || start > 0 && isDigit(s[start-1]) {
+			continue
+		}
+		v, end, ok := coerceAt(s, start)
+		if !ok {
+			continue
+		}
+		if opts.IncludePrerelease {
+			var n int
+			v.Pre, v.Build, n = coerceSuffix(s[end:])
+			end += n
+		}
+		if !opts.RightMost {
+			return v, nil
+		}
+		// The version ending right-most wins, the longest one of those
+		if end > foundEnd {
+			found, foundEnd = v, end
+		}
+	}
+	if foundEnd < 0 {
+		return Version{}, fmt.Errorf("no version found in %s", quote(s))
+	}
+	return found, nil
+}
+
+// coerceAt reads up to three dot separated numbers starting at s[start]. ok
+// is false if a number overflows.
+func coerceAt(s string, start int) (v Version, end int, ok bool) {
+	var components [3]uint64
+	i := start
+	for n := 0; n < 3; n++ {
+		j := i
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		num, err := strconv.ParseUint(s[i:j], 10, 64)
+		if err != nil {
+			return Version{}, 0, false
+		}
+		components[n], end = num, j
+		if j+1 >= len(s) || s[j] != '.' || !isDigit(s[j+1]) {
+			break
+		}
+		i = j + 1
+	}
+	return Version{Major: components[0], Minor: components[1], Patch: components[2]}, end, true
+}
+
+// coerceSuffix returns the valid prerelease and build identifiers at the
+// start of s, if any, and their length.
+func coerceSuffix(s string) (pre []PRVersion, build []string, n int) {
+	if strings.HasPrefix(s, "-") {
+		ids, length := coerceIdentifiers(s[1:])
+		for _, id := range ids {
+			pr, err := NewPRVersion(id)
+			if err != nil {
+				pre = nil
+				break
+			}
+			pre = append(pre, pr)
+		}
+		if pre != nil {
+			n = 1 + length
+		}
+	}
+	if strings.HasPrefix(s[n:], "+") {
+		ids, length := coerceIdentifiers(s[n+1:])
+		if ids != nil {
+			build, n = ids, n+1+length
+		}
+	}
+	return pre, build, n
+}
+
+// coerceIdentifiers returns the non-empty dot separated identifiers at the
+// start of s and their length.
+func coerceIdentifiers(s string) (ids []string, n int) {
+	for {
+		i := n
+		for i < len(s) && (isDigit(s[i]) || isLetter(s[i]) || s[i] == '-') {
+			i++
+		}
+		if i == n {
+			if n > 0 {
+				n-- // the trailing dot
+			}
+			return ids, n
+		}
+		ids = append(ids, s[n:i])
+		if i == len(s) || s[i] != '.' {
+			return ids, i
+		}
+		n = i + 1
+	}
+}
@@ -0,0 +1,44 @@
+package semver
+
+import "testing"
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		s        string
+		opts     CoerceOptions
+		expected string
+	}{
+		{"v1.2", CoerceOptions{}, "1.2.0"},
+		{"release-3.4.1.9000", CoerceOptions{}, "3.4.1"},
+		{"1.2.3.4", CoerceOptions{}, "1.2.3"},
+		{"42", CoerceOptions{}, "42.0.0"},
+		{"libfoo_2.10.tar.gz", CoerceOptions{}, "2.10.0"},
+		{"1.", CoerceOptions{}, "1.0.0"},
+		{"x99999999999999999999.1 2.3", CoerceOptions{}, "1.0.0"},
+		{"1.2.3.4", CoerceOptions{RightMost: true}, "2.3.4"},
+		{"tool-1.2.3/lib-4.5.6", CoerceOptions{RightMost: true}, "4.5.6"},
+		{"1.2.3/4", CoerceOptions{RightMost: true}, "4.0.0"},
+		{"v1.2.3-beta.1+42", CoerceOptions{}, "1.2.3"},
+		{"v1.2.3-beta.1+42", CoerceOptions{IncludePrerelease: true}, "1.2.3-beta.1+42"},
+		{"pkg-1.2-rc.1.tgz", CoerceOptions{IncludePrerelease: true}, "1.2.0-rc.1.tgz"},
+		{"1.2.3-01", CoerceOptions{IncludePrerelease: true}, "1.2.3"},
+		{"1.2.3-", CoerceOptions{IncludePrerelease: true}, "1.2.3"},
+		{"1.2.3+build.7 extra", CoerceOptions{IncludePrerelease: true}, "1.2.3+build.7"},
+		{"a-1.0.0/b-2.0.0-rc.1", CoerceOptions{RightMost: true, IncludePrerelease: true}, "2.0.0-rc.1"},
+	}
+	for _, tc := range tests {
+		v, err := CoerceWithOptions(tc.s, tc.opts)
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.s, err)
+			continue
+		}
+		if v.String() != tc.expected {
+			t.Errorf("Invalid for case %q %+v: Expected %q, got: %q", tc.s, tc.opts, tc.expected, v)
+		}
+	}
+	for _, s := range []string{"", "version", "99999999999999999999"} {
+		if v, err := Coerce(s); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error, got: %q", s, v)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package semver
+
+import "fmt"
+
+// ComparatorSpec is the structured form of a single comparator, e.g.
+// {"op":">=","version":"1.2.3"}. Op is any operator accepted by ParseRange,
+// an empty Op matches the version exactly. Version can be partial or contain
+// wildcards like in a range string.
+type ComparatorSpec struct {
+	Op      string `json:"op"`
+	Version string `json:"version"`
+}
+
+// RangeFromComparators builds a Range matching versions which satisfy all
+// comparators of specs, e.g. decoded from the JSON array
+// [{"op":">=","version":"1.2.3"},{"op":"<","version":"2.0.0"}]. An empty
+// slice is an error, like an empty range string.
+func RangeFromComparators(specs []ComparatorSpec) (Range, error) {
+	c, err := constraintsFromComparators(specs)
+	if err != nil {
+		return nil, err
+	}
+	return c.Range(), nil
+}
+
+func constraintsFromComparators(specs []ComparatorSpec) (*Constraints, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no comparators")
+	}
+	set := make([]string, 0, len(specs))
+	for i, spec := range specs {
+		if spec.Op != "" && scanOperator(spec.Op) != len(spec.Op) {
+			return nil, fmt.Errorf("comparator %d: invalid operator %s", i, quote(spec.Op))
+		}
+		tokens, err := scanRange(spec.Version)
+		if err != nil {
+			return nil, fmt.Errorf("comparator %d: %s", i, err)
+		}
+		if len(tokens) != 1 || tokens[0].Kind != TokenVersion || tokens[0].Offset != 0 || tokens[0].Text != spec.Version {
+			return nil, fmt.Errorf("comparator %d: invalid version %s", i, quote(spec.Version))
+		}
+		set = append(set, comparatorString(spec.Op, spec.Version))
+	}
+	return buildConstraints([][]string{set}, RangeOptions{})
+}
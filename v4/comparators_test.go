@@ -0,0 +1,50 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRangeFromComparators(t *testing.T) {
+	tests := []struct {
+		json string
+		s    string
+	}{
+		{`[{"op":">=","version":"1.2.3"},{"op":"<","version":"2.0.0"}]`, ">=1.2.3 <2.0.0"},
+		{`[{"op":"^","version":"1.2"}]`, ">=1.2.0 <2.0.0"},
+		{`[{"op":"","version":"1.2.3-beta.1"}]`, "1.2.3-beta.1"},
+		{`[{"version":"1.x"},{"op":"!=","version":"1.5.0"}]`, ">=1.0.0 <2.0.0 !=1.5.0"},
+	}
+	for _, tc := range tests {
+		var specs []ComparatorSpec
+		if err := json.Unmarshal([]byte(tc.json), &specs); err != nil {
+			t.Fatal(err)
+		}
+		c, err := constraintsFromComparators(specs)
+		if err != nil {
+			t.Errorf("Invalid for case %s: %s", tc.json, err)
+		} else if c.String() != tc.s {
+			t.Errorf("Invalid for case %s: Expected %q, got: %q", tc.json, tc.s, c)
+		}
+	}
+
+	r, err := RangeFromComparators([]ComparatorSpec{{">=", "1.2.3"}, {"<", "2.0.0"}})
+	if err != nil || !r(MustParse("1.5.0")) || r(MustParse("2.0.0")) {
+		t.Errorf("Unexpected range behavior: %v", err)
+	}
+
+	invalid := [][]ComparatorSpec{
+		nil,
+		{{">>", "1.2.3"}},
+		{{">=", "1.2.3 <2.0.0"}},
+		{{">=", "1.2.3garbage"}},
+		{{">=", " 1.2.3"}},
+		{{">=", ""}},
+		{{"", "||"}},
+	}
+	for _, specs := range invalid {
+		if _, err := RangeFromComparators(specs); err == nil {
+			t.Errorf("Expected error for %v", specs)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package semver
+
+import "strconv"
+
+// OrderingComponent is the part of two versions which decides their order.
+type OrderingComponent int
+
+const (
+	// OrderingEqual means the versions have the same precedence.
+	OrderingEqual OrderingComponent = iota
+	// OrderingMajor means the major versions differ.
+	OrderingMajor
+	// OrderingMinor means the minor versions differ.
+	OrderingMinor
+	// OrderingPatch means the patch versions differ.
+	OrderingPatch
+	// OrderingRelease means only one version is a prerelease, the release
+	// has the higher precedence.
+	OrderingRelease
+	// OrderingPrerelease means the prerelease identifiers at
+	// OrderingReason.Index differ.
+	OrderingPrerelease
+	// OrderingPrereleaseLength means one prerelease has additional
+	// identifiers starting at OrderingReason.Index, the longer prerelease
+	// has the higher precedence.
+	OrderingPrereleaseLength
+)
+
+// OrderingReason explains the result of CompareDetailed.
+type OrderingReason struct {
+	Component OrderingComponent
+	Index     int // prerelease identifier index for OrderingPrerelease and OrderingPrereleaseLength
+}
+
+func (r OrderingReason) String() string {
+	switch r.Component {
+	case OrderingMajor:
+		return "major version"
+	case OrderingMinor:
+		return "minor version"
+	case OrderingPatch:
+		return "patch version"
+	case OrderingRelease:
+		return "release precedes prerelease"
+	case OrderingPrerelease:
+		return "prerelease identifier " + strconv.Itoa(r.Index)
+	case OrderingPrereleaseLength:
+		return "additional prerelease identifier " + strconv.Itoa(r.Index)
+	}
+	return "equal precedence"
+}
+
+// CompareDetailed compares a and b like a.Compare(b) and explains which
+// component decided the ordering, e.g. to explain sort orders in a user
+// interface: 1.0.0-alpha.1 < 1.0.0-alpha.beta by prerelease identifier 1.
+func CompareDetailed(a, b Version) (int, OrderingReason) {
+	if c := compareUint(a.Major, b.Major); c != 0 {
+		return c, OrderingReason{Component: OrderingMajor}
+	}
+	if c := compareUint(a.Minor, b.Minor); c != 0 {
+		return c, OrderingReason{Component: OrderingMinor}
+	}
+	if c := compareUint(a.Patch, b.Patch); c != 0 {
+		return c, OrderingReason{Component: OrderingPatch}
+	}
+
+	if len(a.Pre) == 0 && len(b.Pre) == 0 {
+		return 0, OrderingReason{Component: OrderingEqual}
+	} else if len(a.Pre) == 0 {
+		return 1, OrderingReason{Component: OrderingRelease}
+	} else if len(b.Pre) == 0 {
+		return -1, OrderingReason{Component: OrderingRelease}
+	}
+
+	i := 0
+	for ; i < len(a.Pre) && i < len(b.Pre); i++ {
+		if c := a.Pre[i].Compare(b.Pre[i]); c != 0 {
+			return c, OrderingReason{Component: OrderingPrerelease, Index: i}
+		}
+	}
+	if len(a.Pre) == len(b.Pre) {
+		return 0, OrderingReason{Component: OrderingEqual}
+	} else if len(a.Pre) < len(b.Pre) {
+		return -1, OrderingReason{Component: OrderingPrereleaseLength, Index: i}
+	}
+	return 1, OrderingReason{Component: OrderingPrereleaseLength, Index: i}
+}
+
+func compareUint(a, b uint64) int {
+	if a > b {
+		return 1
+	} else if a < b {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package semver
+
+import "testing"
+
+func TestCompareDetailed(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		c      int
+		reason string
+	}{
+		{"2.0.0", "1.9.9", 1, "major version"},
+		{"1.1.0", "1.2.0", -1, "minor version"},
+		{"1.1.1", "1.1.0", 1, "patch version"},
+		{"1.0.0", "1.0.0-rc.1", 1, "release precedes prerelease"},
+		{"1.0.0-rc.1", "1.0.0", -1, "release precedes prerelease"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1, "prerelease identifier 1"},
+		{"1.0.0-beta", "1.0.0-alpha", 1, "prerelease identifier 0"},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1, "additional prerelease identifier 1"},
+		{"1.0.0-alpha.1.2", "1.0.0-alpha.1", 1, "additional prerelease identifier 2"},
+		{"1.0.0-alpha.1", "1.0.0-alpha.1", 0, "equal precedence"},
+		{"1.0.0+build.1", "1.0.0+build.2", 0, "equal precedence"},
+	}
+	for _, tc := range tests {
+		a, b := MustParse(tc.a), MustParse(tc.b)
+		c, reason := CompareDetailed(a, b)
+		if c != tc.c || reason.String() != tc.reason {
+			t.Errorf("Invalid for case %q vs %q: Expected %d (%s), got: %d (%s)", tc.a, tc.b, tc.c, tc.reason, c, reason)
+		}
+		if c != a.Compare(b) {
+			t.Errorf("Invalid for case %q vs %q: CompareDetailed disagrees with Compare", tc.a, tc.b)
+		}
+	}
+}
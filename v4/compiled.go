@@ -0,0 +1,207 @@
+package semver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// compiledMagic starts the compiled range format, the last byte is the
+// format version.
+const compiledMagic = "SVR\x01"
+
+const compiledFlagNPM = 1 << 0
+
+// MarshalCompiled serializes the compiled comparators of r in a compact
+// versioned binary format, e.g. to cache compiled ranges in a key value store
+// and skip parsing with UnmarshalCompiledRange. It returns nil if r was not
+// created by this package and can not be inspected.
+//
+// The format is the magic "SVR" followed by the format version 1, a flags
+// byte, and the sets of comparators, all counts and numbers as uvarints:
+// the number of sets, and per set the number of comparators, and per
+// comparator the operator, major, minor, patch, prerelease identifiers and
+// build identifiers.
+func (rf Range) MarshalCompiled() []byte {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return nil
+	}
+	return c.appendCompiled(nil)
+}
+
+func (c *Constraints) appendCompiled(b []byte) []byte {
+	b = append(b, compiledMagic...)
+	var flags byte
+	if c.npm {
+		flags |= compiledFlagNPM
+	}
+	b = append(b, flags)
+	b = appendUvarint(b, uint64(len(c.sets)))
+	for _, set := range c.sets {
+		b = appendUvarint(b, uint64(len(set)))
+		for _, vr := range set {
+			b = append(b, byte(vr.op))
+			b = appendVersion(b, vr.v)
+		}
+	}
+	return b
+}
+
+// appendVersion appends the major, minor and patch numbers, prerelease
+// identifiers and build identifiers of v.
+func appendVersion(b []byte, v Version) []byte {
+	b = appendUvarint(b, v.Major)
+	b = appendUvarint(b, v.Minor)
+	b = appendUvarint(b, v.Patch)
+	b = appendUvarint(b, uint64(len(v.Pre)))
+	for _, pre := range v.Pre {
+		if pre.IsNum {
+			b = append(b, 0)
+			b = appendUvarint(b, pre.VersionNum)
+		} else {
+			b = append(b, 1)
+			b = appendString(b, pre.VersionStr)
+		}
+	}
+	b = appendUvarint(b, uint64(len(v.Build)))
+	for _, build := range v.Build {
+		b = appendString(b, build)
+	}
+	return b
+}
+
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = appendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+var errCompiledTruncated = errors.New("compiled range is truncated")
+
+// UnmarshalCompiledRange restores a Range serialized by MarshalCompiled. The
+// data is validated, it is an error if it is truncated, has trailing bytes,
+// an unknown format version or invalid comparators.
+func UnmarshalCompiledRange(data []byte) (Range, error) {
+	c, err := unmarshalCompiledConstraints(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.Range(), nil
+}
+
+func unmarshalCompiledConstraints(data []byte) (*Constraints, error) {
+	if len(data) < len(compiledMagic) || string(data[:len(compiledMagic)-1]) != compiledMagic[:len(compiledMagic)-1] {
+		return nil, errors.New("not a compiled range")
+	}
+	if data[len(compiledMagic)-1] != compiledMagic[len(compiledMagic)-1] {
+		return nil, fmt.Errorf("unsupported compiled range format version %d", data[len(compiledMagic)-1])
+	}
+	d := compiledDecoder{data: data[len(compiledMagic):]}
+	flags := d.byte()
+	c := &Constraints{npm: flags&compiledFlagNPM != 0}
+	for i, nsets := 0, d.count(); i < nsets && d.err == nil; i++ {
+		var set []versionRange
+		for j, n := 0, d.count(); j < n && d.err == nil; j++ {
+			vr := versionRange{op: Operator(d.byte())}
+			vr.v = d.version()
+			if (vr.op < OpEQ || vr.op > OpLTE) && d.err == nil {
+				d.err = fmt.Errorf("invalid operator %d", vr.op)
+			}
+			vr.c = vr.op.comparator()
+			set = append(set, vr)
+		}
+		c.sets = append(c.sets, set)
+	}
+	if d.err == nil && len(d.data) > 0 {
+		d.err = fmt.Errorf("compiled range has %d trailing bytes", len(d.data))
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return c, nil
+}
+
+// compiledDecoder reads the compiled range format, the first error sticks.
+type compiledDecoder struct {
+	data []byte
+	err  error
+}
+
+// version reads a version appended by appendVersion and validates its
+// identifiers.
+func (d *compiledDecoder) version() Version {
+	var v Version
+	v.Major, v.Minor, v.Patch = d.uvarint(), d.uvarint(), d.uvarint()
+	for k, npre := 0, d.count(); k < npre && d.err == nil; k++ {
+		var pre PRVersion
+		if d.byte() == 0 {
+			pre = PRVersion{VersionNum: d.uvarint(), IsNum: true}
+		} else if s := d.string(); d.err == nil {
+			if pre, d.err = NewPRVersion(s); d.err == nil && pre.IsNum {
+				d.err = fmt.Errorf("numeric prerelease %s encoded as string", quote(s))
+			}
+		}
+		v.Pre = append(v.Pre, pre)
+	}
+	for k, nbuild := 0, d.count(); k < nbuild && d.err == nil; k++ {
+		build := d.string()
+		if d.err == nil {
+			_, d.err = NewBuildVersion(build)
+		}
+		v.Build = append(v.Build, build)
+	}
+	return v
+}
+
+func (d *compiledDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) == 0 {
+		d.err = errCompiledTruncated
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *compiledDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errCompiledTruncated
+		return 0
+	}
+	d.data = d.data[n:]
+	return x
+}
+
+// count reads a count, which can not exceed the remaining bytes as every
+// element takes at least one byte.
+func (d *compiledDecoder) count() int {
+	n := d.uvarint()
+	if d.err == nil && n > uint64(len(d.data)) {
+		d.err = errCompiledTruncated
+		return 0
+	}
+	return int(n)
+}
+
+func (d *compiledDecoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
@@ -0,0 +1,74 @@
+package semver
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompiledRoundTrip(t *testing.T) {
+	tests := []string{
+		"1.2.3",
+		"^1.2.3 || ~4.5",
+		">=1.2.3-alpha.1 <2.0.0-rc.2+build.7",
+		"!=1.5.0 >1.0.0 <=18446744073709551615.0.0",
+	}
+	for _, s := range tests {
+		data := MustParseRange(s).MarshalCompiled()
+		r, err := UnmarshalCompiledRange(data)
+		if err != nil {
+			t.Errorf("Invalid for case %q: unexpected error: %s", s, err)
+			continue
+		}
+		c, _ := constraintsOf(r)
+		want, _ := ExpandRangeString(s)
+		if c.String() != want {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", s, want, c)
+		}
+		if !bytes.Equal(r.MarshalCompiled(), data) {
+			t.Errorf("Invalid for case %q: encoding is not stable", s)
+		}
+	}
+}
+
+func TestCompiledNPM(t *testing.T) {
+	r, err := ParseRangeWithOptions("^1.2.3-beta.2", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := UnmarshalCompiledRange(r.MarshalCompiled())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"1.2.3-beta.3", "1.3.0", "1.4.0-beta.1"} {
+		v := MustParse(s)
+		if restored(v) != r(v) {
+			t.Errorf("Invalid for case %q: Expected %t, got: %t", s, r(v), restored(v))
+		}
+	}
+}
+
+func TestCompiledNotInspectable(t *testing.T) {
+	if data := Range(func(Version) bool { return true }).MarshalCompiled(); data != nil {
+		t.Errorf("Expected nil, got: %v", data)
+	}
+}
+
+func TestUnmarshalCompiledRangeInvalid(t *testing.T) {
+	valid := MustParseRange(">=1.2.3-alpha+b").MarshalCompiled()
+	tests := [][]byte{
+		nil,
+		[]byte("XYZ\x01"),
+		[]byte("SVR\x02\x00\x00"),
+		valid[:len(valid)-1],
+		append(append([]byte(nil), valid...), 0),
+		[]byte("SVR\x01\x00\x01\x01\x09\x01\x02\x03\x00\x00"),                 // unknown operator
+		[]byte("SVR\x01\x00\x01\x01\x00\x01\x02\x03\x01\x01\x02\x30\x31\x00"), // numeric prerelease as string
+		[]byte("SVR\x01\x00\x01\x01\x00\x01\x02\x03\x00\x01\x01\x00"),         // invalid build identifier
+		[]byte("SVR\x01\x00\xff\xff\xff\xff\x0f"),
+	}
+	for _, data := range tests {
+		if _, err := UnmarshalCompiledRange(data); err == nil {
+			t.Errorf("Invalid for case %q: Expected error", data)
+		}
+	}
+}
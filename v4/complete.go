@@ -0,0 +1,108 @@
+package semver
+
+import "strings"
+
+// SuggestionKind is the kind of a Suggestion.
+type SuggestionKind int
+
+const (
+	// SuggestOperator completes an operator, e.g. "^".
+	SuggestOperator SuggestionKind = iota
+	// SuggestVersion completes a version, e.g. "1.2.3".
+	SuggestVersion
+	// SuggestOr starts another set of comparators with "||".
+	SuggestOr
+)
+
+// Suggestion is a completion of a partially typed range, see CompleteRange.
+type Suggestion struct {
+	Kind SuggestionKind
+	// Offset is the byte offset in the partial range at which Text replaces
+	// the rest of the input.
+	Offset int
+	Text   string
+	// Detail describes the completion: the summary of an operator or the
+	// expanded normal form of the completed comparator.
+	Detail string
+}
+
+// CompleteRange proposes completions for the partially typed range partial,
+// e.g. for manifest editors. At the start of a comparator it proposes the
+// operators and the available versions, after an operator the available
+// versions and longer operators, within a version the available versions
+// starting with the typed text. Versions are proposed from the highest to the
+// lowest, prereleases only if the typed text contains a '-'.
+func CompleteRange(partial string, available []Version) []Suggestion {
+	tokens := Tokenize(partial)
+	var last, prev *Token
+	if n := len(tokens); n > 0 {
+		if t := tokens[n-1]; t.Offset+len(t.Text) == len(partial) {
+			last = &tokens[n-1]
+			if n > 1 {
+				prev = &tokens[n-2]
+			}
+		} else {
+			prev = &tokens[n-1]
+		}
+	}
+
+	var suggestions []Suggestion
+	switch {
+	case last == nil || last.Kind == TokenOr || last.Kind == TokenHyphen || last.Kind == TokenOpenParen:
+		offset := len(partial)
+		if prev != nil && last == nil && prev.Kind == TokenVersion {
+			suggestions = append(suggestions, Suggestion{Kind: SuggestOr, Offset: offset, Text: "||", Detail: "Matches if any set matches."})
+		}
+		suggestions = append(suggestions, operatorSuggestions(offset, "")...)
+		suggestions = append(suggestions, versionSuggestions(offset, "", "", available)...)
+	case last.Kind == TokenOperator:
+		suggestions = append(suggestions, operatorSuggestions(last.Offset, last.Text)...)
+		suggestions = append(suggestions, versionSuggestions(len(partial), last.Text, "", available)...)
+	default:
+		op := ""
+		if prev != nil && prev.Kind == TokenOperator {
+			op = prev.Text
+		}
+		suggestions = append(suggestions, versionSuggestions(last.Offset, op, last.Text, available)...)
+	}
+	return suggestions
+}
+
+// operatorSuggestions proposes the operators starting with, but longer than,
+// prefix.
+func operatorSuggestions(offset int, prefix string) []Suggestion {
+	var suggestions []Suggestion
+	for _, op := range rangeOperators {
+		for _, token := range append([]string{op.token}, op.aliases...) {
+			if len(token) > len(prefix) && strings.HasPrefix(token, prefix) {
+				suggestions = append(suggestions, Suggestion{Kind: SuggestOperator, Offset: offset, Text: token, Detail: op.summary})
+			}
+		}
+	}
+	return suggestions
+}
+
+// versionSuggestions proposes the available versions starting with prefix,
+// the details are expanded with the operator op.
+func versionSuggestions(offset int, op, prefix string, available []Version) []Suggestion {
+	versions := make([]Version, len(available))
+	copy(versions, available)
+	Sort(versions)
+
+	var suggestions []Suggestion
+	seen := map[string]bool{}
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		if len(v.Pre) > 0 && !strings.Contains(prefix, "-") {
+			continue
+		}
+		s := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: v.Pre}.String()
+		if seen[s] || !strings.HasPrefix(s, prefix) {
+			continue
+		}
+		seen[s] = true
+		detail, _ := ExpandRangeString(op + s)
+		suggestions = append(suggestions, Suggestion{Kind: SuggestVersion, Offset: offset, Text: s, Detail: detail})
+	}
+	return suggestions
+}
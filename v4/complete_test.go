@@ -0,0 +1,56 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func formatSuggestions(suggestions []Suggestion) string {
+	var parts []string
+	for _, s := range suggestions {
+		parts = append(parts, fmt.Sprintf("%s@%d", s.Text, s.Offset))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestCompleteRange(t *testing.T) {
+	available := []Version{}
+	for _, s := range []string{"1.2.0", "1.10.0", "2.0.0", "2.1.0-beta.1", "1.2.0+build"} {
+		available = append(available, MustParse(s))
+	}
+	ops := "= == != ! > >= < <= ~ ~> ^"
+	tests := []struct {
+		partial     string
+		suggestions string
+	}{
+		{"", opsAt(ops, 0) + " 2.0.0@0 1.10.0@0 1.2.0@0"},
+		{">", ">=@0 2.0.0@1 1.10.0@1 1.2.0@1"},
+		{"^1.", "1.10.0@1 1.2.0@1"},
+		{"^1.1", "1.10.0@1"},
+		{">= 2", "2.0.0@3"},
+		{"2.1.0-", "2.1.0-beta.1@0"},
+		{">=1.2.0 ", "||@8 " + opsAt(ops, 8) + " 2.0.0@8 1.10.0@8 1.2.0@8"},
+		{"1.2.0 ||", opsAt(ops, 8) + " 2.0.0@8 1.10.0@8 1.2.0@8"},
+		{"3", ""},
+	}
+	for _, tc := range tests {
+		if s := formatSuggestions(CompleteRange(tc.partial, available)); s != tc.suggestions {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.partial, tc.suggestions, s)
+		}
+	}
+
+	for _, s := range CompleteRange("^1.", available) {
+		if s.Detail != ">=1.10.0 <2.0.0" && s.Detail != ">=1.2.0 <2.0.0" {
+			t.Errorf("Unexpected detail %q for %q", s.Detail, s.Text)
+		}
+	}
+}
+
+func opsAt(ops string, offset int) string {
+	var parts []string
+	for _, op := range strings.Fields(ops) {
+		parts = append(parts, fmt.Sprintf("%s@%d", op, offset))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,260 @@
+package semver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Constraints is the structured form of a parsed range: a disjunction (||)
+// of sets of comparators which must all be satisfied. Unlike the opaque
+// Range func, Constraints can be inspected.
+type Constraints struct {
+	sets [][]versionRange
+	npm  bool // RangeOptions.NPMCompat without RangeOptions.IncludePrerelease
+}
+
+// ParseConstraints parses a range like ParseRange, see ParseRange for the
+// supported syntax.
+func ParseConstraints(s string) (*Constraints, error) {
+	return ParseConstraintsWithOptions(s, RangeOptions{})
+}
+
+// ParseConstraintsWithOptions parses a range like ParseRangeWithOptions.
+func ParseConstraintsWithOptions(s string, opts RangeOptions) (*Constraints, error) {
+	if opts.OnDivergence != nil {
+		if d := CompareParsers(s); d != nil {
+			opts.OnDivergence(*d)
+		}
+	}
+	if m := loadMetricsSink(); m != nil {
+		start := time.Now()
+		c, err := parseConstraints(s, opts)
+		m.ObserveRangeParse(time.Since(start), err == nil)
+		return c, err
+	}
+	return parseConstraints(s, opts)
+}
+
+func parseConstraints(s string, opts RangeOptions) (*Constraints, error) {
+	var orParts [][]string
+	var err error
+	if opts.Loose {
+		if s, err = looseRange(s); err != nil {
+			return nil, err
+		}
+	}
+	if opts.SemVerOnly {
+		if err := checkSemVerOnly(s); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Tolerant && !opts.SemVerOnly {
+		orParts, err = splitORParts(splitAndTrim(unquoteRange(s)))
+	} else if opts.Precedence != PrecedenceAND || opts.OnAmbiguousPrecedence != nil || strings.ContainsAny(s, "()") {
+		return parseGroupedConstraints(s, opts)
+	} else if c, ok := parseConstraintsFast(s, opts.NPMCompat, opts.NPMCompat && !opts.IncludePrerelease); ok {
+		return c, nil
+	} else {
+		orParts, err = scanORParts(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := buildConstraints(orParts, opts)
+	if err != nil && !opts.Tolerant {
+		return nil, comparatorError(s, opts.NPMCompat, err)
+	}
+	return c, err
+}
+
+// unquoteRange removes the quotes around the parts of s which constraints
+// copied from TOML or YAML files often keep, e.g. `>= "1.2.3"` or
+// `'^1.2' || '^2'`: every pair of matching single or double quotes. An
+// unmatched quote is kept.
+func unquoteRange(s string) string {
+	if !strings.ContainsAny(s, `"'`) {
+		return s
+	}
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if q := s[i]; q == '"' || q == '\'' {
+			if end := strings.IndexByte(s[i+1:], q); end >= 0 {
+				b = append(b, s[i+1:i+1+end]...)
+				i += end + 1
+				continue
+			}
+		}
+		b = append(b, s[i])
+	}
+	return string(b)
+}
+
+// buildConstraints expands and compiles the comparators of orParts, as
+// returned by splitORParts or scanORParts.
+func buildConstraints(orParts [][]string, opts RangeOptions) (*Constraints, error) {
+	c := &Constraints{sets: make([][]versionRange, 0, len(orParts)), npm: opts.NPMCompat && !opts.IncludePrerelease}
+	for _, p := range orParts {
+		set := make([]versionRange, 0, len(p))
+		for _, ap := range p {
+			vrs, err := buildComparator(ap, opts.NPMCompat)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, vrs...)
+		}
+		c.sets = append(c.sets, set)
+	}
+	return c, nil
+}
+
+// buildComparator expands and compiles the comparator ap, see
+// expandWildcardVersion for npm.
+func buildComparator(ap string, npm bool) ([]versionRange, error) {
+	expanded, err := expandWildcardVersion([][]string{{ap}}, npm)
+	if err != nil {
+		return nil, err
+	}
+	vrs := make([]versionRange, 0, len(expanded[0]))
+	for _, e := range expanded[0] {
+		opStr, vStr, err := splitComparatorVersion(e)
+		if err != nil {
+			return nil, err
+		}
+		vr, err := buildVersionRange(opStr, vStr)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse Range %s: %w", quote(e), err)
+		}
+		vrs = append(vrs, *vr)
+	}
+	return vrs, nil
+}
+
+// MustParseConstraints is like ParseConstraints but panics if the range
+// cannot be parsed.
+func MustParseConstraints(s string) *Constraints {
+	c, err := ParseConstraints(s)
+	if err != nil {
+		panic(`semver: ParseConstraints(` + truncate(s, maxErrorInputLength) + `): ` + err.Error())
+	}
+	return c
+}
+
+// Check checks if v satisfies the constraints.
+func (c *Constraints) Check(v Version) bool {
+	if m := loadMetricsSink(); m != nil {
+		start := time.Now()
+		ok := c.check(v)
+		m.ObserveRangeEval(time.Since(start))
+		return ok
+	}
+	return c.check(v)
+}
+
+func (c *Constraints) check(v Version) bool {
+	for _, set := range c.sets {
+		if checkSet(set, v) && (!c.npm || len(v.Pre) == 0 || hasPrereleaseAnchor(set, v)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPrereleaseAnchor checks if a comparator of set names a prerelease of the
+// same major.minor.patch as v. npm only lets prerelease versions satisfy such
+// sets, so that opting into one prerelease does not opt into all of them.
+func hasPrereleaseAnchor(set []versionRange, v Version) bool {
+	for i := range set {
+		a := set[i].v
+		if len(a.Pre) > 0 && a.Major == v.Major && a.Minor == v.Minor && a.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSet checks if v satisfies all comparators of set.
+func checkSet(set []versionRange, v Version) bool {
+	for i := range set {
+		if !set[i].c(v, set[i].v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Range returns the constraints as a Range. The Range checks the comparators
+// of each set in the order which rejects versions outside of it the
+// quickest.
+func (c *Constraints) Range() Range {
+	eval := c.evaluationOrder()
+	return Range(func(v Version) bool {
+		if reportProbe(v, c) {
+			return false
+		}
+		return eval.Check(v)
+	})
+}
+
+// String returns the expanded normal form of the constraints: wildcard,
+// tilde, caret and hyphen ranges are expanded to plain comparators, e.g.
+// "^1.2" becomes ">=1.2.0 <2.0.0". Within a set, lower bounds come first,
+// followed by exact versions, upper bounds and exclusions, each ordered by
+// version. Sets are joined by " || ". The prerelease rule of
+// RangeOptions.NPMCompat is not part of the string, constraints parsed with
+// it read back as equivalent constraints only with the same options.
+func (c *Constraints) String() string {
+	var b strings.Builder
+	for i, set := range c.sets {
+		if i > 0 {
+			b.WriteString(" || ")
+		}
+		for j, vr := range normalOrder(set) {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			if vr.op != OpEQ {
+				b.WriteString(vr.op.String())
+			}
+			b.WriteString(vr.v.String())
+		}
+	}
+	return b.String()
+}
+
+// normalOrder returns a copy of set in the order of the normal form.
+func normalOrder(set []versionRange) []versionRange {
+	sorted := make([]versionRange, len(set))
+	copy(sorted, set)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if oi, oj := operatorRank(sorted[i].op), operatorRank(sorted[j].op); oi != oj {
+			return oi < oj
+		}
+		return sorted[i].v.LT(sorted[j].v)
+	})
+	return sorted
+}
+
+// operatorRank orders operators in the normal form.
+func operatorRank(op Operator) int {
+	switch op {
+	case OpGT, OpGTE:
+		return 0
+	case OpEQ:
+		return 1
+	case OpLT, OpLTE:
+		return 2
+	}
+	return 3
+}
+
+// ExpandRangeString parses the range s and returns its expanded normal form,
+// e.g. ExpandRangeString("^1.2") returns ">=1.2.0 <2.0.0".
+func ExpandRangeString(s string) (string, error) {
+	c, err := ParseConstraints(s)
+	if err != nil {
+		return "", err
+	}
+	return c.String(), nil
+}
@@ -0,0 +1,102 @@
+package semver
+
+import "testing"
+
+func TestParseConstraints(t *testing.T) {
+	c, err := ParseConstraints(">1.2.2 <1.2.4 || >=2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := c.Range()
+	tests := []struct {
+		v string
+		b bool
+	}{
+		{"1.2.2", false},
+		{"1.2.3", true},
+		{"1.2.4", false},
+		{"2.0.0", true},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if c.Check(v) != tc.b || r(v) != tc.b {
+			t.Errorf("Invalid for case %q: Expected %t", tc.v, tc.b)
+		}
+	}
+
+	if _, err := ParseConstraints(">>1.2.3"); err == nil {
+		t.Error("Expected error for invalid range")
+	}
+}
+
+func TestMustParseConstraints_panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked")
+		}
+	}()
+	_ = MustParseConstraints("invalid constraints")
+}
+
+func TestExpandRangeString(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{"^1.2", ">=1.2.0 <2.0.0"},
+		{"^1.2.3", ">=1.2.3 <2.0.0"},
+		{"~1.2.3", ">=1.2.3 <1.3.0"},
+		{"~1.2.3-alpha", ">=1.2.3-alpha <1.3.0"},
+		{"!1.2.3-alpha", "!=1.2.3-alpha"},
+		{"1.x", ">=1.0.0 <2.0.0"},
+		{"1.2.3", "1.2.3"},
+		{"==1.2.3", "1.2.3"},
+		{"!1.2.3", "!=1.2.3"},
+		{"<=1.x", "<2.0.0"},
+		{"<2.0.0 >=1.0.0 !=1.5.0 >1.0.1", ">=1.0.0 >1.0.1 <2.0.0 !=1.5.0"},
+		{">1.2.3 <2 || 3.x", ">1.2.3 <2.0.0 || >=3.0.0 <4.0.0"},
+		{"  >=  1.2.3   ||  1.0.0 ", ">=1.2.3 || 1.0.0"},
+	}
+	for _, tc := range tests {
+		o, err := ExpandRangeString(tc.i)
+		if err != nil {
+			t.Errorf("Unexpected error for case %q: %s", tc.i, err)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got %q", tc.i, tc.o, o)
+		}
+	}
+	if _, err := ExpandRangeString(">>1.2.3"); err == nil {
+		t.Error("Expected error for invalid range")
+	}
+}
+
+func TestParseConstraintsQuoted(t *testing.T) {
+	tests := []struct {
+		s        string
+		expanded string
+	}{
+		{`>= "1.2.3"`, ">=1.2.3"},
+		{`"^1.2"`, ">=1.2.0 <2.0.0"},
+		{`'>=1.0.0' '<2.0.0'`, ">=1.0.0 <2.0.0"},
+		{`"1.2.3" || '2.0.0'`, "1.2.3 || 2.0.0"},
+		{`>= "1.2.3" <"2"`, ">=1.2.3 <2.0.0"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.s, RangeOptions{Tolerant: true})
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.s, err)
+			continue
+		}
+		if s := c.String(); s != tc.expanded {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.expanded, s)
+		}
+		if _, err := ParseConstraints(tc.s); err == nil {
+			t.Errorf("Invalid for case %q: Expected the strict parser to reject quotes", tc.s)
+		}
+	}
+	for _, s := range []string{`"1.2.3`, `1.2.3'`, `"1.2.3'`} {
+		if _, err := ParseConstraintsWithOptions(s, RangeOptions{Tolerant: true}); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error for an unmatched quote", s)
+		}
+	}
+}
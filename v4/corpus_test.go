@@ -0,0 +1,320 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// negativeCorpus holds pathological inputs which the exported entry points
+// must reject or accept without panicking.
+var negativeCorpus = []string{
+	"",
+	" ",
+	".",
+	"..",
+	"...",
+	"....",
+	"-",
+	"+",
+	"-+",
+	"1.",
+	"1..",
+	".1",
+	"1.2.",
+	"1.2.3.",
+	"1.2.3-",
+	"1.2.3+",
+	"1.2.3-+",
+	"1.2.3--",
+	"1.2.3++",
+	"1.2.3-.",
+	"1.2.3+.",
+	"1.2.3-a..b",
+	"x.",
+	"x.x.",
+	"*.*.*.*",
+	"x-",
+	"x+",
+	"1.x-",
+	"1.x+build",
+	"1.x.3-beta",
+	" 1 . 2 . 3 ",
+	"1 2 3",
+	"v",
+	"vv1.2.3",
+	"=",
+	"==",
+	"!",
+	"!=",
+	">",
+	"<=",
+	"^",
+	"~",
+	"~>",
+	">=>=1.0.0",
+	"<<1.0.0",
+	"^~1.0.0",
+	"||",
+	"|| ||",
+	"1.0.0 ||",
+	"|| 1.0.0",
+	"1.0.0 | 2.0.0",
+	"-",
+	"1.0.0 -",
+	"- 1.0.0",
+	"1.0.0 - - 2.0.0",
+	"1.0.0 - 2.0.0 - 3.0.0",
+	"(",
+	")",
+	"()",
+	"(1.0.0",
+	"1.0.0)",
+	")(",
+	"!()",
+	"!(1.0.0",
+	"'",
+	"\"",
+	"'1.0.0",
+	"\"\"",
+	// Huge numbers
+	"18446744073709551615.18446744073709551615.18446744073709551615",
+	"18446744073709551616.0.0",
+	"0.18446744073709551616.0",
+	"0.0.18446744073709551616",
+	"1.2.3-18446744073709551616",
+	"99999999999999999999999999999999999999.0.0",
+	"^18446744073709551615.18446744073709551615.18446744073709551615",
+	"~18446744073709551615.18446744073709551615.18446744073709551615",
+	"^0.18446744073709551615.18446744073709551615",
+	"^0.0.18446744073709551615",
+	"~18446744073709551615",
+	"18446744073709551615.x",
+	">18446744073709551615.18446744073709551615.18446744073709551615",
+	"<=0.0.0-0",
+	"*",
+	"<18446744073709551615.18446744073709551615.18446744073709551615",
+	">=18446744073709551615.18446744073709551615.0",
+	"^18446744073709551614.0.0",
+	"~0.18446744073709551614",
+	"0.0.18446744073709551614 - 18446744073709551615.18446744073709551615.18446744073709551615",
+	"<1.0.0 || >=18446744073709551615.0.0-0",
+	"1e3.0.0",
+	"1.2.3e-5",
+	"-1.2.3",
+	"0x1.2.3",
+	"1_000.0.0",
+	// Unicode digits and letters
+	"١.٢.٣",
+	"１.２.３",
+	"1.2.３",
+	"1.2.3-١",
+	"1.2.3+ß",
+	"ⅰ.ⅱ.ⅲ",
+	"1.2.3-é",
+	"1。2。3",
+	"^１.２",
+	// Null bytes and control characters
+	"\x00",
+	"1.2.3\x00",
+	"\x001.2.3",
+	"1.\x00.3",
+	"1.2.3-\x00probe",
+	"1.2.3+\x00probe",
+	"1.2.3\t",
+	"1.2.3\n|| 2.0.0",
+	"\r\n",
+	"\x7f",
+	"\xff\xfe",
+	"1.2.\xc3",
+	// Right-to-left and invisible characters
+	"\u202e1.2.3",
+	"1.2.3\u202e",
+	"\u200f1.2.3\u200f",
+	"1.2\u200b.3",
+	"\ufeff1.2.3",
+	">=\u202e1.0.0",
+	"א.ב.ג",
+}
+
+// generatedCorpus returns large pathological inputs.
+func generatedCorpus() []string {
+	return []string{
+		strings.Repeat("1", 10000),
+		strings.Repeat("1.", 10000),
+		strings.Repeat(".", 10000),
+		"1.2.3-" + strings.Repeat("a.", 5000) + "a",
+		"1.2.3+" + strings.Repeat("b.", 5000) + "b",
+		strings.Repeat("1.0.0 || ", 5000) + "1.0.0",
+		strings.Repeat("|| ", 5000),
+		strings.Repeat(">=1.0.0 ", 5000),
+		strings.Repeat("(", 5000) + "1.0.0" + strings.Repeat(")", 5000),
+		strings.Repeat("(", 5000),
+		strings.Repeat("!(", 2500) + "1.0.0" + strings.Repeat(")", 2500),
+		strings.Repeat("^", 5000) + "1.0.0",
+		strings.Repeat("x.", 5000),
+		strings.Repeat("\u202e", 5000),
+		strings.Repeat("\x00", 5000),
+		strings.Repeat("1.0.0 - ", 2500) + "2.0.0",
+	}
+}
+
+// corpusEntryPoints are the exported functions reading untrusted strings.
+var corpusEntryPoints = map[string]func(s string){
+	"Parse":               func(s string) { Parse(s) },
+	"ParseTolerant":       func(s string) { ParseTolerant(s) },
+	"ParseStrict":         func(s string) { ParseStrict(s) },
+	"ParseWithPrecision":  func(s string) { p, _ := ParseWithPrecision(s); _ = p.String() },
+	"New":                 func(s string) { New(s) },
+	"NewPRVersion":        func(s string) { NewPRVersion(s) },
+	"NewBuildVersion":     func(s string) { NewBuildVersion(s) },
+	"FinalizeVersion":     func(s string) { FinalizeVersion(s) },
+	"Coerce":              func(s string) { Coerce(s) },
+	"CoerceRightMost":     func(s string) { CoerceWithOptions(s, CoerceOptions{RightMost: true, IncludePrerelease: true}) },
+	"NormalizePartial":    func(s string) { NormalizePartial(s) },
+	"ParseOperator":       func(s string) { ParseOperator(s) },
+	"Tokenize":            func(s string) { Tokenize(s) },
+	"ExpandRangeString":   func(s string) { ExpandRangeString(s) },
+	"CompareParsers":      func(s string) { CompareParsers(s) },
+	"ParseSpec":           func(s string) { ParseSpec(s) },
+	"ParseVers":           func(s string) { ParseVers(s) },
+	"FromPurl":            func(s string) { FromPurl(s) },
+	"ParseGitFragment":    func(s string) { ParseGitSemverFragment("https://example.com/repo.git#semver:" + s) },
+	"CompleteRange":       func(s string) { CompleteRange(s, parseVersions("1.0.0", "1.2.3", "2.0.0-beta.1")) },
+	"DecodeVersionList":   func(s string) { DecodeVersionList([]byte(s)) },
+	"UnmarshalCompiled":   func(s string) { UnmarshalCompiledRange([]byte(s)) },
+	"GobDecode":           func(s string) { gob.NewDecoder(strings.NewReader(s)).Decode(new(Version)) },
+	"VersionGobDecode":    func(s string) { new(Version).GobDecode([]byte(s)) },
+	"VersionJSON":         func(s string) { new(Version).UnmarshalJSON([]byte(s)) },
+	"VersionJSONString":   func(s string) { new(Version).UnmarshalJSON([]byte(fmt.Sprintf("%q", s))) },
+	"ConstraintsJSON":     func(s string) { new(Constraints).UnmarshalJSON([]byte(fmt.Sprintf("%q", s))) },
+	"VersionScan":         func(s string) { new(Version).Scan(s) },
+	"EOLScheduleJSON":     func(s string) { LoadEOLSchedule(strings.NewReader(s)) },
+	"RangeCacheRestore":   func(s string) { NewRangeCache(4, RangeOptions{}).Restore(strings.NewReader(s)) },
+	"VersionIndexRestore": func(s string) { new(VersionIndex).Restore(strings.NewReader(s)) },
+	"Bump":                func(s string) { MustParse("1.2.3").Bump(BumpPrerelease, s) },
+	"Neighbours":          func(s string) { exerciseVersion(ParseTolerant(s)) },
+	"ParseRange":          func(s string) { exerciseRange(ParseRange(s)) },
+	"ParseRangeTolerant":  func(s string) { exerciseRange(ParseRangeWithOptions(s, RangeOptions{Tolerant: true})) },
+	"ParseRangeNPM":       func(s string) { exerciseRange(ParseRangeWithOptions(s, RangeOptions{NPMCompat: true})) },
+	"ParseRangeSemVerOnly": func(s string) {
+		exerciseRange(ParseRangeWithOptions(s, RangeOptions{SemVerOnly: true}))
+	},
+	"ParseRangeExplicit": func(s string) {
+		exerciseRange(ParseRangeWithOptions(s, RangeOptions{Precedence: PrecedenceExplicit}))
+	},
+	"ParseWithCargo":    func(s string) { exerciseRange(ParseWith(CargoProfile, s)) },
+	"ParseWithGem":      func(s string) { exerciseRange(ParseWith(GemProfile, s)) },
+	"ParseWithComposer": func(s string) { exerciseRange(ParseWith(ComposerProfile, s)) },
+}
+
+// exerciseRange calls the functions inspecting a parsed range.
+func exerciseRange(r Range, err error) {
+	if err != nil {
+		return
+	}
+	for _, v := range parseVersions("0.0.0", "1.0.0", "1.2.3-beta.1", "18446744073709551615.18446744073709551615.18446744073709551615") {
+		r(v)
+	}
+	_ = r.String()
+	_ = r.Slug()
+	_, _ = r.Intervals()
+	_, _ = r.Hull()
+	_, _ = r.MinSatisfying()
+	_, _ = r.MaxSatisfying()
+	_ = r.Simplify().String()
+	_, _ = UnmarshalCompiledRange(r.MarshalCompiled())
+	_, _ = r.Segments(MustParse("18446744073709551615.18446744073709551615.18446744073709551615"))
+	other := MustParseRange(">=1.2.3 <18446744073709551615.0.0 || >=18446744073709551615.0.0")
+	_, _, _, _ = r.Intersects(other), r.Subset(other), other.Subset(r), r.Subset(r)
+	// More versions than the representative ones, so that some are drawn
+	for _, v := range SampleVersions(r, 64, 1) {
+		exerciseVersion(v, nil)
+	}
+}
+
+// exerciseVersion calls the functions deriving versions from a parsed
+// version.
+func exerciseVersion(v Version, err error) {
+	if err != nil {
+		return
+	}
+	for _, grain := range []ReleaseType{ReleaseMajor, ReleaseMinor, ReleasePatch} {
+		_, _ = NextVersion(v, grain)
+		_, _ = PrevVersion(v, grain)
+		for _, previous := range []uint64{0, 2, 18446744073709551615} {
+			_ = SupportWindow(v, WindowPolicy{Grain: grain, Previous: previous})
+		}
+	}
+}
+
+// maxCorpusAlloc bounds the memory allocated for an input of n bytes, which
+// must grow linearly with the input.
+func maxCorpusAlloc(n int) uint64 {
+	return uint64(n)*4096 + 4<<20
+}
+
+func TestNegativeCorpus(t *testing.T) {
+	corpus := append(append([]string(nil), negativeCorpus...), generatedCorpus()...)
+	for name, f := range corpusEntryPoints {
+		for _, s := range corpus {
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			if p := callRecovered(f, s); p != nil {
+				t.Errorf("Invalid for case %s(%s): Unexpected panic: %v", name, quote(truncate(s, 40)), p)
+				continue
+			}
+			runtime.ReadMemStats(&after)
+			if alloc := after.TotalAlloc - before.TotalAlloc; alloc > maxCorpusAlloc(len(s)) {
+				t.Errorf("Invalid for case %s(%s): Expected at most %d bytes allocated, got: %d", name, quote(truncate(s, 40)), maxCorpusAlloc(len(s)), alloc)
+			}
+		}
+	}
+}
+
+func callRecovered(f func(string), s string) (p interface{}) {
+	defer func() {
+		p = recover()
+	}()
+	f(s)
+	return nil
+}
+
+func TestNegativeCorpusBinary(t *testing.T) {
+	// Corrupted binary encodings of valid values
+	inputs := [][]byte{
+		EncodeVersionList(parseVersions("1.0.0", "1.2.3-beta.1+build")),
+		MustParseRange("^1.2.3 || >=3.0.0-rc.1 !=3.1.0").MarshalCompiled(),
+	}
+	var gobbed bytes.Buffer
+	if err := gob.NewEncoder(&gobbed).Encode(MustParse("1.2.3-beta.1+build")); err == nil {
+		inputs = append(inputs, gobbed.Bytes())
+	}
+	decoders := map[string]func([]byte){
+		"DecodeVersionList": func(b []byte) { DecodeVersionList(b) },
+		"UnmarshalCompiled": func(b []byte) { UnmarshalCompiledRange(b) },
+		"GobDecode":         func(b []byte) { gob.NewDecoder(bytes.NewReader(b)).Decode(new(Version)) },
+	}
+	for name, decode := range decoders {
+		for _, in := range inputs {
+			for i := 0; i <= len(in); i++ {
+				variants := [][]byte{in[:i]}
+				if i < len(in) {
+					for _, c := range []byte{0x00, 0x7f, 0x80, 0xff} {
+						b := bytes.Repeat(in, 1)
+						b[i] = c
+						variants = append(variants, b)
+					}
+				}
+				for _, b := range variants {
+					if p := callRecovered(func(string) { decode(b) }, ""); p != nil {
+						t.Errorf("Invalid for case %s(%x): Unexpected panic: %v", name, b, p)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DedupePolicy selects which of several equal versions, which differ only in
+// their build meta data, Dedupe keeps.
+type DedupePolicy int
+
+const (
+	// DedupeHighestBuild keeps the version with the highest build meta
+	// data. Build identifiers are compared like prerelease identifiers:
+	// numeric ones numerically and below alphanumeric ones, and a longer
+	// build is higher if all preceding identifiers are equal. A version
+	// without build meta data is the lowest: 1.0.0+build.10 is kept over
+	// 1.0.0+build.9 and 1.0.0.
+	DedupeHighestBuild DedupePolicy = iota
+	// DedupeFirst keeps the first occurrence, e.g. from the preferred
+	// mirror.
+	DedupeFirst
+	// DedupeLast keeps the last occurrence, e.g. from the latest listing.
+	DedupeLast
+	// DedupeStripBuild keeps the first occurrence without its build meta
+	// data.
+	DedupeStripBuild
+	// DedupeKeepBuilds only removes versions which are equal including
+	// their build meta data, see SameBuild.
+	DedupeKeepBuilds
+)
+
+func (p DedupePolicy) String() string {
+	switch p {
+	case DedupeHighestBuild:
+		return "highest-build"
+	case DedupeFirst:
+		return "first"
+	case DedupeLast:
+		return "last"
+	case DedupeStripBuild:
+		return "strip-build"
+	case DedupeKeepBuilds:
+		return "keep-builds"
+	}
+	return fmt.Sprintf("DedupePolicy(%d)", int(p))
+}
+
+// Dedupe returns versions without the duplicates of equal versions, which
+// differ only in their build meta data, e.g. when merging the version lists
+// of mirrors. The policy selects the version kept of each group of equal
+// versions, it takes the position of the first one. The order of versions is
+// preserved otherwise, versions is not modified.
+func Dedupe(versions []Version, policy DedupePolicy) []Version {
+	var out []Version
+	// Indexes in out of the versions kept for each key
+	kept := make(map[VersionKey][]int, len(versions))
+	for _, v := range versions {
+		k := v.Key()
+		indexes := kept[k]
+		if len(indexes) == 0 || (policy == DedupeKeepBuilds && !containsBuild(out, indexes, v)) {
+			if policy == DedupeStripBuild {
+				v.Build = nil
+			}
+			kept[k] = append(indexes, len(out))
+			out = append(out, v)
+			continue
+		}
+		i := indexes[0]
+		switch policy {
+		case DedupeHighestBuild:
+			if compareBuild(v.Build, out[i].Build) > 0 {
+				out[i] = v
+			}
+		case DedupeLast:
+			out[i] = v
+		}
+	}
+	return out
+}
+
+// containsBuild checks if the versions at indexes of versions include v with
+// the same build meta data.
+func containsBuild(versions []Version, indexes []int, v Version) bool {
+	for _, i := range indexes {
+		if SameBuild(versions[i], v) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareBuild compares build meta data like prerelease identifiers, see
+// DedupeHighestBuild.
+func compareBuild(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareBuildIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func compareBuildIdentifier(a, b string) int {
+	aNum, bNum := containsOnly(a, numbers), containsOnly(b, numbers)
+	switch {
+	case aNum && bNum:
+		// Build identifiers may have leading zeroes and exceed uint64
+		ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if c := compareUint(uint64(len(ta)), uint64(len(tb))); c != 0 {
+			return c
+		}
+		if c := strings.Compare(ta, tb); c != 0 {
+			return c
+		}
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
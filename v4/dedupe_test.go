@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDedupe(t *testing.T) {
+	versions := parseVersions("1.0.0+build.9", "2.0.0", "1.0.0", "1.0.0+build.10", "2.0.0+mirror", "1.0.0-rc.1+b", "1.0.0+build.9", "1.0.0-rc.1")
+	tests := []struct {
+		policy DedupePolicy
+		result string
+	}{
+		{DedupeHighestBuild, "1.0.0+build.10 2.0.0+mirror 1.0.0-rc.1+b"},
+		{DedupeFirst, "1.0.0+build.9 2.0.0 1.0.0-rc.1+b"},
+		{DedupeLast, "1.0.0+build.9 2.0.0+mirror 1.0.0-rc.1"},
+		{DedupeStripBuild, "1.0.0 2.0.0 1.0.0-rc.1"},
+		{DedupeKeepBuilds, "1.0.0+build.9 2.0.0 1.0.0 1.0.0+build.10 2.0.0+mirror 1.0.0-rc.1+b 1.0.0-rc.1"},
+	}
+	for _, tc := range tests {
+		var parts []string
+		for _, v := range Dedupe(versions, tc.policy) {
+			parts = append(parts, v.String())
+		}
+		if s := strings.Join(parts, " "); s != tc.result {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.policy, tc.result, s)
+		}
+	}
+	if versions[0].String() != "1.0.0+build.9" {
+		t.Errorf("Expected the versions to be unmodified, got: %q", versions[0])
+	}
+	if Dedupe(nil, DedupeFirst) != nil {
+		t.Errorf("Expected no versions")
+	}
+}
+
+func TestCompareBuild(t *testing.T) {
+	tests := []struct {
+		a, b string
+		c    int
+	}{
+		{"", "", 0},
+		{"", "build", -1},
+		{"build.9", "build.10", -1},
+		{"build.010", "build.9", 1},
+		{"build.09", "build.9", -1},
+		{"1", "a", -1},
+		{"a", "b", -1},
+		{"build", "build.1", -1},
+		{"99999999999999999999999", "3", 1},
+		{"sha.abc", "sha.abc", 0},
+	}
+	for _, tc := range tests {
+		var a, b []string
+		if tc.a != "" {
+			a = strings.Split(tc.a, ".")
+		}
+		if tc.b != "" {
+			b = strings.Split(tc.b, ".")
+		}
+		if c := compareBuild(a, b); c != tc.c {
+			t.Errorf("Invalid for case %q and %q: Expected %d, got: %d", tc.a, tc.b, tc.c, c)
+		}
+		if c := compareBuild(b, a); c != -tc.c {
+			t.Errorf("Invalid for case %q and %q: Expected %d, got: %d", tc.b, tc.a, -tc.c, c)
+		}
+	}
+}
+
+func TestDedupePolicyString(t *testing.T) {
+	if s := DedupeKeepBuilds.String(); s != "keep-builds" {
+		t.Errorf("Expected %q, got: %q", "keep-builds", s)
+	}
+	if s := DedupePolicy(42).String(); s != "DedupePolicy(42)" {
+		t.Errorf("Expected %q, got: %q", "DedupePolicy(42)", s)
+	}
+}
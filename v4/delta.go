@@ -0,0 +1,104 @@
+package semver
+
+import "fmt"
+
+// DiffVersionSets returns the versions of new which are not in old, and the
+// versions of old which are not in new, e.g. to find the versions published
+// or unpublished between two registry snapshots. Versions are compared by
+// precedence, build meta data is ignored. Both results are sorted in
+// ascending order and free of duplicates, the inputs are not modified.
+func DiffVersionSets(old, new []Version) (added, removed []Version) {
+	oldSorted := sortedCopy(old)
+	newSorted := sortedCopy(new)
+	// Sorted inputs can not fail
+	_ = DiffVersionStreams(sliceStream(oldSorted), sliceStream(newSorted), func(v Version, isAdded bool) error {
+		if isAdded {
+			added = append(added, v)
+		} else {
+			removed = append(removed, v)
+		}
+		return nil
+	})
+	return added, removed
+}
+
+// DiffVersionStreams is the streaming variant of DiffVersionSets for
+// snapshots too large to hold in memory. old and new return the versions of
+// the snapshots in ascending order and false once they are exhausted.
+// Duplicates are skipped. emit is called in ascending order for every
+// version that was added to or removed from new, its error aborts the
+// comparison. It is an error if a stream is not in ascending order.
+func DiffVersionStreams(old, new func() (Version, bool), emit func(v Version, added bool) error) error {
+	o := &versionStream{next: old, name: "old"}
+	n := &versionStream{next: new, name: "new"}
+	if err := o.advance(); err != nil {
+		return err
+	}
+	if err := n.advance(); err != nil {
+		return err
+	}
+	for o.ok || n.ok {
+		var err error
+		switch {
+		case !n.ok || (o.ok && o.v.LT(n.v)):
+			if err = emit(o.v, false); err == nil {
+				err = o.advance()
+			}
+		case !o.ok || n.v.LT(o.v):
+			if err = emit(n.v, true); err == nil {
+				err = n.advance()
+			}
+		default:
+			if err = o.advance(); err == nil {
+				err = n.advance()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// versionStream wraps a version stream, skipping duplicates and checking the
+// order.
+type versionStream struct {
+	next func() (Version, bool)
+	name string
+	v    Version
+	ok   bool
+}
+
+func (s *versionStream) advance() error {
+	prev, hadPrev := s.v, s.ok
+	for {
+		s.v, s.ok = s.next()
+		if !s.ok || !hadPrev {
+			return nil
+		}
+		switch s.v.Compare(prev) {
+		case -1:
+			return fmt.Errorf("%s versions are not sorted: %s follows %s", s.name, s.v, prev)
+		case 1:
+			return nil
+		}
+	}
+}
+
+func sortedCopy(versions []Version) []Version {
+	sorted := make([]Version, len(versions))
+	copy(sorted, versions)
+	Sort(sorted)
+	return sorted
+}
+
+func sliceStream(versions []Version) func() (Version, bool) {
+	return func() (Version, bool) {
+		if len(versions) == 0 {
+			return Version{}, false
+		}
+		v := versions[0]
+		versions = versions[1:]
+		return v, true
+	}
+}
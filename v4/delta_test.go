@@ -0,0 +1,83 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func parseVersions(ss ...string) []Version {
+	versions := make([]Version, len(ss))
+	for i, s := range ss {
+		versions[i] = MustParse(s)
+	}
+	return versions
+}
+
+func TestDiffVersionSets(t *testing.T) {
+	tests := []struct {
+		old     []string
+		new     []string
+		added   string
+		removed string
+	}{
+		{nil, nil, "[]", "[]"},
+		{nil, []string{"1.0.0", "0.9.0"}, "[0.9.0 1.0.0]", "[]"},
+		{[]string{"1.0.0"}, nil, "[]", "[1.0.0]"},
+		{[]string{"1.0.0", "1.1.0"}, []string{"1.1.0", "1.0.0"}, "[]", "[]"},
+		{[]string{"1.0.0", "1.1.0", "1.2.0"}, []string{"1.0.0", "1.2.0", "1.3.0-beta.1", "1.3.0"}, "[1.3.0-beta.1 1.3.0]", "[1.1.0]"},
+		{[]string{"2.0.0", "1.0.0", "1.0.0"}, []string{"3.0.0", "3.0.0", "1.0.0"}, "[3.0.0]", "[2.0.0]"},
+		{[]string{"1.0.0+build.1"}, []string{"1.0.0+build.2"}, "[]", "[]"},
+	}
+	for _, tc := range tests {
+		old := parseVersions(tc.old...)
+		added, removed := DiffVersionSets(old, parseVersions(tc.new...))
+		if s := fmtVersions(added); s != tc.added {
+			t.Errorf("Invalid for case %q -> %q: Expected added %q, got: %q", tc.old, tc.new, tc.added, s)
+		}
+		if s := fmtVersions(removed); s != tc.removed {
+			t.Errorf("Invalid for case %q -> %q: Expected removed %q, got: %q", tc.old, tc.new, tc.removed, s)
+		}
+		if len(tc.old) > 0 && old[0].String() != tc.old[0] {
+			t.Errorf("Invalid for case %q: input was modified", tc.old)
+		}
+	}
+}
+
+func TestDiffVersionStreams(t *testing.T) {
+	var events []string
+	err := DiffVersionStreams(
+		sliceStream(parseVersions("1.0.0", "1.1.0", "1.1.0")),
+		sliceStream(parseVersions("1.1.0", "2.0.0")),
+		func(v Version, added bool) error {
+			if added {
+				events = append(events, "+"+v.String())
+			} else {
+				events = append(events, "-"+v.String())
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.Join(events, " "); s != "-1.0.0 +2.0.0" {
+		t.Errorf("Expected %q, got: %q", "-1.0.0 +2.0.0", s)
+	}
+
+	err = DiffVersionStreams(
+		sliceStream(nil),
+		sliceStream(parseVersions("2.0.0", "1.0.0")),
+		func(Version, bool) error { return nil })
+	if err == nil {
+		t.Errorf("Expected error for unsorted stream")
+	}
+
+	stop := errors.New("stop")
+	err = DiffVersionStreams(
+		sliceStream(nil),
+		sliceStream(parseVersions("1.0.0", "2.0.0")),
+		func(Version, bool) error { return stop })
+	if err != stop {
+		t.Errorf("Expected %v, got: %v", stop, err)
+	}
+}
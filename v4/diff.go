@@ -0,0 +1,93 @@
+package semver
+
+import "fmt"
+
+// Difference is the most significant difference between two versions, see
+// Version.Diff.
+type Difference int
+
+const (
+	// DiffNone is the difference of identical versions.
+	DiffNone Difference = iota
+	// DiffMajor is a change of the major version.
+	DiffMajor
+	// DiffMinor is a change of the minor version.
+	DiffMinor
+	// DiffPatch is a change of the patch version.
+	DiffPatch
+	// DiffPrerelease is a change of the prerelease identifiers only.
+	DiffPrerelease
+	// DiffBuild is a change of the build meta data only, which has no
+	// precedence.
+	DiffBuild
+)
+
+func (d Difference) String() string {
+	switch d {
+	case DiffNone:
+		return "none"
+	case DiffMajor:
+		return "major"
+	case DiffMinor:
+		return "minor"
+	case DiffPatch:
+		return "patch"
+	case DiffPrerelease:
+		return "prerelease"
+	case DiffBuild:
+		return "build"
+	}
+	return fmt.Sprintf("Difference(%d)", int(d))
+}
+
+// Diff returns the most significant difference between v and o like
+// node-semver's diff, e.g. to label the severity of an upgrade: 1.2.3 and
+// 1.3.0 differ in the minor version, 1.2.3-beta.1 and 1.2.3-beta.2 in the
+// prerelease. The order of v and o does not matter.
+//
+// A prerelease and a release differ like the release type which publishes
+// the prerelease, not by their prerelease: 2.0.0-rc.1 and 2.0.0 differ in
+// the major version, 1.3.0-rc.1 and 1.3.0 in the minor one and 1.2.3-rc.1
+// and 1.2.3 in the patch one.
+func (v Version) Diff(o Version) Difference {
+	low, high := v, o
+	if v.GT(o) {
+		low, high = o, v
+	}
+	if len(low.Pre) > 0 && len(high.Pre) == 0 {
+		if low.Minor == 0 && low.Patch == 0 {
+			return DiffMajor
+		}
+		if low.Major == high.Major && low.Minor == high.Minor && low.Patch == high.Patch {
+			if low.Patch == 0 {
+				return DiffMinor
+			}
+			return DiffPatch
+		}
+	}
+	switch {
+	case v.Major != o.Major:
+		return DiffMajor
+	case v.Minor != o.Minor:
+		return DiffMinor
+	case v.Patch != o.Patch:
+		return DiffPatch
+	case !samePrerelease(v.Pre, o.Pre):
+		return DiffPrerelease
+	case !sameBuild(v.Build, o.Build):
+		return DiffBuild
+	}
+	return DiffNone
+}
+
+func sameBuild(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
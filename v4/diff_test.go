@@ -0,0 +1,40 @@
+package semver
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		v, o string
+		diff Difference
+	}{
+		{"1.2.3", "1.2.3", DiffNone},
+		{"1.2.3", "2.0.0", DiffMajor},
+		{"1.2.3", "1.3.0", DiffMinor},
+		{"1.2.3", "1.2.4", DiffPatch},
+		{"1.2.3-beta.1", "1.2.3-beta.2", DiffPrerelease},
+		{"1.2.3-beta", "1.2.3-beta.1", DiffPrerelease},
+		{"1.2.3+build.1", "1.2.3+build.2", DiffBuild},
+		{"1.2.3+build", "1.2.3", DiffBuild},
+		{"1.2.3-rc.1+a", "1.2.3-rc.1+a", DiffNone},
+		{"2.0.0-rc.1", "2.0.0", DiffMajor},
+		{"1.3.0-rc.1", "1.3.0", DiffMinor},
+		{"1.2.3-rc.1", "1.2.3", DiffPatch},
+		{"1.0.0-1", "1.1.1", DiffMajor},
+		{"1.1.0-1", "1.2.0", DiffMinor},
+		{"1.2.3", "2.0.0-rc.1", DiffMajor},
+		{"1.2.3", "1.2.4-rc.1", DiffPatch},
+		{"1.2.3-rc.1", "1.2.4-rc.1", DiffPatch},
+	}
+	for _, tc := range tests {
+		v, o := MustParse(tc.v), MustParse(tc.o)
+		if d := v.Diff(o); d != tc.diff {
+			t.Errorf("Invalid for case %q, %q: Expected %s, got: %s", tc.v, tc.o, tc.diff, d)
+		}
+		if d := o.Diff(v); d != tc.diff {
+			t.Errorf("Invalid for case %q, %q: Expected %s, got: %s", tc.o, tc.v, tc.diff, d)
+		}
+	}
+	if s := Difference(42).String(); s != "Difference(42)" {
+		t.Errorf("Expected %q, got: %q", "Difference(42)", s)
+	}
+}
@@ -0,0 +1,51 @@
+package semver
+
+import "fmt"
+
+// Divergence describes a range which the legacy parser (RangeOptions.Tolerant)
+// and the current strict parser read differently.
+type Divergence struct {
+	Range      string
+	Legacy     string // expanded normal form by the legacy parser, if parsed
+	LegacyErr  error
+	Current    string // expanded normal form by the current parser, if parsed
+	CurrentErr error
+}
+
+func (d Divergence) String() string {
+	return fmt.Sprintf("%s: legacy %s, current %s", quote(d.Range), divergenceResult(d.Legacy, d.LegacyErr), divergenceResult(d.Current, d.CurrentErr))
+}
+
+func divergenceResult(s string, err error) string {
+	if err != nil {
+		return "error (" + err.Error() + ")"
+	}
+	return quote(s)
+}
+
+// CompareParsers parses the range s with both the legacy and the current
+// parser and returns their divergence, or nil if both agree: either both fail
+// or both produce the same expanded normal form. A panic of the legacy parser
+// is reported as its error.
+func CompareParsers(s string) *Divergence {
+	d := Divergence{Range: s}
+	d.Legacy, d.LegacyErr = expandWithRecover(s, RangeOptions{Tolerant: true})
+	d.Current, d.CurrentErr = expandWithRecover(s, RangeOptions{})
+	if (d.LegacyErr == nil) == (d.CurrentErr == nil) && d.Legacy == d.Current {
+		return nil
+	}
+	return &d
+}
+
+func expandWithRecover(s string, opts RangeOptions) (expanded string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("parser panicked: %v", r)
+		}
+	}()
+	c, err := parseConstraints(s, opts)
+	if err != nil {
+		return "", err
+	}
+	return c.String(), nil
+}
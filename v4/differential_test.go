@@ -0,0 +1,44 @@
+package semver
+
+import "testing"
+
+func TestCompareParsers(t *testing.T) {
+	tests := []struct {
+		r        string
+		diverges bool
+	}{
+		{">=1.2.3 <2.0.0 || ^3.1.0", false},
+		{"~1.2.x", false},
+		{"string", false},
+		{"1.2.3xyz", true},
+		{"~1.2.3abc", true},
+		{"1.2.3.4", true},
+		{"1.2.3 - 2.0.0", true},
+	}
+	for _, tc := range tests {
+		d := CompareParsers(tc.r)
+		if (d != nil) != tc.diverges {
+			t.Errorf("Invalid for case %q: Expected divergence %t, got: %v", tc.r, tc.diverges, d)
+		}
+	}
+
+	d := CompareParsers("1.2.3.4")
+	if d.Legacy != "1.2.3" || d.LegacyErr != nil || d.CurrentErr == nil {
+		t.Errorf("Unexpected divergence: %v", d)
+	}
+}
+
+func TestOnDivergence(t *testing.T) {
+	var reported []Divergence
+	opts := RangeOptions{Tolerant: true, OnDivergence: func(d Divergence) {
+		reported = append(reported, d)
+	}}
+	for _, s := range []string{"^1.2.3", "1.2.3.4"} {
+		if _, err := ParseRangeWithOptions(s, opts); err != nil {
+			t.Errorf("Invalid for case %q: %s", s, err)
+		}
+	}
+	if len(reported) != 1 || reported[0].Range != "1.2.3.4" {
+		t.Errorf("Unexpected divergences: %v", reported)
+	}
+}
@@ -0,0 +1,123 @@
+package semver
+
+import "strings"
+
+// operatorSpec describes a range operator. The scanner accepts exactly the
+// operators of rangeOperators, RangeDocumentation is generated from it.
+type operatorSpec struct {
+	token   string
+	aliases []string
+	summary string
+	example string
+}
+
+var rangeOperators = []operatorSpec{
+	{"=", []string{"", "=="}, "Matches exactly the version.", "=1.2.3"},
+	{"!=", []string{"!"}, "Matches every version except the version.", "!=1.2.3"},
+	{">", nil, "Matches versions greater than the version.", ">1.2.3"},
+	{">=", nil, "Matches versions greater than or equal to the version.", ">=1.2.3"},
+	{"<", nil, "Matches versions less than the version.", "<1.2.3"},
+	{"<=", nil, "Matches versions less than or equal to the version.", "<=1.2.3"},
+	{"~", nil, "Allows patch level changes if a minor version is given, minor level changes otherwise.", "~1.2.3"},
+	{"~>", nil, "The same as ~ like in npm.", "~>1.2"},
+	{"^", nil, "Allows changes that do not modify the left-most non-zero number, ^0.2.3 allows patch level changes only.", "^1.2.3"},
+}
+
+var rangeForms = []SyntaxDoc{
+	{Syntax: "x", Aliases: []string{"*"}, Summary: "A wildcard matches any number in its position, missing positions are wildcards as well.", Example: "1.2.x"},
+	{Syntax: "A - B", Summary: "A hyphen range matches versions from A up to B, both included; a partial B includes all versions it names.", Example: "1.2.3 - 2.3"},
+	{Syntax: "A B", Summary: "Comparators separated by whitespace must all match.", Example: ">=1.2.3 <2.0.0"},
+	{Syntax: "A || B", Summary: "Sets separated by || match if any set matches. AND binds tighter than OR.", Example: "<1.0.0 || >=2.0.0"},
+	{Syntax: "(A)", Summary: "Parentheses group a range to combine it with other comparators.", Example: "(1.x || 3.x) !=1.5.0"},
+	{Syntax: "!(A)", Summary: "A negated group matches the versions the group does not match.", Example: "!(>=2.0.0 <3.0.0)"},
+}
+
+// SyntaxDoc documents an operator or syntactic form of the range dialect.
+type SyntaxDoc struct {
+	Syntax   string   // e.g. ">=" or "A - B"
+	Aliases  []string // equivalent spellings, "" stands for a bare version
+	Summary  string   // one sentence description
+	Example  string   // example range
+	Expanded string   // expanded normal form of Example, see ExpandRangeString
+}
+
+// RangeDoc documents the range dialect accepted by ParseRange.
+type RangeDoc struct {
+	Grammar   string // EBNF grammar of a range
+	Operators []SyntaxDoc
+	Forms     []SyntaxDoc
+}
+
+// RangeDocumentation returns the documentation of the range dialect compiled
+// into this package, generated from the operator table of the parser, e.g.
+// to render help text in a user interface.
+func RangeDocumentation() RangeDoc {
+	doc := RangeDoc{Grammar: rangeGrammar()}
+	for _, op := range rangeOperators {
+		doc.Operators = append(doc.Operators, documentSyntax(SyntaxDoc{
+			Syntax:  op.token,
+			Aliases: op.aliases,
+			Summary: op.summary,
+			Example: op.example,
+		}))
+	}
+	for _, form := range rangeForms {
+		doc.Forms = append(doc.Forms, documentSyntax(form))
+	}
+	return doc
+}
+
+// documentSyntax fills in the expanded example of d, the slices are copied
+// so that callers can not modify the tables.
+func documentSyntax(d SyntaxDoc) SyntaxDoc {
+	if d.Aliases != nil {
+		d.Aliases = append([]string(nil), d.Aliases...)
+	}
+	d.Expanded, _ = ExpandRangeString(d.Example)
+	return d
+}
+
+// operatorTokens lists the spellings of all operators of rangeOperators.
+var operatorTokens = func() []string {
+	var tokens []string
+	for _, op := range rangeOperators {
+		for _, token := range append([]string{op.token}, op.aliases...) {
+			if token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	return tokens
+}()
+
+func rangeGrammar() string {
+	ops := make([]string, len(operatorTokens))
+	for i, token := range operatorTokens {
+		ops[i] = `"` + token + `"`
+	}
+	return `range      = set { "||" set } .
+set        = clause { " " clause } .
+clause     = partial " - " partial | [ operator ] partial | [ "!" ] "(" range ")" .
+operator   = ` + strings.Join(ops, " | ") + ` .
+partial    = xr [ "." xr [ "." xr [ prerelease ] [ build ] ] ] .
+xr         = "x" | "*" | number .
+number     = "0" | digit1to9 { digit } .
+prerelease = "-" identifier { "." identifier } .
+build      = "+" identifier { "." identifier } .
+identifier = ( letter | digit | "-" ) { letter | digit | "-" } .
+(* A wildcard can only be followed by wildcards, prerelease and build
+   meta data require three numbers. *)
+`
+}
+
+// scanOperator returns the length of the longest operator at the start of s,
+// or 0.
+func scanOperator(s string) int {
+	n := 0
+	for _, token := range operatorTokens {
+		if len(token) > n && strings.HasPrefix(s, token) {
+			n = len(token)
+		}
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRangeDocumentation(t *testing.T) {
+	doc := RangeDocumentation()
+	if len(doc.Operators) != len(rangeOperators) {
+		t.Fatalf("Expected %d operators, got: %d", len(rangeOperators), len(doc.Operators))
+	}
+	for _, d := range append(doc.Operators, doc.Forms...) {
+		if d.Expanded == "" {
+			t.Errorf("Example %q of %q does not parse", d.Example, d.Syntax)
+		}
+	}
+	for _, token := range operatorTokens {
+		if !strings.Contains(doc.Grammar, `"`+token+`"`) {
+			t.Errorf("Grammar does not contain operator %q", token)
+		}
+		if _, err := ParseRange(token + "1.2.3"); err != nil {
+			t.Errorf("Documented operator %q is not accepted: %s", token, err)
+		}
+	}
+
+	doc.Operators[0].Aliases[0] = "changed"
+	if RangeDocumentation().Operators[0].Aliases[0] != "" {
+		t.Errorf("RangeDocumentation must return copies")
+	}
+}
+
+func TestRangeDocumentationExpanded(t *testing.T) {
+	tests := []struct {
+		syntax   string
+		expanded string
+	}{
+		{"~", ">=1.2.3 <1.3.0"},
+		{"~>", ">=1.2.0 <1.3.0"},
+		{"^", ">=1.2.3 <2.0.0"},
+	}
+	doc := RangeDocumentation()
+	for _, tc := range tests {
+		for _, d := range doc.Operators {
+			if d.Syntax == tc.syntax && d.Expanded != tc.expanded {
+				t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.syntax, tc.expanded, d.Expanded)
+			}
+		}
+	}
+}
+
+func TestScanOperator(t *testing.T) {
+	tests := []struct {
+		s string
+		n int
+	}{
+		{">=1", 2},
+		{">1", 1},
+		{"~>1", 2},
+		{"==1", 2},
+		{"1", 0},
+		{"", 0},
+	}
+	for _, tc := range tests {
+		if n := scanOperator(tc.s); n != tc.n {
+			t.Errorf("Invalid for case %q: Expected %d, got: %d", tc.s, tc.n, n)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package semver
+
+// DowngradeEvent is a transition to a lower version in a stream of versions,
+// e.g. a rollback in a deployment history.
+type DowngradeEvent struct {
+	Index int     // position of To in the stream
+	From  Version // version before the transition
+	To    Version // lower version after the transition
+}
+
+// DetectDowngrades returns the transitions of seq to a lower version, in
+// stream order. Repeated versions and versions differing only in build meta
+// data are no transitions. seq yields the versions in event order, it is
+// shaped like an iter.Seq[Version], so range functions can be passed
+// directly. Slices can be passed with VersionsSeq.
+func DetectDowngrades(seq func(yield func(Version) bool)) []DowngradeEvent {
+	var events []DowngradeEvent
+	var prev Version
+	i := 0
+	seq(func(v Version) bool {
+		if i > 0 && v.LT(prev) {
+			events = append(events, DowngradeEvent{Index: i, From: prev, To: v})
+		}
+		prev = v
+		i++
+		return true
+	})
+	return events
+}
+
+// VersionsSeq returns a sequence yielding the versions in order, e.g. for
+// DetectDowngrades.
+func VersionsSeq(versions []Version) func(yield func(Version) bool) {
+	return func(yield func(Version) bool) {
+		for _, v := range versions {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDetectDowngrades(t *testing.T) {
+	tests := []struct {
+		versions []string
+		events   string
+	}{
+		{nil, "[]"},
+		{[]string{"1.0.0", "1.1.0", "1.1.0", "2.0.0"}, "[]"},
+		{[]string{"1.0.0", "1.1.0", "1.0.5", "1.2.0", "1.2.0-rc.1"}, "[2:1.1.0->1.0.5 4:1.2.0->1.2.0-rc.1]"},
+		{[]string{"1.0.0+build.2", "1.0.0+build.1"}, "[]"},
+		{[]string{"3.0.0", "2.0.0", "1.0.0"}, "[1:3.0.0->2.0.0 2:2.0.0->1.0.0]"},
+	}
+	for _, tc := range tests {
+		var versions []Version
+		for _, s := range tc.versions {
+			versions = append(versions, MustParse(s))
+		}
+		events := []string{}
+		for _, e := range DetectDowngrades(VersionsSeq(versions)) {
+			events = append(events, fmt.Sprintf("%d:%s->%s", e.Index, e.From, e.To))
+		}
+		if s := fmt.Sprint(events); s != tc.events {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.versions, tc.events, s)
+		}
+	}
+}
+
+func TestVersionsSeqStops(t *testing.T) {
+	n := 0
+	VersionsSeq(parseVersions("1.0.0", "2.0.0", "3.0.0"))(func(Version) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Errorf("Expected the sequence to stop after 2 versions, got: %d", n)
+	}
+}
@@ -0,0 +1,65 @@
+package semver
+
+import "fmt"
+
+// DriftReport is the result of DriftCheck.
+type DriftReport struct {
+	// Locked is the version pinned by the lockfile.
+	Locked Version
+	// Resolved is the version resolving spec today would pick, the highest
+	// available version satisfying it. It is the zero Version if
+	// Resolvable is false.
+	Resolved   Version
+	Resolvable bool
+
+	// Drifted is set if resolving today would not pick the locked version:
+	// Resolved differs from Locked by precedence, or no version resolves.
+	Drifted bool
+	// LockedSatisfies is unset if the locked version does not satisfy
+	// spec, e.g. because the manifest was edited without updating the
+	// lockfile.
+	LockedSatisfies bool
+	// LockedAvailable is unset if the locked version is not available,
+	// e.g. because it was unpublished or yanked.
+	LockedAvailable bool
+}
+
+// String describes the report as a lockfile drift warning, e.g.
+// "locked 1.2.3 would resolve to 1.4.0", or "locked 1.2.3 is current".
+func (r DriftReport) String() string {
+	var s string
+	switch {
+	case !r.Resolvable:
+		s = fmt.Sprintf("locked %s would not resolve, no available version satisfies the constraint", r.Locked)
+	case r.Drifted:
+		s = fmt.Sprintf("locked %s would resolve to %s", r.Locked, r.Resolved)
+	default:
+		s = fmt.Sprintf("locked %s is current", r.Locked)
+	}
+	if !r.LockedSatisfies {
+		s += ", the locked version does not satisfy the constraint"
+	}
+	if !r.LockedAvailable {
+		s += ", the locked version is not available"
+	}
+	return s
+}
+
+// DriftCheck checks whether resolving spec against the available versions
+// today would pick a different version than the locked one, the computation
+// behind lockfile drift warnings: with spec "^1.2.0" and 1.4.0 available,
+// 1.2.3 drifted to 1.4.0. Prereleases are picked if spec admits them, parse
+// spec with RangeOptions.NPMCompat to resolve like npm. Build meta data is
+// ignored, 1.2.3+build.2 is no drift from 1.2.3+build.1.
+func DriftCheck(spec Range, locked Version, available []Version) DriftReport {
+	r := DriftReport{Locked: locked, LockedSatisfies: spec(locked)}
+	r.Resolved, r.Resolvable = MaxSatisfying(available, spec)
+	r.Drifted = !r.Resolvable || !r.Resolved.Equals(locked)
+	for _, v := range available {
+		if v.Equals(locked) {
+			r.LockedAvailable = true
+			break
+		}
+	}
+	return r
+}
@@ -0,0 +1,41 @@
+package semver
+
+import "testing"
+
+func TestDriftCheck(t *testing.T) {
+	available := parseVersions("1.2.3", "1.3.0", "1.4.0+build.2", "2.0.0", "2.1.0-beta.1")
+	tests := []struct {
+		spec   string
+		locked string
+		report string
+	}{
+		{"^1.2.0", "1.2.3", "locked 1.2.3 would resolve to 1.4.0+build.2"},
+		{"^1.2.0", "1.4.0+build.1", "locked 1.4.0+build.1 is current"},
+		{"~1.2.0", "1.2.3", "locked 1.2.3 is current"},
+		{"^2.0.0", "1.2.3", "locked 1.2.3 would resolve to 2.1.0-beta.1, the locked version does not satisfy the constraint"},
+		{"^1.2.0", "1.2.4", "locked 1.2.4 would resolve to 1.4.0+build.2, the locked version is not available"},
+		{"~1.2.4", "1.2.4", "locked 1.2.4 would not resolve, no available version satisfies the constraint, the locked version is not available"},
+		{"^3.0.0", "1.2.3", "locked 1.2.3 would not resolve, no available version satisfies the constraint, the locked version does not satisfy the constraint"},
+	}
+	for _, tc := range tests {
+		r := DriftCheck(MustParseRange(tc.spec), MustParse(tc.locked), available)
+		if s := r.String(); s != tc.report {
+			t.Errorf("Invalid for case %q locked at %q: Expected %q, got: %q", tc.spec, tc.locked, tc.report, s)
+		}
+	}
+
+	r := DriftCheck(MustParseRange("^1.2.0"), MustParse("1.2.3"), available)
+	if !r.Drifted || !r.Resolvable || !r.LockedSatisfies || !r.LockedAvailable || r.Resolved.String() != "1.4.0+build.2" {
+		t.Errorf("Unexpected report: %+v", r)
+	}
+	npm, err := ParseRangeWithOptions("^2.0.0", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := DriftCheck(npm, MustParse("2.0.0"), available); r.Drifted {
+		t.Errorf("Expected no drift with the prerelease rule of npm, got: %+v", r)
+	}
+	if r := DriftCheck(MustParseRange("^1.2.0"), MustParse("1.4.0"), available); r.Drifted {
+		t.Errorf("Expected no drift, got: %+v", r)
+	}
+}
@@ -0,0 +1,187 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// eolDateLayout is the date format of endoflife.date.
+const eolDateLayout = "2006-01-02"
+
+// EOLSchedule maps version lines to the dates their support ends, e.g. to
+// nag users of a CLI to upgrade. A line is a version prefix like "3" for the
+// 3.x.x versions or "3.11" for the 3.11.x versions, the most specific line
+// containing a version applies. The zero value is an empty schedule.
+type EOLSchedule struct {
+	lines []eolLine
+}
+
+type eolLine struct {
+	prefix []uint64
+	eol    time.Time // zero if unknown
+	ended  bool      // support ended at an unknown date
+}
+
+// contains checks if v belongs to the line.
+func (l eolLine) contains(v Version) bool {
+	components := [3]uint64{v.Major, v.Minor, v.Patch}
+	for i, n := range l.prefix {
+		if components[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Set schedules the end of support of line, e.g. "3.11", at eol. A zero eol
+// removes the date of a known line.
+func (s *EOLSchedule) Set(line string, eol time.Time) error {
+	prefix, err := parseEOLLine(line)
+	if err != nil {
+		return err
+	}
+	s.set(eolLine{prefix: prefix, eol: eol})
+	return nil
+}
+
+func (s *EOLSchedule) set(l eolLine) {
+	for i := range s.lines {
+		if equalPrefix(s.lines[i].prefix, l.prefix) {
+			s.lines[i] = l
+			return
+		}
+	}
+	s.lines = append(s.lines, l)
+}
+
+func equalPrefix(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseEOLLine parses a line of one to three dot separated numbers.
+func parseEOLLine(line string) ([]uint64, error) {
+	parts := strings.Split(line, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version line %s", quote(line))
+	}
+	prefix := make([]uint64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version line %s", quote(line))
+		}
+		prefix[i] = n
+	}
+	return prefix, nil
+}
+
+// line returns the most specific line containing v.
+func (s *EOLSchedule) line(v Version) (eolLine, bool) {
+	var found eolLine
+	ok := false
+	for _, l := range s.lines {
+		if l.contains(v) && (!ok || len(l.prefix) > len(found.prefix)) {
+			found, ok = l, true
+		}
+	}
+	return found, ok
+}
+
+// IsEOL checks if the support of the line of v has ended at the given time.
+// Versions of unknown lines, or of lines without an end date, are supported.
+func (s *EOLSchedule) IsEOL(v Version, at time.Time) bool {
+	l, ok := s.line(v)
+	if !ok {
+		return false
+	}
+	return l.ended || !l.eol.IsZero() && !at.Before(l.eol)
+}
+
+// NextEOL returns the date the support of the line of v ends, which may lie
+// in the past. ok is false if the line is unknown or has no end date.
+func (s *EOLSchedule) NextEOL(v Version) (eol time.Time, ok bool) {
+	l, ok := s.line(v)
+	if !ok || l.eol.IsZero() {
+		return time.Time{}, false
+	}
+	return l.eol, true
+}
+
+// eolCycle is a release cycle in the format of endoflife.date.
+type eolCycle struct {
+	Cycle json.RawMessage `json:"cycle"`
+	EOL   json.RawMessage `json:"eol"`
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface. It reads
+// the release cycles of a product in the format of the endoflife.date API,
+// e.g. [{"cycle": "3.11", "eol": "2027-10-31"}]. The "eol" field is a date,
+// or a boolean whether support ended at an unknown date. Other fields are
+// ignored.
+func (s *EOLSchedule) UnmarshalJSON(data []byte) error {
+	var cycles []eolCycle
+	if err := json.Unmarshal(data, &cycles); err != nil {
+		return err
+	}
+	var schedule EOLSchedule
+	for _, c := range cycles {
+		l, err := parseEOLCycle(c)
+		if err != nil {
+			return err
+		}
+		schedule.set(l)
+	}
+	*s = schedule
+	return nil
+}
+
+func parseEOLCycle(c eolCycle) (eolLine, error) {
+	// Cycles are strings, but a few products list them as numbers
+	var line string
+	if err := json.Unmarshal(c.Cycle, &line); err != nil {
+		var n json.Number
+		if json.Unmarshal(c.Cycle, &n) != nil {
+			return eolLine{}, fmt.Errorf("invalid cycle %s", c.Cycle)
+		}
+		line = n.String()
+	}
+	prefix, err := parseEOLLine(line)
+	if err != nil {
+		return eolLine{}, err
+	}
+	l := eolLine{prefix: prefix}
+
+	var date string
+	if err := json.Unmarshal(c.EOL, &date); err == nil {
+		if l.eol, err = time.Parse(eolDateLayout, date); err != nil {
+			return eolLine{}, fmt.Errorf("cycle %s: invalid eol date %s", quote(line), quote(date))
+		}
+	} else if len(c.EOL) > 0 {
+		if err := json.Unmarshal(c.EOL, &l.ended); err != nil {
+			return eolLine{}, fmt.Errorf("cycle %s: invalid eol %s", quote(line), c.EOL)
+		}
+	}
+	return l, nil
+}
+
+// LoadEOLSchedule reads a schedule in the format of the endoflife.date API
+// from r, see EOLSchedule.UnmarshalJSON.
+func LoadEOLSchedule(r io.Reader) (*EOLSchedule, error) {
+	var s EOLSchedule
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,91 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const eolJSON = `[
+	{"cycle": "3.12", "releaseDate": "2023-10-02", "eol": "2028-10-31", "latest": "3.12.4"},
+	{"cycle": "3.11", "eol": "2027-10-31"},
+	{"cycle": "3", "eol": false},
+	{"cycle": "2.7", "eol": true},
+	{"cycle": 2, "eol": "2020-01-01"}
+]`
+
+func eolDate(s string) time.Time {
+	t, err := time.Parse(eolDateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestEOLSchedule(t *testing.T) {
+	s, err := LoadEOLSchedule(strings.NewReader(eolJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := eolDate("2027-10-31")
+	tests := []struct {
+		v     string
+		isEOL bool
+		next  string
+	}{
+		{"3.12.1", false, "2028-10-31"},
+		{"3.11.9", true, "2027-10-31"},
+		{"3.10.0", false, ""},
+		{"2.7.18", true, ""},
+		{"2.6.0", true, "2020-01-01"},
+		{"1.0.0", false, ""},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if isEOL := s.IsEOL(v, at); isEOL != tc.isEOL {
+			t.Errorf("Invalid for case %q: Expected %t, got: %t", tc.v, tc.isEOL, isEOL)
+		}
+		var next string
+		if eol, ok := s.NextEOL(v); ok {
+			next = eol.Format(eolDateLayout)
+		}
+		if next != tc.next {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.v, tc.next, next)
+		}
+	}
+	if s.IsEOL(MustParse("3.11.0"), eolDate("2027-10-30")) {
+		t.Errorf("Expected 3.11.0 to be supported before its eol date")
+	}
+}
+
+func TestEOLScheduleSet(t *testing.T) {
+	var s EOLSchedule
+	if err := s.Set("1.2", eolDate("2024-01-01")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("1.2", eolDate("2025-01-01")); err != nil {
+		t.Fatal(err)
+	}
+	if eol, ok := s.NextEOL(MustParse("1.2.3")); !ok || !eol.Equal(eolDate("2025-01-01")) {
+		t.Errorf("Expected the rescheduled eol, got: %s", eol)
+	}
+	for _, line := range []string{"", "1.x", "1.2.3.4", "-1"} {
+		if err := s.Set(line, time.Time{}); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error", line)
+		}
+	}
+}
+
+func TestEOLScheduleInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{}`,
+		`[{"cycle": "focal", "eol": "2025-04-25"}]`,
+		`[{"cycle": "1.0", "eol": "April 2025"}]`,
+		`[{"cycle": "1.0", "eol": 5}]`,
+		`[{"cycle": true}]`,
+	} {
+		if _, err := LoadEOLSchedule(strings.NewReader(data)); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error", data)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package semver
+
+import "sort"
+
+// evaluationOrder returns constraints equivalent to c whose sets check the
+// cheapest and most selective comparators first, so that a version outside
+// a set is rejected early: comparators of releases come before those of
+// prereleases, which compare their identifiers one by one, and within each,
+// exact versions come before bounds and exclusions, which rarely reject a
+// version. The sets of c keep their order, as the inspection functions
+// report them as written, and sets already in evaluation order are shared.
+func (c *Constraints) evaluationOrder() *Constraints {
+	var ordered *Constraints
+	for i, set := range c.sets {
+		if inEvaluationOrder(set) {
+			continue
+		}
+		if ordered == nil {
+			ordered = &Constraints{sets: make([][]versionRange, len(c.sets)), npm: c.npm}
+			copy(ordered.sets, c.sets)
+		}
+		sorted := make([]versionRange, len(set))
+		copy(sorted, set)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return evaluationCost(sorted[i]) < evaluationCost(sorted[j])
+		})
+		ordered.sets[i] = sorted
+	}
+	if ordered == nil {
+		return c
+	}
+	return ordered
+}
+
+// inEvaluationOrder checks if the comparators of set are ordered by their
+// evaluation cost.
+func inEvaluationOrder(set []versionRange) bool {
+	for i := 1; i < len(set); i++ {
+		if evaluationCost(set[i]) < evaluationCost(set[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluationCost ranks vr by the cost of checking it relative to the share
+// of versions it rejects, lower ranks are checked first.
+func evaluationCost(vr versionRange) int {
+	var cost int
+	switch vr.op {
+	case OpEQ:
+		cost = 0
+	case OpNE:
+		cost = 2
+	default:
+		cost = 1
+	}
+	if len(vr.v.Pre) > 0 {
+		cost += 3
+	}
+	return cost
+}
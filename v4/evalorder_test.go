@@ -0,0 +1,118 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func formatSets(c *Constraints) string {
+	var sets []string
+	for _, set := range c.sets {
+		var comparators []string
+		for _, vr := range set {
+			comparators = append(comparators, vr.op.String()+vr.v.String())
+		}
+		sets = append(sets, strings.Join(comparators, " "))
+	}
+	return strings.Join(sets, " || ")
+}
+
+func TestConstraintsEvaluationOrder(t *testing.T) {
+	tests := []struct {
+		r     string
+		order string
+	}{
+		{">=1.0.0 <2.0.0", ">=1.0.0 <2.0.0"},
+		{"<2.0.0 >=1.0.0", "<2.0.0 >=1.0.0"},
+		{"!=1.5.0 >=1.0.0 <2.0.0", ">=1.0.0 <2.0.0 !=1.5.0"},
+		{">=1.0.0-rc.1 <2.0.0 !=1.5.0", "<2.0.0 !=1.5.0 >=1.0.0-rc.1"},
+		{"!=1.2.0-beta.1 1.2.0-beta.2 >1.0.0", ">1.0.0 =1.2.0-beta.2 !=1.2.0-beta.1"},
+		{">=1.0.0 || !=3.0.0 >2.0.0", ">=1.0.0 || >2.0.0 !=3.0.0"},
+	}
+	for _, tc := range tests {
+		c := MustParseConstraints(tc.r)
+		written := formatSets(c)
+		if order := formatSets(c.evaluationOrder()); order != tc.order {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.order, order)
+		}
+		if got := formatSets(c); got != written {
+			t.Errorf("Invalid for case %q: Expected the sets to keep their order %q, got: %q", tc.r, written, got)
+		}
+	}
+
+	c := MustParseConstraints(">=1.0.0 <2.0.0 || 3.0.0")
+	if c.evaluationOrder() != c {
+		t.Errorf("Expected constraints in evaluation order to be shared")
+	}
+
+	c = MustParseConstraints("!=1.0.1 !=1.5.0 >=1.0.0 <2.0.0")
+	if written, ordered := comparisons(c, evaluationCorpus), comparisons(c.evaluationOrder(), evaluationCorpus); ordered >= written {
+		t.Errorf("Expected fewer comparisons than %d in evaluation order, got: %d", written, ordered)
+	}
+}
+
+func TestConstraintsRangeEvaluationOrder(t *testing.T) {
+	r := MustParseRange("!=1.2.0-beta.1 >=1.0.0-rc.1 <2.0.0 !=1.5.0 || 3.0.0-rc.1")
+	if got := r.String(); got != ">=1.0.0-rc.1 <2.0.0 !=1.2.0-beta.1 !=1.5.0 || 3.0.0-rc.1" {
+		t.Errorf("Expected the range to keep its comparators, got: %q", got)
+	}
+	for _, v := range parseVersions("0.9.0", "1.0.0-rc.1", "1.2.0-beta.1", "1.2.0", "1.5.0", "1.9.9", "2.0.0", "3.0.0-rc.1") {
+		want := MustParseConstraints(r.String()).Check(v)
+		if got := r(v); got != want {
+			t.Errorf("Invalid for case %q: Expected %v, got: %v", v, want, got)
+		}
+	}
+}
+
+// evaluationCorpus resembles the versions of a package registry: mostly
+// releases, some of them with prereleases.
+var evaluationCorpus = parseVersions(
+	"0.1.0", "0.9.2", "1.0.0-alpha.1", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "1.2.0-beta.1", "1.2.0",
+	"1.2.1", "1.3.0", "1.4.0", "1.5.0", "1.5.1", "1.6.0-rc.1", "1.6.0", "2.0.0-alpha.1", "2.0.0-beta.2",
+	"2.0.0-rc.1", "2.0.0", "2.0.1", "2.1.0", "2.2.0", "3.0.0-rc.1", "3.0.0", "3.1.0", "4.0.0",
+)
+
+// comparisons returns the number of comparators c checks for the versions.
+func comparisons(c *Constraints, versions []Version) int {
+	var n int
+	for _, v := range versions {
+		for _, set := range c.sets {
+			matched := true
+			for _, vr := range set {
+				n++
+				if !vr.c(v, vr.v) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+	}
+	return n
+}
+
+func BenchmarkConstraintsEvaluationOrder(b *testing.B) {
+	// Exclusions of yanked versions, written first, rarely reject a version
+	c := MustParseConstraints("!=1.0.1 !=1.2.0-beta.1 !=1.5.0 !=2.0.1 >=1.0.0-rc.1 <2.0.0 || !=3.0.0 >=3.0.0-rc.1 <4.0.0")
+	b.Run("written", func(b *testing.B) {
+		b.ReportAllocs()
+		b.ReportMetric(float64(comparisons(c, evaluationCorpus)), "comparisons/op")
+		for n := 0; n < b.N; n++ {
+			for _, v := range evaluationCorpus {
+				c.check(v)
+			}
+		}
+	})
+	b.Run("ordered", func(b *testing.B) {
+		ordered := c.evaluationOrder()
+		b.ReportAllocs()
+		b.ReportMetric(float64(comparisons(ordered, evaluationCorpus)), "comparisons/op")
+		for n := 0; n < b.N; n++ {
+			for _, v := range evaluationCorpus {
+				ordered.check(v)
+			}
+		}
+	})
+}
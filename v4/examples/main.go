@@ -81,4 +81,24 @@ func main() {
 	if err != nil {
 		fmt.Printf("Create build version failed: %s\n", err)
 	}
+
+	fmt.Println("\nMatch prereleases in ranges:")
+	rc := semver.MustParse("2.0.0-rc.1")
+	beta := semver.MustParse("1.2.4-beta.1")
+	policies := []struct {
+		name string
+		opts semver.RangeOptions
+	}{
+		{"precedence", semver.RangeOptions{}},
+		{"npm", semver.RangeOptions{NPMCompat: true}},
+		{"npm includePrerelease", semver.RangeOptions{NPMCompat: true, IncludePrerelease: true}},
+	}
+	for _, p := range policies {
+		r, err := semver.ParseRangeWithOptions("^1.2.3", p.opts)
+		if err != nil {
+			fmt.Printf("Error parsing range: %s\n", err)
+			continue
+		}
+		fmt.Printf("%s: ^1.2.3 matches %q: %t, %q: %t\n", p.name, beta, r(beta), rc, r(rc))
+	}
 }
@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"math"
+	"sync"
+)
+
+// The fast path parses the common ranges of lockfiles and manifests in a
+// single pass without intermediate strings: sets of comparators joined by
+// "||", each an optional operator ">", ">=", "<", "<=", "=", "!=", "^" or
+// "~" followed by a full release version like "1.2.3". Every other range,
+// including every invalid one, is left to the general parser, which also
+// produces the errors. Both parsers must yield the same Constraints.
+
+// fastScratch is the reused scratch space of the fast path.
+type fastScratch struct {
+	comparators []versionRange
+	ends        []int // end of each set in comparators
+}
+
+var fastScratchPool = sync.Pool{
+	New: func() interface{} { return new(fastScratch) },
+}
+
+// parseConstraintsFast parses s if it is a range of the fast path, ok is
+// false otherwise. It allocates the result only: the Constraints, its sets
+// and, with prereleaseFloor, the prereleases of the upper bounds.
+func parseConstraintsFast(s string, prereleaseFloor, npm bool) (c *Constraints, ok bool) {
+	sc := fastScratchPool.Get().(*fastScratch)
+	defer fastScratchPool.Put(sc)
+	sc.comparators, sc.ends = sc.comparators[:0], sc.ends[:0]
+
+	maxLength := loadLimits().MaxLength
+	i := skipSpaces(s, 0)
+	if i == len(s) {
+		return nil, false
+	}
+	for {
+		// Comparator
+		opStart := i
+		for i < len(s) && (s[i] == '>' || s[i] == '<' || s[i] == '=' || s[i] == '!' || s[i] == '^' || s[i] == '~') {
+			i++
+		}
+		opStr := s[opStart:i]
+		i = skipSpaces(s, i)
+		vStart := i
+		v, next, ok := scanFastVersion(s, i)
+		if !ok || maxLength > 0 && next-vStart > maxLength {
+			return nil, false
+		}
+		i = next
+		switch opStr {
+		case "^", "~":
+			var upper Version
+			var err error
+			if opStr == "^" {
+				upper, err = caretUpperBound(v, 3)
+			} else {
+				upper, err = tildeUpperBound(v, 3)
+			}
+			if err != nil {
+				return nil, false
+			}
+			if prereleaseFloor {
+				upper.Pre = []PRVersion{{IsNum: true}}
+			}
+			sc.comparators = append(sc.comparators,
+				versionRange{v: upper, c: compLT, op: OpLT},
+				versionRange{v: v, c: compGE, op: OpGTE})
+		case "==", "!":
+			// Aliases are rare, the general parser handles them
+			return nil, false
+		default:
+			op, ok := parseOperator(opStr)
+			if !ok {
+				return nil, false
+			}
+			sc.comparators = append(sc.comparators, versionRange{v: v, c: op.comparator(), op: op})
+		}
+
+		// Separator
+		if i == len(s) {
+			break
+		}
+		if s[i] != ' ' && s[i] != '|' {
+			return nil, false
+		}
+		if i = skipSpaces(s, i); i == len(s) {
+			break
+		}
+		if i+1 < len(s) && s[i] == '|' && s[i+1] == '|' {
+			sc.ends = append(sc.ends, len(sc.comparators))
+			if i = skipSpaces(s, i+2); i == len(s) {
+				return nil, false
+			}
+		}
+	}
+	sc.ends = append(sc.ends, len(sc.comparators))
+
+	comparators := make([]versionRange, len(sc.comparators))
+	copy(comparators, sc.comparators)
+	c = &Constraints{sets: make([][]versionRange, len(sc.ends)), npm: npm}
+	start := 0
+	for j, end := range sc.ends {
+		c.sets[j] = comparators[start:end:end]
+		start = end
+	}
+	return c, true
+}
+
+// scanFastVersion scans a release version without leading zeroes starting at
+// s[i] and returns it and the index following it.
+func scanFastVersion(s string, i int) (v Version, next int, ok bool) {
+	if v.Major, i, ok = scanFastNumber(s, i); !ok || i == len(s) || s[i] != '.' {
+		return Version{}, 0, false
+	}
+	if v.Minor, i, ok = scanFastNumber(s, i+1); !ok || i == len(s) || s[i] != '.' {
+		return Version{}, 0, false
+	}
+	if v.Patch, i, ok = scanFastNumber(s, i+1); !ok {
+		return Version{}, 0, false
+	}
+	return v, i, true
+}
+
+// scanFastNumber scans a number without leading zeroes starting at s[i].
+func scanFastNumber(s string, i int) (n uint64, next int, ok bool) {
+	start := i
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		d := uint64(s[i] - '0')
+		if n > (math.MaxUint64-d)/10 {
+			return 0, 0, false
+		}
+		n = n*10 + d
+	}
+	if i == start || s[start] == '0' && i-start > 1 {
+		return 0, 0, false
+	}
+	return n, i, true
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,179 @@
+package semver
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var fastRanges = []string{
+	"1.2.3",
+	"=1.2.3",
+	">1.2.3",
+	">=1.2.3 <2.0.0",
+	"  >=1.2.3   <2.0.0  ",
+	">= 1.2.3 < 2.0.0",
+	"<=1.2.3 || >2.0.0",
+	"^1.2.3",
+	"^0.2.3 || ^0.0.3",
+	"~1.2.3",
+	"~0.0.0",
+	"!=1.2.3",
+	">=1.0.0 <2.0.0 || >=3.0.1 <4.0.0 !=3.0.3 || >=5.0.0",
+	"1.2.3||2.0.0",
+	"1.2.3 ||2.0.0",
+	"18446744073709551615.18446744073709551614.0",
+	"~18446744073709551615.18446744073709551614.0",
+}
+
+// slowRanges are left to the general parser.
+var slowRanges = []string{
+	"",
+	"   ",
+	"1.2",
+	"1.2.x",
+	"v1.2.3",
+	"1.2.3-beta",
+	"1.2.3+build",
+	"01.2.3",
+	"1.02.3",
+	"1.2.03",
+	"==1.2.3",
+	"!1.2.3",
+	"=>1.2.3",
+	"~>1.2.3",
+	">=1.2.3 <",
+	"1.2.3 - 2.0.0",
+	"1.2.3 ||",
+	"|| 1.2.3",
+	"1.2.3 | 2.0.0",
+	"1.2.3 || || 2.0.0",
+	"(1.2.3)",
+	"1.2.3\t2.0.0",
+	"1.2.3a",
+	"18446744073709551616.0.0",
+	"^18446744073709551615.0.0",
+	"~0.18446744073709551615.0",
+}
+
+func TestParseConstraintsFast(t *testing.T) {
+	opts := []RangeOptions{
+		{},
+		{NPMCompat: true},
+		{NPMCompat: true, IncludePrerelease: true},
+	}
+	for _, s := range fastRanges {
+		for _, o := range opts {
+			fast, ok := parseConstraintsFast(s, o.NPMCompat, o.NPMCompat && !o.IncludePrerelease)
+			if !ok {
+				t.Errorf("Invalid for case %q: Expected the fast path", s)
+				continue
+			}
+			orParts, err := scanORParts(s)
+			if err != nil {
+				t.Errorf("Invalid for case %q: Unexpected error: %s", s, err)
+				continue
+			}
+			slow, err := buildConstraints(orParts, o)
+			if err != nil {
+				t.Errorf("Invalid for case %q: Unexpected error: %s", s, err)
+				continue
+			}
+			if !equalConstraints(fast, slow) {
+				t.Errorf("Invalid for case %q with %+v: Expected %s, got: %s", s, o, slow, fast)
+			}
+		}
+	}
+	for _, s := range slowRanges {
+		if c, ok := parseConstraintsFast(s, false, false); ok {
+			t.Errorf("Invalid for case %q: Expected the general parser, got: %s", s, c)
+		}
+	}
+}
+
+func TestParseConstraintsFastLimits(t *testing.T) {
+	defer SetLimits(DefaultLimits)
+	SetLimits(Limits{MaxLength: 4})
+	if _, ok := parseConstraintsFast("^1.2.3", false, false); ok {
+		t.Errorf("Expected a version exceeding MaxLength to take the general parser")
+	}
+	if _, err := ParseRange("^1.2.3"); err == nil {
+		t.Errorf("Expected an error for a version exceeding MaxLength")
+	}
+}
+
+// equalConstraints checks if a and b have the same comparators in the same
+// order.
+func equalConstraints(a, b *Constraints) bool {
+	if a.npm != b.npm || len(a.sets) != len(b.sets) {
+		return false
+	}
+	for i := range a.sets {
+		if len(a.sets[i]) != len(b.sets[i]) {
+			return false
+		}
+		for j, vr := range a.sets[i] {
+			other := b.sets[i][j]
+			if vr.op != other.op || !reflect.DeepEqual(vr.v, other.v) ||
+				reflect.ValueOf(vr.c).Pointer() != reflect.ValueOf(other.c).Pointer() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func BenchmarkRangeParseCaret(b *testing.B) {
+	const VERSION = "^1.2.3"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = ParseRange(VERSION)
+	}
+}
+
+func BenchmarkRangeParseLockfile(b *testing.B) {
+	ranges := []string{"^1.2.3", "~4.5.6", ">=1.0.0 <2.0.0 || >=3.0.0", "1.2.3", "^0.14.2 || ^15.0.0", ">=2.1.0"}
+	var size int64
+	for _, r := range ranges {
+		size += int64(len(r))
+	}
+	b.SetBytes(size)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, r := range ranges {
+			_, _ = ParseRange(r)
+		}
+	}
+}
+
+func TestParseConstraintsFastRandom(t *testing.T) {
+	pieces := []string{"^", "~", ">=", ">", "<", "<=", "=", "!=", "1.2.3", "0.0.0", "10.20.30", "0.1.0", " ", "  ", "||", "|"}
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 20000; n++ {
+		var b strings.Builder
+		for k := rng.Intn(8) + 1; k > 0; k-- {
+			b.WriteString(pieces[rng.Intn(len(pieces))])
+		}
+		s := b.String()
+		fast, ok := parseConstraintsFast(s, false, false)
+		if !ok {
+			continue
+		}
+		orParts, err := scanORParts(s)
+		if err != nil {
+			t.Errorf("Invalid for case %q: The general parser fails: %s", s, err)
+			continue
+		}
+		slow, err := buildConstraints(orParts, RangeOptions{})
+		if err != nil {
+			t.Errorf("Invalid for case %q: The general parser fails: %s", s, err)
+			continue
+		}
+		if !equalConstraints(fast, slow) {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", s, slow, fast)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package semver
+
+import "sort"
+
+// Filter returns the versions satisfying the range in the order of versions,
+// e.g. the allowed versions of a registry listing. versions is not modified.
+func (rf Range) Filter(versions []Version) []Version {
+	var allowed []Version
+	for _, v := range versions {
+		if rf(v) {
+			allowed = append(allowed, v)
+		}
+	}
+	return allowed
+}
+
+// FilterDescending is like Filter but returns the versions sorted from the
+// highest to the lowest, so the first one is the preferred candidate.
+// Versions of equal precedence keep their order.
+func (rf Range) FilterDescending(versions []Version) []Version {
+	allowed := rf.Filter(versions)
+	sort.SliceStable(allowed, func(i, j int) bool {
+		return allowed[i].GT(allowed[j])
+	})
+	return allowed
+}
+
+// FilterSeq returns a sequence yielding the versions of seq which satisfy the
+// range, evaluated lazily, e.g. while paging through a registry listing. seq
+// is shaped like an iter.Seq[Version], see VersionsSeq, and so is the
+// result.
+func (rf Range) FilterSeq(seq func(yield func(Version) bool)) func(yield func(Version) bool) {
+	return func(yield func(Version) bool) {
+		seq(func(v Version) bool {
+			if !rf(v) {
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
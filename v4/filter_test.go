@@ -0,0 +1,73 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func joinVersions(versions []Version) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestRangeFilter(t *testing.T) {
+	versions := parseVersions("1.2.0", "2.0.0", "1.10.0", "1.2.3-beta.1", "0.9.0", "1.4.0+build", "1.4.0")
+	tests := []struct {
+		r          string
+		filtered   string
+		descending string
+	}{
+		{"^1.2", "1.2.0 1.10.0 1.2.3-beta.1 1.4.0+build 1.4.0", "1.10.0 1.4.0+build 1.4.0 1.2.3-beta.1 1.2.0"},
+		{">=1.2.3-beta <1.3.0", "1.2.3-beta.1", "1.2.3-beta.1"},
+		{"<2 !=1.2.3-beta.1", "1.2.0 1.10.0 0.9.0 1.4.0+build 1.4.0", "1.10.0 1.4.0+build 1.4.0 1.2.0 0.9.0"},
+		{">=3", "", ""},
+	}
+	for _, tc := range tests {
+		r := MustParseRange(tc.r)
+		if s := joinVersions(r.Filter(versions)); s != tc.filtered {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.filtered, s)
+		}
+		if s := joinVersions(r.FilterDescending(versions)); s != tc.descending {
+			t.Errorf("Invalid for case %q: Expected descending %q, got: %q", tc.r, tc.descending, s)
+		}
+	}
+	if versions[1].String() != "2.0.0" {
+		t.Errorf("Expected the versions to be unmodified, got: %q", versions[1])
+	}
+}
+
+func TestRangeFilterSeq(t *testing.T) {
+	versions := parseVersions("1.2.0", "2.0.0", "1.10.0", "0.9.0", "1.4.0")
+	r := MustParseRange("^1.2")
+
+	var all []Version
+	r.FilterSeq(VersionsSeq(versions))(func(v Version) bool {
+		all = append(all, v)
+		return true
+	})
+	if s := joinVersions(all); s != "1.2.0 1.10.0 1.4.0" {
+		t.Errorf("Expected %q, got: %q", "1.2.0 1.10.0 1.4.0", s)
+	}
+
+	// Stopping early stops the underlying sequence
+	var first []Version
+	pulled := 0
+	seq := func(yield func(Version) bool) {
+		for _, v := range versions {
+			pulled++
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	r.FilterSeq(seq)(func(v Version) bool {
+		first = append(first, v)
+		return len(first) < 2
+	})
+	if s := joinVersions(first); s != "1.2.0 1.10.0" || pulled != 3 {
+		t.Errorf("Expected %q after 3 versions, got: %q after %d", "1.2.0 1.10.0", s, pulled)
+	}
+}
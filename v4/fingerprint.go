@@ -0,0 +1,72 @@
+package semver
+
+import "math"
+
+// fingerprintSpan is the number of consecutive major and minor numbers
+// mapped to distinct fingerprint bits.
+const fingerprintSpan = 8
+
+// VersionFingerprint returns a fingerprint of v with a single bit set, which
+// identifies the major/minor bucket of v. A version can only satisfy a Range
+// r if VersionFingerprint(v)&RangeFingerprint(r) != 0, so fingerprints
+// allow to route match queries across shards, or to skip shards, before
+// evaluating the Range.
+//
+// The bit of a version is (major%8)*8 + minor%8: buckets of different major
+// or minor numbers only collide if their majors and minors both differ by a
+// multiple of 8. Buckets within 8 consecutive majors and 8 consecutive
+// minors never collide.
+func VersionFingerprint(v Version) uint64 {
+	return 1 << fingerprintBit(v.Major, v.Minor)
+}
+
+func fingerprintBit(major, minor uint64) uint {
+	return uint(major%fingerprintSpan*fingerprintSpan + minor%fingerprintSpan)
+}
+
+// RangeFingerprint returns the union of the fingerprints of all versions
+// which may satisfy r, see VersionFingerprint. It is conservative: a Range
+// spanning 8 or more majors, an unbounded Range and a Range which can not
+// be inspected have all bits set. A Range matching no version has none.
+func RangeFingerprint(r Range) uint64 {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return math.MaxUint64
+	}
+	var fp uint64
+	for _, i := range c.intervals() {
+		if i.Upper.Unbounded {
+			return math.MaxUint64
+		}
+		var lo Version
+		if !i.Lower.Unbounded {
+			lo = i.Lower.Version
+		}
+		hi := i.Upper.Version
+		if hi.Major-lo.Major >= fingerprintSpan {
+			return math.MaxUint64
+		}
+		for major := lo.Major; ; major++ {
+			minLo, minHi := uint64(0), uint64(math.MaxUint64)
+			if major == lo.Major {
+				minLo = lo.Minor
+			}
+			if major == hi.Major {
+				minHi = hi.Minor
+			}
+			if minHi-minLo >= fingerprintSpan-1 {
+				minLo, minHi = 0, fingerprintSpan-1
+			}
+			for minor := minLo; ; minor++ {
+				fp |= 1 << fingerprintBit(major, minor)
+				if minor == minHi {
+					break
+				}
+			}
+			if major == hi.Major {
+				break
+			}
+		}
+	}
+	return fp
+}
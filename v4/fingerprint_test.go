@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestRangeFingerprint(t *testing.T) {
+	tests := []struct {
+		r    string
+		bits int
+	}{
+		{"1.2.3", 1},
+		{"~1.2.3", 2}, // 1.3.0-0 satisfies <1.3.0
+		{">=1.2.0 <1.2.9", 1},
+		{"^1.2.3", 9},
+		{"1.x || 3.x", 18},
+		{">=1.0.0 <9.0.0", 64},
+		{">=1.0.0", 64},
+		{"<0.3.0", 4},
+		{">2.0.0 <1.0.0", 0},
+	}
+	for _, tc := range tests {
+		if n := bits.OnesCount64(RangeFingerprint(MustParseRange(tc.r))); n != tc.bits {
+			t.Errorf("Invalid for case %q: Expected %d bits, got: %d", tc.r, tc.bits, n)
+		}
+	}
+	if fp := RangeFingerprint(func(Version) bool { return false }); fp != math.MaxUint64 {
+		t.Errorf("Expected all bits for a plain func, got: %x", fp)
+	}
+}
+
+func TestFingerprintNoFalseNegatives(t *testing.T) {
+	ranges := []string{"1.2.3", "~1.2.3", "^1.2.3", "^0.1.2", "1.x || 3.x", "<2.0.0", "!=1.5.0 >=1.0.0 <7.0.0", ">=1.2.3-beta <1.2.3"}
+	var versions []Version
+	for major := uint64(0); major < 10; major++ {
+		for minor := uint64(0); minor < 12; minor++ {
+			versions = append(versions,
+				Version{Major: major, Minor: minor, Patch: 3},
+				Version{Major: major, Minor: minor, Pre: []PRVersion{{VersionStr: "rc"}}})
+		}
+	}
+	for _, s := range ranges {
+		r := MustParseRange(s)
+		fp := RangeFingerprint(r)
+		for _, v := range versions {
+			if r(v) && VersionFingerprint(v)&fp == 0 {
+				t.Errorf("Invalid for case %q: %q matches but the fingerprints do not", s, v)
+			}
+		}
+	}
+}
+
+func TestVersionFingerprintBuckets(t *testing.T) {
+	seen := map[uint64]Version{}
+	for major := uint64(3); major < 3+fingerprintSpan; major++ {
+		for minor := uint64(5); minor < 5+fingerprintSpan; minor++ {
+			v := Version{Major: major, Minor: minor, Patch: 1}
+			fp := VersionFingerprint(v)
+			if other, ok := seen[fp]; ok {
+				t.Errorf("%q and %q collide", v, other)
+			}
+			seen[fp] = v
+		}
+	}
+	if VersionFingerprint(MustParse("1.2.3")) != VersionFingerprint(MustParse("1.2.9-beta")) {
+		t.Errorf("Versions of the same bucket must have the same fingerprint")
+	}
+}
@@ -0,0 +1,47 @@
+package semver
+
+import "sort"
+
+// FlagSet is a compiled rollout of feature flags, each flag applying to the
+// client versions of a Range. Compile it once with NewFlagSet and evaluate
+// it per request with Active. A FlagSet is safe for concurrent use.
+type FlagSet struct {
+	names  []string
+	ranges []Range
+}
+
+// NewFlagSet compiles the rollout, which maps flag names to the ranges of
+// client versions they apply to. A nil Range never applies.
+func NewFlagSet(rollout map[string]Range) *FlagSet {
+	fs := &FlagSet{names: make([]string, 0, len(rollout))}
+	for name, r := range rollout {
+		if r != nil {
+			fs.names = append(fs.names, name)
+		}
+	}
+	sort.Strings(fs.names)
+	fs.ranges = make([]Range, len(fs.names))
+	for i, name := range fs.names {
+		fs.ranges[i] = rollout[name]
+	}
+	return fs
+}
+
+// Active returns the names of the flags applying to the client version v in
+// ascending order.
+func (fs *FlagSet) Active(v Version) []string {
+	var active []string
+	for i, r := range fs.ranges {
+		if r(v) {
+			active = append(active, fs.names[i])
+		}
+	}
+	return active
+}
+
+// FlagActiveFor returns the names of the flags of rollout applying to the
+// client version v in ascending order, see NewFlagSet. Services evaluating
+// the same rollout repeatedly should compile it once with NewFlagSet.
+func FlagActiveFor(clientVersion Version, rollout map[string]Range) []string {
+	return NewFlagSet(rollout).Active(clientVersion)
+}
@@ -0,0 +1,51 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlagActiveFor(t *testing.T) {
+	rollout := map[string]Range{
+		"new-checkout": MustParseRange(">=2.3.0"),
+		"dark-mode":    MustParseRange("^2 || ^3"),
+		"legacy-sync":  MustParseRange("<2.0.0"),
+		"beta-search":  MustParseRange(">=2.4.0-beta.1 <2.4.0"),
+		"disabled":     nil,
+	}
+	tests := []struct {
+		v     string
+		flags string
+	}{
+		{"1.9.0", "[legacy-sync]"},
+		{"2.3.1", "[dark-mode new-checkout]"},
+		{"2.4.0-beta.2", "[beta-search dark-mode new-checkout]"},
+		{"4.0.0", "[new-checkout]"},
+	}
+	fs := NewFlagSet(rollout)
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if s := fmt.Sprint(FlagActiveFor(v, rollout)); s != tc.flags {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.v, tc.flags, s)
+		}
+		if s := fmt.Sprint(fs.Active(v)); s != tc.flags {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.v, tc.flags, s)
+		}
+	}
+	if flags := FlagActiveFor(MustParse("1.0.0"), nil); flags != nil {
+		t.Errorf("Expected no flags, got: %v", flags)
+	}
+}
+
+func BenchmarkFlagSetActive(b *testing.B) {
+	rollout := map[string]Range{}
+	for i := 0; i < 50; i++ {
+		rollout[fmt.Sprintf("flag-%d", i)] = MustParseRange(fmt.Sprintf(">=1.%d.0 <3.0.0", i))
+	}
+	fs := NewFlagSet(rollout)
+	v := MustParse("1.25.0")
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		fs.Active(v)
+	}
+}
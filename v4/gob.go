@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// gobFormat is the version of the gob encoding of Version. Decoders of later
+// releases keep reading all earlier formats.
+const gobFormat = 1
+
+// GobEncode implements the encoding/gob.GobEncoder interface. The encoding
+// does not depend on the layout of the Version struct, so that versions
+// encoded by one release of this package decode in later releases.
+//
+// The format is the format version 1 followed by the major, minor and patch
+// numbers, prerelease identifiers and build identifiers like in the format
+// of MarshalCompiled.
+func (v Version) GobEncode() ([]byte, error) {
+	return appendVersion([]byte{gobFormat}, v), nil
+}
+
+// GobDecode implements the encoding/gob.GobDecoder interface. The data is
+// validated, it is an error if it is truncated, has trailing bytes, an
+// unknown format version or invalid identifiers.
+//
+// Releases before the explicit encoding let gob encode the fields of
+// Version, gob does not pass such streams to GobDecode. Decode them into
+// LegacyGobVersion instead.
+func (v *Version) GobDecode(data []byte) error {
+	d := compiledDecoder{data: data}
+	if format := d.byte(); d.err == nil && format != gobFormat {
+		return fmt.Errorf("unsupported gob version format %d", format)
+	}
+	decoded := d.version()
+	if d.err == nil && len(d.data) > 0 {
+		d.err = fmt.Errorf("%d trailing bytes", len(d.data))
+	}
+	if d.err == errCompiledTruncated {
+		return errors.New("gob encoded version is truncated")
+	}
+	if d.err != nil {
+		return fmt.Errorf("invalid gob encoded version: %w", d.err)
+	}
+	*v = decoded
+	return nil
+}
+
+// LegacyGobVersion has the fields of Version as gob encoded them before
+// Version implemented GobEncode, to read gob streams written by those
+// releases: decode into a type with LegacyGobVersion where the stream has a
+// Version, e.g. a struct field, and convert it with Version. gob matches
+// struct fields by name, the name of the type does not matter.
+type LegacyGobVersion struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+	Pre   []PRVersion
+	Build []string
+}
+
+// Version returns the decoded version.
+func (l LegacyGobVersion) Version() Version {
+	return Version{Major: l.Major, Minor: l.Minor, Patch: l.Patch, Pre: l.Pre, Build: l.Build}
+}
@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGobRoundTrip(t *testing.T) {
+	type release struct {
+		Name    string
+		Version Version
+		Prev    []Version
+	}
+	in := release{
+		Name:    "cli",
+		Version: MustParse("1.2.3-rc.1.x+build.7"),
+		Prev:    []Version{MustParse("1.2.2"), MustParse("0.0.0")},
+	}
+	tolerant, err := ParseTolerant("v1.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Prev = append(in.Prev, tolerant)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out release
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Expected %+v, got: %+v", in, out)
+	}
+}
+
+// gobFixtureEncoding is the GobEncode format 1 of 2.0.0-beta.2+exp.sha.5114f85.
+const gobFixtureEncoding = "010200000201046265746100020303657870037368610735313134663835"
+
+func TestGobEncoding(t *testing.T) {
+	v := MustParse("2.0.0-beta.2+exp.sha.5114f85")
+	b, err := v.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := hex.EncodeToString(b); s != gobFixtureEncoding {
+		t.Errorf("Expected %s, got: %s", gobFixtureEncoding, s)
+	}
+	var out Version
+	if err := out.GobDecode(b); err != nil || !reflect.DeepEqual(out, v) {
+		t.Errorf("Expected %q, got: %q, %v", v, out, err)
+	}
+
+	for _, data := range [][]byte{nil, {gobFormat}, b[:len(b)-1], append(b[:len(b):len(b)], 0), append([]byte{2}, b[1:]...)} {
+		out := MustParse("1.0.0")
+		if err := out.GobDecode(data); err == nil {
+			t.Errorf("Invalid for case %x: Expected an error, got: %q", data, out)
+		} else if !out.EQ(MustParse("1.0.0")) {
+			t.Errorf("Invalid for case %x: The version must not be modified, got: %q", data, out)
+		}
+	}
+}
+
+// Gob streams written by releases before GobEncode, gob encoded the exported
+// fields of Version by name.
+const (
+	// release{"cli", 1.2.3-rc.1.x+build.7, [1.2.2 0.0.0 18446744073709551615.0.1]}
+	gobFixtureRelease = "347f0301010772656c6561736501ff8000010301044e616d65010c00010756657273696f6e01ff820001045072657601ff8a00000047ff810301010756657273696f6e01ff8200010501054d616a6f7201060001054d696e6f7201060001055061746368010600010350726501ff860001054275696c6401ff8800000021ff85020101125b5d73656d7665722e505256657273696f6e01ff860001ff8400003fff8303010109505256657273696f6e01ff84000103010a56657273696f6e537472010c00010a56657273696f6e4e756d010600010549734e756d010200000016ff87020101085b5d737472696e6701ff8800010c00001fff89020101105b5d73656d7665722e56657273696f6e01ff8a0001ff82000041ff800103636c6901010101020103010301027263000201010100010178000102056275696c640137000103010101020102000001f8ffffffffffffffff02010000"
+	// 2.0.0-beta.2+exp.sha.5114f85
+	gobFixtureVersion = "47ff810301010756657273696f6e01ff8200010501054d616a6f7201060001054d696e6f7201060001055061746368010600010350726501ff860001054275696c6401ff8800000021ff85020101125b5d73656d7665722e505256657273696f6e01ff860001ff8400003fff8303010109505256657273696f6e01ff84000103010a56657273696f6e537472010c00010a56657273696f6e4e756d010600010549734e756d010200000016ff87020101085b5d737472696e6701ff8800010c000025ff820102030201046265746100020201010001030365787003736861073531313466383500"
+)
+
+func TestGobLegacyFixtures(t *testing.T) {
+	type release struct {
+		Name    string
+		Version LegacyGobVersion
+		Prev    []LegacyGobVersion
+	}
+	data, err := hex.DecodeString(gobFixtureRelease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r release
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&r); err != nil {
+		t.Fatal(err)
+	}
+	var prev []string
+	for _, p := range r.Prev {
+		prev = append(prev, p.Version().String())
+	}
+	if r.Name != "cli" || r.Version.Version().String() != "1.2.3-rc.1.x+build.7" || fmt.Sprint(prev) != "[1.2.2 0.0.0 18446744073709551615.0.1]" {
+		t.Errorf("Unexpected release: %+v", r)
+	}
+
+	data, err = hex.DecodeString(gobFixtureVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var l LegacyGobVersion
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&l); err != nil {
+		t.Fatal(err)
+	}
+	want := Version{Major: 2, Pre: []PRVersion{{VersionStr: "beta"}, {VersionNum: 2, IsNum: true}}, Build: []string{"exp", "sha", "5114f85"}}
+	if !reflect.DeepEqual(l.Version(), want) {
+		t.Errorf("Expected %+v, got: %+v", want, l.Version())
+	}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(new(Version)); err == nil {
+		t.Errorf("Expected an error decoding a legacy stream into Version")
+	}
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Conflict is a set of dependencies on the same package which no available
+// version of that package satisfies together.
+type Conflict struct {
+	Requirements []Edge
+}
+
+// Report explains why no version of Package satisfies all dependencies on
+// it by listing the conflicting direct dependencies.
+type Report struct {
+	Package   string
+	Conflicts []Conflict
+}
+
+// Explain reports the conflicting dependencies on pkg, the available
+// versions of pkg being its nodes in g. Every edge on pkg is treated as a
+// requirement of the current selection, so g should only contain the
+// selected nodes of the other packages. Explain returns nil if a version of
+// pkg satisfies all dependencies on it or if nothing depends on pkg.
+//
+// Only the direct dependencies on pkg are reported, Explain does not derive
+// why the dependents themselves were selected like the derivation trees of
+// PubGrub. Dependencies which can't be satisfied on their own are reported
+// first, then incompatible pairs. If every pair is compatible but there
+// still is no common version, all dependencies are reported as a single
+// conflict.
+func Explain(g *Graph, pkg string) *Report {
+	var requirements []Edge
+	for _, e := range g.edges {
+		if e.To == pkg {
+			requirements = append(requirements, e)
+		}
+	}
+	if len(requirements) == 0 || g.satisfiable(pkg, requirements...) {
+		return nil
+	}
+
+	r := &Report{Package: pkg}
+	var satisfiable []Edge
+	for _, e := range requirements {
+		if g.satisfiable(pkg, e) {
+			satisfiable = append(satisfiable, e)
+		} else {
+			r.Conflicts = append(r.Conflicts, Conflict{Requirements: []Edge{e}})
+		}
+	}
+	for i := range satisfiable {
+		for j := i + 1; j < len(satisfiable); j++ {
+			if !g.satisfiable(pkg, satisfiable[i], satisfiable[j]) {
+				r.Conflicts = append(r.Conflicts, Conflict{Requirements: []Edge{satisfiable[i], satisfiable[j]}})
+			}
+		}
+	}
+	if len(r.Conflicts) == 0 {
+		r.Conflicts = append(r.Conflicts, Conflict{Requirements: satisfiable})
+	}
+	return r
+}
+
+// satisfiable checks if a node of pkg satisfies all edges.
+func (g *Graph) satisfiable(pkg string, edges ...Edge) bool {
+	for _, n := range g.nodes {
+		if n.Name != pkg {
+			continue
+		}
+		ok := true
+		for _, e := range edges {
+			if !e.Range(n.Version) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the conflict as a sentence, e.g. "a@1.0.0 requires b ^2.0.0,
+// but c@3.4.0 requires b ^1.0.0, so a@1.0.0 and c@3.4.0 are incompatible."
+func (c Conflict) String() string {
+	reqs := make([]string, len(c.Requirements))
+	froms := make([]string, len(c.Requirements))
+	for i, e := range c.Requirements {
+		reqs[i] = e.From.String() + " requires " + e.To + " " + e.Constraint
+		froms[i] = e.From.String()
+	}
+	switch len(reqs) {
+	case 0:
+		return ""
+	case 1:
+		return reqs[0] + ", but no available version of " + c.Requirements[0].To + " satisfies it."
+	case 2:
+		return reqs[0] + ", but " + reqs[1] + ", so " + froms[0] + " and " + froms[1] + " are incompatible."
+	default:
+		return joinList(reqs) + ", but no available version of " + c.Requirements[0].To +
+			" satisfies all of them, so " + joinList(froms) + " are incompatible."
+	}
+}
+
+// String renders the report as nested plain text.
+func (r *Report) String() string {
+	var b strings.Builder
+	b.WriteString("Because no version of ")
+	b.WriteString(r.Package)
+	b.WriteString(" satisfies all dependencies on it:\n")
+	for _, c := range r.Conflicts {
+		b.WriteString("  - ")
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	b.WriteString("So ")
+	b.WriteString(r.Package)
+	b.WriteString(" can not be resolved.")
+	return b.String()
+}
+
+type requirementJSON struct {
+	From       string `json:"from"`
+	Package    string `json:"package"`
+	Constraint string `json:"constraint"`
+}
+
+type conflictJSON struct {
+	Requirements []requirementJSON `json:"requirements"`
+	Message      string            `json:"message"`
+}
+
+type reportJSON struct {
+	Package   string         `json:"package"`
+	Conflicts []conflictJSON `json:"conflicts"`
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	out := reportJSON{Package: r.Package, Conflicts: make([]conflictJSON, len(r.Conflicts))}
+	for i, c := range r.Conflicts {
+		reqs := make([]requirementJSON, len(c.Requirements))
+		for j, e := range c.Requirements {
+			reqs[j] = requirementJSON{From: e.From.String(), Package: e.To, Constraint: e.Constraint}
+		}
+		out.Conflicts[i] = conflictJSON{Requirements: reqs, Message: c.String()}
+	}
+	return json.Marshal(out)
+}
+
+// joinList joins items as "a, b and c".
+func joinList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
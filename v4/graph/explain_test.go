@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	g := New()
+	g.AddNode(node("b", "1.5.0"))
+	g.AddNode(node("b", "2.1.0"))
+	g.AddEdge(node("a", "1.0.0"), "b", "^2.0.0")
+	g.AddEdge(node("c", "3.4.0"), "b", "^1.0.0")
+
+	r := Explain(g, "b")
+	if r == nil {
+		t.Fatal("Expected conflict report")
+	}
+	expected := "Because no version of b satisfies all dependencies on it:\n" +
+		"  - a@1.0.0 requires b ^2.0.0, but c@3.4.0 requires b ^1.0.0, so a@1.0.0 and c@3.4.0 are incompatible.\n" +
+		"So b can not be resolved."
+	if r.String() != expected {
+		t.Errorf("Expected report:\n%s\ngot:\n%s", expected, r)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded reportJSON
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Package != "b" || len(decoded.Conflicts) != 1 || len(decoded.Conflicts[0].Requirements) != 2 ||
+		decoded.Conflicts[0].Requirements[1].From != "c@3.4.0" || decoded.Conflicts[0].Requirements[1].Constraint != "^1.0.0" {
+		t.Errorf("Unexpected JSON report: %s", b)
+	}
+
+	if r := Explain(g, "a"); r != nil {
+		t.Errorf("Unexpected report for package without dependents: %s", r)
+	}
+	if r := Explain(New(), "b"); r != nil {
+		t.Errorf("Unexpected report for empty graph: %s", r)
+	}
+	if r := Explain(g, "missing"); r != nil {
+		t.Errorf("Unexpected report for unknown package: %s", r)
+	}
+}
+
+func TestExplainConflicts(t *testing.T) {
+	g := New()
+	g.AddNode(node("b", "1.0.0"))
+	g.AddNode(node("b", "2.0.0"))
+	g.AddNode(node("b", "3.0.0"))
+	g.AddEdge(node("a", "1.0.0"), "b", ">=2.0.0")
+	g.AddEdge(node("c", "1.0.0"), "b", "<=2.0.0")
+	if r := Explain(g, "b"); r != nil {
+		t.Fatalf("Unexpected report: %s", r)
+	}
+
+	g.AddEdge(node("d", "1.0.0"), "b", "!=2.0.0")
+	r := Explain(g, "b")
+	if r == nil || len(r.Conflicts) != 1 || len(r.Conflicts[0].Requirements) != 3 {
+		t.Fatalf("Expected single conflict of all dependencies, got %v", r)
+	}
+	expected := "a@1.0.0 requires b >=2.0.0, c@1.0.0 requires b <=2.0.0 and d@1.0.0 requires b !=2.0.0, " +
+		"but no available version of b satisfies all of them, so a@1.0.0, c@1.0.0 and d@1.0.0 are incompatible."
+	if s := r.Conflicts[0].String(); s != expected {
+		t.Errorf("Expected %q, got %q", expected, s)
+	}
+
+	g.AddEdge(node("e", "1.0.0"), "b", ">=4.0.0")
+	r = Explain(g, "b")
+	if r == nil || len(r.Conflicts) == 0 || len(r.Conflicts[0].Requirements) != 1 {
+		t.Fatalf("Expected unsatisfiable dependency first, got %v", r)
+	}
+	if s := r.Conflicts[0].String(); s != "e@1.0.0 requires b >=4.0.0, but no available version of b satisfies it." {
+		t.Errorf("Unexpected conflict: %q", s)
+	}
+}
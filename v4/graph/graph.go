@@ -0,0 +1,225 @@
+// Package graph implements a dependency graph of package versions whose
+// edges are labeled by version ranges.
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+// Node is a package at a specific version.
+type Node struct {
+	Name    string
+	Version semver.Version
+}
+
+// String returns the node as name@version.
+func (n Node) String() string {
+	return n.Name + "@" + n.Version.String()
+}
+
+// Edge is a dependency of From on the package To, constrained by Range.
+type Edge struct {
+	From       Node
+	To         string
+	Constraint string
+	Range      semver.Range
+}
+
+// String returns the edge as "name@version -> to constraint".
+func (e Edge) String() string {
+	return e.From.String() + " -> " + e.To + " " + e.Constraint
+}
+
+// Allows checks if the edge allows the node n, nodes of other packages are
+// always allowed.
+func (e Edge) Allows(n Node) bool {
+	return n.Name != e.To || e.Range(n.Version)
+}
+
+// Graph is a dependency graph. Nodes and edges are kept in insertion order,
+// so all queries are deterministic.
+type Graph struct {
+	nodes []Node
+	index map[string]int
+	edges []Edge
+}
+
+// New creates an empty Graph.
+func New() *Graph {
+	return &Graph{index: make(map[string]int)}
+}
+
+// AddNode adds n to the graph, adding the same node twice is a no-op.
+func (g *Graph) AddNode(n Node) {
+	key := n.String()
+	if _, ok := g.index[key]; ok {
+		return
+	}
+	g.index[key] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+}
+
+// AddEdge adds a dependency of from on package to constrained by the range
+// constraint. from is added to the graph if missing.
+func (g *Graph) AddEdge(from Node, to string, constraint string) error {
+	r, err := semver.ParseRange(constraint)
+	if err != nil {
+		return fmt.Errorf("dependency of %s on %s: %s", from, to, err)
+	}
+	g.AddNode(from)
+	g.edges = append(g.edges, Edge{From: from, To: to, Constraint: constraint, Range: r})
+	return nil
+}
+
+// Nodes returns all nodes of the graph.
+func (g *Graph) Nodes() []Node {
+	nodes := make([]Node, len(g.nodes))
+	copy(nodes, g.nodes)
+	return nodes
+}
+
+// Edges returns all edges of the graph.
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, len(g.edges))
+	copy(edges, g.edges)
+	return edges
+}
+
+// EdgesFrom returns the dependencies of n.
+func (g *Graph) EdgesFrom(n Node) []Edge {
+	key := n.String()
+	var edges []Edge
+	for _, e := range g.edges {
+		if e.From.String() == key {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Excluding returns the edges whose range excludes the candidate, i.e. the
+// dependencies which prevent candidate from being selected.
+func (g *Graph) Excluding(candidate Node) []Edge {
+	var edges []Edge
+	for _, e := range g.edges {
+		if !e.Allows(candidate) {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Targets returns the nodes satisfying the edge e.
+func (g *Graph) Targets(e Edge) []Node {
+	var nodes []Node
+	for _, n := range g.nodes {
+		if n.Name == e.To && e.Range(n.Version) {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// CycleError is returned by TopologicalOrder if the graph contains a cycle.
+type CycleError struct {
+	Cycle []Node
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		parts[i] = n.String()
+	}
+	return "dependency cycle: " + strings.Join(parts, " -> ")
+}
+
+// TopologicalOrder returns the nodes ordered so that every node comes after
+// all nodes satisfying its dependencies. A *CycleError is returned if the
+// graph contains a cycle.
+func (g *Graph) TopologicalOrder() ([]Node, error) {
+	if cycle := g.FindCycle(); cycle != nil {
+		return nil, &CycleError{Cycle: cycle}
+	}
+	adj := g.adjacency()
+	visited := make([]bool, len(g.nodes))
+	order := make([]Node, 0, len(g.nodes))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, j := range adj[i] {
+			visit(j)
+		}
+		order = append(order, g.nodes[i])
+	}
+	for i := range g.nodes {
+		visit(i)
+	}
+	return order, nil
+}
+
+// FindCycle returns a cycle of the graph, starting and ending with the same
+// node, or nil if the graph is acyclic.
+func (g *Graph) FindCycle() []Node {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	adj := g.adjacency()
+	state := make([]int, len(g.nodes))
+	var stack []int
+	var cycle []Node
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		state[i] = active
+		stack = append(stack, i)
+		for _, j := range adj[i] {
+			switch state[j] {
+			case active:
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k] == j {
+						for _, l := range stack[k:] {
+							cycle = append(cycle, g.nodes[l])
+						}
+						cycle = append(cycle, g.nodes[j])
+						return true
+					}
+				}
+			case unvisited:
+				if visit(j) {
+					return true
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = done
+		return false
+	}
+	for i := range g.nodes {
+		if state[i] == unvisited && visit(i) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// adjacency maps node indexes to the indexes of the nodes satisfying their
+// dependencies.
+func (g *Graph) adjacency() [][]int {
+	adj := make([][]int, len(g.nodes))
+	for _, e := range g.edges {
+		from := g.index[e.From.String()]
+		for i, n := range g.nodes {
+			if n.Name == e.To && e.Range(n.Version) {
+				adj[from] = append(adj[from], i)
+			}
+		}
+	}
+	return adj
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+func node(name, version string) Node {
+	return Node{Name: name, Version: semver.MustParse(version)}
+}
+
+func names(nodes []Node) []string {
+	s := make([]string, len(nodes))
+	for i, n := range nodes {
+		s[i] = n.String()
+	}
+	return s
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	g := New()
+	a, b, c := node("a", "1.0.0"), node("b", "2.1.0"), node("c", "3.4.0")
+	g.AddNode(c)
+	g.AddNode(b)
+	if err := g.AddEdge(a, "b", "^2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge(b, "c", ">=3.0.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := g.TopologicalOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"c@3.4.0", "b@2.1.0", "a@1.0.0"}
+	got := names(order)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected %q, got %q", expected, got)
+		}
+	}
+
+	if targets := g.Targets(g.EdgesFrom(a)[0]); len(targets) != 1 || targets[0].String() != "b@2.1.0" {
+		t.Errorf("Unexpected targets: %q", names(targets))
+	}
+	if err := g.AddEdge(a, "b", ">>1"); err == nil {
+		t.Error("Expected error for invalid constraint")
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	g := New()
+	a, b := node("a", "1.0.0"), node("b", "1.0.0")
+	g.AddEdge(a, "b", "1.x")
+	if g.FindCycle() != nil {
+		t.Fatal("Unexpected cycle")
+	}
+	g.AddEdge(b, "a", ">=1.0.0")
+
+	cycle := g.FindCycle()
+	if got := names(cycle); len(got) != 3 || got[0] != "a@1.0.0" || got[1] != "b@1.0.0" || got[2] != "a@1.0.0" {
+		t.Errorf("Unexpected cycle: %q", got)
+	}
+	_, err := g.TopologicalOrder()
+	if _, ok := err.(*CycleError); !ok {
+		t.Fatalf("Expected *CycleError, got %v", err)
+	}
+	if err.Error() != "dependency cycle: a@1.0.0 -> b@1.0.0 -> a@1.0.0" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+}
+
+func TestExcluding(t *testing.T) {
+	g := New()
+	g.AddEdge(node("a", "1.0.0"), "c", "^1.0.0")
+	g.AddEdge(node("b", "1.0.0"), "c", ">=2.0.0")
+	g.AddEdge(node("b", "1.0.0"), "d", "1.0.0")
+
+	excluding := g.Excluding(node("c", "1.5.0"))
+	if len(excluding) != 1 || excluding[0].String() != "b@1.0.0 -> c >=2.0.0" {
+		t.Errorf("Unexpected excluding edges for c@1.5.0: %v", excluding)
+	}
+	if excluding := g.Excluding(node("c", "3.0.0")); len(excluding) != 1 || excluding[0].From.Name != "a" {
+		t.Errorf("Unexpected excluding edges for c@3.0.0: %v", excluding)
+	}
+	if excluding := g.Excluding(node("e", "1.0.0")); len(excluding) != 0 {
+		t.Errorf("Unexpected excluding edges for e@1.0.0: %v", excluding)
+	}
+}
@@ -0,0 +1,287 @@
+package semver
+
+// maxGroupedSets bounds the number of comparator sets of a range with
+// groups, as AND and negation multiply the sets of their operands.
+const maxGroupedSets = 1024
+
+// groupParser parses a range with groups and negations:
+//
+//	range  = set { "||" set } .
+//	set    = clause { clause } .
+//	clause = [ "!" ] "(" range ")" | comparator .
+//
+// The grammar is the one of the default PrecedenceAND, PrecedenceOR swaps
+// the roles of "||" and AND. The comparators are compiled like in a range
+// without groups, the groups are combined in the disjunctive form of
+// Constraints.
+type groupParser struct {
+	sc     *rangeScanner
+	tokens []Token
+	pos    int
+	opts   RangeOptions
+}
+
+// parseGroupedConstraints parses the range s which contains parentheses.
+func parseGroupedConstraints(s string, opts RangeOptions) (*Constraints, error) {
+	tokens, err := scanRange(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &groupParser{sc: &rangeScanner{s: s}, tokens: tokens, opts: opts}
+	c, err := p.parseRange()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(tokens) {
+		t := tokens[p.pos]
+		return nil, p.sc.errorf(ErrUnexpectedToken, t.Offset, t.Text, "unexpected %s", quote(t.Text))
+	}
+	return c, nil
+}
+
+func (p *groupParser) peek() (Token, bool) {
+	if p.pos == len(p.tokens) {
+		return Token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// nextText returns the text of the next token, or "" at the end of the
+// range.
+func (p *groupParser) nextText() string {
+	t, _ := p.peek()
+	return t.Text
+}
+
+// textAt returns the text of the token at offset, or "" if there is none.
+func (p *groupParser) textAt(offset int) string {
+	for _, t := range p.tokens {
+		if t.Offset == offset {
+			return t.Text
+		}
+	}
+	return ""
+}
+
+// offset returns the offset of the next token, or the end of the range.
+func (p *groupParser) offset() int {
+	if t, ok := p.peek(); ok {
+		return t.Offset
+	}
+	return len(p.sc.s)
+}
+
+// parseRange parses clauses joined by "||" or AND up to the end of the range
+// or a closing parenthesis, and combines them by p.opts.Precedence.
+func (p *groupParser) parseRange() (*Constraints, error) {
+	var clauses []*Constraints
+	var ors []bool    // whether "||" or AND joins clauses i and i+1
+	var offsets []int // offset of the join of clauses i and i+1
+	for {
+		t, ok := p.peek()
+		if !ok || t.Kind == TokenOr || t.Kind == TokenCloseParen {
+			return nil, p.sc.errorf(ErrEmptyRange, p.offset(), p.nextText(), "expected range")
+		}
+		clause, err := p.parseClause()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+
+		t, ok = p.peek()
+		if !ok || t.Kind == TokenCloseParen {
+			return p.combine(clauses, ors, offsets)
+		}
+		if t.Kind == TokenOr {
+			p.pos++
+		}
+		ors = append(ors, t.Kind == TokenOr)
+		offsets = append(offsets, t.Offset)
+	}
+}
+
+// combine joins clauses by the operators ors, the operator of higher
+// precedence first.
+func (p *groupParser) combine(clauses []*Constraints, ors []bool, offsets []int) (*Constraints, error) {
+	orFirst := p.opts.Precedence == PrecedenceOR
+	for i := range ors {
+		if ors[i] == ors[0] {
+			continue
+		}
+		if p.opts.Precedence == PrecedenceExplicit {
+			return nil, p.sc.errorf(ErrAmbiguousPrecedence, offsets[i], p.textAt(offsets[i]), "'||' and AND mixed without parentheses")
+		}
+		if p.opts.OnAmbiguousPrecedence != nil {
+			binds := "AND binds tighter than '||'"
+			if orFirst {
+				binds = "'||' binds tighter than AND"
+			}
+			p.opts.OnAmbiguousPrecedence(p.sc.errorf(ErrAmbiguousPrecedence, offsets[i], p.textAt(offsets[i]), "'||' and AND mixed without parentheses, %s", binds).(*RangeSyntaxError))
+		}
+		break
+	}
+
+	// Group the clauses joined by the operator of higher precedence, then
+	// join the groups
+	var groups []*Constraints
+	var groupOffsets []int
+	first := 0
+	for i := 0; i <= len(ors); i++ {
+		if i < len(ors) && ors[i] == orFirst {
+			continue
+		}
+		group, err := p.joinAll(clauses[first:i+1], orFirst, offsets[first:i])
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+		if i < len(ors) {
+			groupOffsets = append(groupOffsets, offsets[i])
+		}
+		first = i + 1
+	}
+	return p.joinAll(groups, !orFirst, groupOffsets)
+}
+
+// joinAll joins cs by "||" if or is set, by AND otherwise. offsets are the
+// offsets of the joins of cs[i] and cs[i+1]. Joining all at once keeps long
+// ranges like ">=1.0.0 >=1.0.0 ..." linear, as the sets are not copied and
+// checked again for every clause.
+func (p *groupParser) joinAll(cs []*Constraints, or bool, offsets []int) (*Constraints, error) {
+	if len(cs) == 1 {
+		return cs[0], nil
+	}
+	if or {
+		var n int
+		for _, c := range cs {
+			n += len(c.sets)
+		}
+		sets := make([][]versionRange, 0, n)
+		for _, c := range cs {
+			sets = append(sets, c.sets...)
+		}
+		return &Constraints{sets: sets, npm: cs[0].npm}, nil
+	}
+
+	// The comparators of single sets are joined into one set, the
+	// disjunctions are distributed over it afterwards
+	var set []versionRange
+	var single bool
+	var disjunctions []*Constraints
+	var disjunctionOffsets []int
+	for i, c := range cs {
+		if len(c.sets) == 1 {
+			set = append(set, c.sets[0]...)
+			single = true
+			continue
+		}
+		disjunctions = append(disjunctions, c)
+		if i > 0 {
+			disjunctionOffsets = append(disjunctionOffsets, offsets[i-1])
+		} else {
+			disjunctionOffsets = append(disjunctionOffsets, offsets[0])
+		}
+	}
+	c := &Constraints{sets: [][]versionRange{set}, npm: cs[0].npm}
+	if !single {
+		c, disjunctions, disjunctionOffsets = disjunctions[0], disjunctions[1:], disjunctionOffsets[1:]
+	}
+	c, err := p.check(c, offsets[0])
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range disjunctions {
+		if c, err = p.check(andConstraints(c, d), disjunctionOffsets[i]); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// check drops the sets of c no version satisfies, unless no set would remain,
+// and checks that c does not exceed maxGroupedSets.
+func (p *groupParser) check(c *Constraints, offset int) (*Constraints, error) {
+	sets := c.sets[:0:0]
+	for _, set := range c.sets {
+		if len(setIntervals(set)) > 0 {
+			sets = append(sets, set)
+		}
+	}
+	if len(sets) > 0 {
+		c.sets = sets
+	}
+	if len(c.sets) > maxGroupedSets {
+		return nil, p.sc.errorf(ErrRangeTooComplex, offset, p.textAt(offset), "range has more than %d sets of comparators", maxGroupedSets)
+	}
+	return c, nil
+}
+
+func (p *groupParser) parseClause() (*Constraints, error) {
+	t := p.tokens[p.pos]
+	negate := t.Kind == TokenOperator && t.Text == "!" && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].Kind == TokenOpenParen
+	if negate {
+		p.pos++
+		t = p.tokens[p.pos]
+	}
+	if t.Kind != TokenOpenParen {
+		clause, next, err := p.sc.scanClause(p.tokens, p.pos)
+		if err != nil {
+			return nil, err
+		}
+		start := p.pos
+		p.pos = next
+		c, err := buildConstraints([][]string{{clause}}, p.opts)
+		if err != nil {
+			return nil, p.sc.versionError(p.tokens[start:next], p.opts.NPMCompat)
+		}
+		return c, nil
+	}
+	p.pos++
+	c, err := p.parseRange()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); !ok || t.Kind != TokenCloseParen {
+		return nil, p.sc.errorf(ErrUnexpectedToken, p.offset(), p.nextText(), "expected ')'")
+	}
+	p.pos++
+	if !negate {
+		return c, nil
+	}
+	result := &Constraints{sets: [][]versionRange{nil}, npm: c.npm}
+	for _, set := range c.sets {
+		if result, err = p.check(andConstraints(result, negateSet(set, c.npm)), t.Offset); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// negateSet returns the negation of a set of comparators: the disjunction of
+// its negated comparators. The negation of a range is the conjunction of its
+// negated sets.
+func negateSet(set []versionRange, npm bool) *Constraints {
+	negated := &Constraints{npm: npm}
+	for _, vr := range set {
+		op := vr.op.negate()
+		negated.sets = append(negated.sets, []versionRange{{v: vr.v, c: op.comparator(), op: op}})
+	}
+	return negated
+}
+
+// negate returns the operator matching exactly the versions op does not.
+func (op Operator) negate() Operator {
+	switch op {
+	case OpNE:
+		return OpEQ
+	case OpGT:
+		return OpLTE
+	case OpGTE:
+		return OpLT
+	case OpLT:
+		return OpGTE
+	case OpLTE:
+		return OpGT
+	}
+	return OpNE
+}
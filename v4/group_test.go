@@ -0,0 +1,151 @@
+package semver
+
+import "testing"
+
+func TestParseRangeGroups(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{"(1.2.3)", "1.2.3"},
+		{"!(>=2.0.0 <3.0.0) || >=4.0.0", "<2.0.0 || >=3.0.0 || >=4.0.0"},
+		{"( >=2.0.0 <3.0.0 )", ">=2.0.0 <3.0.0"},
+		{"(1.x || 3.x) !=1.5.0", ">=1.0.0 <2.0.0 !=1.5.0 || >=3.0.0 <4.0.0 !=1.5.0"},
+		{"!(1.2.3)", "!=1.2.3"},
+		{"!(!=1.2.3)", "1.2.3"},
+		{"! (^1.2)", ">=2.0.0 || <1.2.0"},
+		{"!(1.x || 3.x)", "<1.0.0 <3.0.0 || >=2.0.0 <3.0.0 || >=2.0.0 >=4.0.0"},
+		{"!(!(~1.2.3))", ">=1.2.3 <1.3.0"},
+		{"((1.2.3 - 2))", ">=1.2.3 <3.0.0"},
+		{">=1.0.0 (<2.0.0 || >3.0.0)", ">=1.0.0 <2.0.0 || >=1.0.0 >3.0.0"},
+		{"!1.2.3 (>1.0.0)", ">1.0.0 !=1.2.3"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraints(tc.i)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if s := c.String(); s != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, s)
+		}
+	}
+}
+
+func TestParseRangeGroupsMatch(t *testing.T) {
+	r := MustParseRange("!( >=2.0.0 <3.0.0 ) || >=4.0.0")
+	for v, expected := range map[string]bool{"1.9.9": true, "2.0.0": false, "2.9.9": false, "3.0.0": true, "4.1.0": true} {
+		if r(MustParse(v)) != expected {
+			t.Errorf("Invalid for case %q: Expected %t", v, expected)
+		}
+	}
+}
+
+func TestParseRangeGroupsInvalid(t *testing.T) {
+	tests := []struct {
+		i      string
+		offset int
+	}{
+		{"()", 1},
+		{"(1.2.3", 6},
+		{"1.2.3)", 5},
+		{"(1.2.3 ||)", 9},
+		{"!=(1.2.3)", 2},
+		{"(1.2.3) - 2", 8},
+		{"(>=)", 3},
+		{"(1.2.3x)", 6},
+	}
+	for _, tc := range tests {
+		_, err := ParseRange(tc.i)
+		serr, ok := err.(*RangeSyntaxError)
+		if !ok {
+			t.Errorf("Invalid for case %q: Expected *RangeSyntaxError, got: %v", tc.i, err)
+			continue
+		}
+		if serr.Offset != tc.offset {
+			t.Errorf("Invalid for case %q: Expected offset %d, got: %d (%s)", tc.i, tc.offset, serr.Offset, serr)
+		}
+	}
+
+	if _, err := ParseRangeWithOptions("(1.2.3)", RangeOptions{SemVerOnly: true}); err == nil {
+		t.Errorf("Expected groups to be rejected in SemVer-only mode")
+	}
+
+	// Every negated set multiplies the sets of the result
+	s := "!(1.0.0 2.0.0)"
+	for i := 0; i < 10; i++ {
+		s += " !(1.0.0 2.0.0)"
+	}
+	if _, err := ParseRange(s); err == nil {
+		t.Errorf("Expected error for a range with too many sets")
+	}
+}
+
+func TestParseRangePrecedence(t *testing.T) {
+	tests := []struct {
+		i          string
+		precedence Precedence
+		o          string
+	}{
+		{"1.x || >=2.0.0 <2.5.0", PrecedenceAND, ">=1.0.0 <2.0.0 || >=2.0.0 <2.5.0"},
+		{"1.x || >=2.0.0 <2.5.0", PrecedenceOR, ">=1.0.0 <2.0.0 <2.5.0 || >=2.0.0 <2.5.0"},
+		{">=1.0.0 <2.0.0 || >=3.0.0 !=3.1.0", PrecedenceOR, ">=1.0.0 <2.0.0 !=3.1.0 || >=1.0.0 >=3.0.0 !=3.1.0"},
+		{"1.2.3 || 2.0.0 || 3.0.0", PrecedenceOR, "1.2.3 || 2.0.0 || 3.0.0"},
+		{">=1.0.0 <2.0.0", PrecedenceOR, ">=1.0.0 <2.0.0"},
+		{"(1.x || 2.x) !=1.5.0", PrecedenceExplicit, ">=1.0.0 <2.0.0 !=1.5.0 || >=2.0.0 <3.0.0 !=1.5.0"},
+		{"1.x || (>=2.0.0 <2.5.0)", PrecedenceExplicit, ">=1.0.0 <2.0.0 || >=2.0.0 <2.5.0"},
+		{"^1.2.3", PrecedenceExplicit, ">=1.2.3 <2.0.0"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.i, RangeOptions{Precedence: tc.precedence})
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if s := c.String(); s != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, s)
+		}
+	}
+}
+
+func TestParseRangePrecedenceExplicit(t *testing.T) {
+	tests := []struct {
+		i      string
+		offset int
+	}{
+		{"1.x || >=2.0.0 <2.5.0", 15},
+		{">=1.0.0 <2.0.0 || 3.x", 15},
+		{"(1.x 2.x || 3.x)", 9},
+	}
+	for _, tc := range tests {
+		_, err := ParseRangeWithOptions(tc.i, RangeOptions{Precedence: PrecedenceExplicit})
+		serr, ok := err.(*RangeSyntaxError)
+		if !ok {
+			t.Errorf("Invalid for case %q: Expected *RangeSyntaxError, got: %v", tc.i, err)
+		} else if serr.Offset != tc.offset {
+			t.Errorf("Invalid for case %q: Expected offset %d, got: %d (%s)", tc.i, tc.offset, serr.Offset, serr)
+		}
+	}
+}
+
+func TestOnAmbiguousPrecedence(t *testing.T) {
+	var warnings []*RangeSyntaxError
+	opts := RangeOptions{OnAmbiguousPrecedence: func(err *RangeSyntaxError) {
+		warnings = append(warnings, err)
+	}}
+	for _, s := range []string{">=1.0.0 <2.0.0", "1.x || 2.x", "(1.x || 2.x) !=1.5.0"} {
+		if _, err := ParseRangeWithOptions(s, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got: %v", warnings)
+	}
+	r, err := ParseRangeWithOptions("1.x || >=2.0.0 <2.5.0", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || warnings[0].Offset != 15 {
+		t.Fatalf("Expected one warning at offset 15, got: %v", warnings)
+	}
+	// The warning does not change the result
+	if !r(MustParse("1.5.0")) {
+		t.Errorf("Expected AND to bind tighter")
+	}
+}
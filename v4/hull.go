@@ -0,0 +1,43 @@
+package semver
+
+// Intervals returns the versions satisfying the range as intervals, sorted by
+// version, disjoint and not adjacent: "^1.2 || >=3 !=3.1.0" yields
+// [1.2.0,2.0.0), [3.0.0,3.1.0) and (3.1.0,). Gaps between the intervals are
+// the versions the range excludes. Versions are compared by precedence, the
+// prerelease rule of RangeOptions.NPMCompat is not taken into account. It is
+// empty if no version satisfies the range and returns ErrRangeNotInspectable
+// if the range was not created by this package.
+func (rf Range) Intervals() ([]Interval, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return nil, ErrRangeNotInspectable
+	}
+	intervals := c.intervals()
+	for i := range intervals {
+		intervals[i] = withoutBuilds(intervals[i])
+	}
+	return intervals, nil
+}
+
+// Hull returns the least interval containing all versions satisfying the
+// range: "^1.2 || >=3 <4" yields [1.2.0,4.0.0). It returns
+// ErrNoSatisfyingVersion if no version satisfies the range and
+// ErrRangeNotInspectable if the range was not created by this package.
+func (rf Range) Hull() (Interval, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return Interval{}, ErrRangeNotInspectable
+	}
+	intervals := c.intervals()
+	if len(intervals) == 0 {
+		return Interval{}, ErrNoSatisfyingVersion
+	}
+	return withoutBuilds(Interval{Lower: intervals[0].Lower, Upper: intervals[len(intervals)-1].Upper}), nil
+}
+
+// withoutBuilds returns i with the build meta data of its endpoints removed.
+func withoutBuilds(i Interval) Interval {
+	i.Lower.Version = withoutBuild(i.Lower.Version)
+	i.Upper.Version = withoutBuild(i.Upper.Version)
+	return i
+}
@@ -0,0 +1,77 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRangeIntervals(t *testing.T) {
+	tests := []struct {
+		r         string
+		intervals string
+		hull      string
+	}{
+		{"^1.2 || >=3 !=3.1.0", "[1.2.0,2.0.0) [3.0.0,3.1.0) (3.1.0,)", "[1.2.0,)"},
+		{"^1.2 || >=3 <4", "[1.2.0,2.0.0) [3.0.0,4.0.0)", "[1.2.0,4.0.0)"},
+		{">1.0.0 <=2.0.0-rc.1", "(1.0.0,2.0.0-rc.1]", "(1.0.0,2.0.0-rc.1]"},
+		{"<1.0.0 || >=1.0.0", "(,)", "(,)"},
+		{"<1.0.0", "(,1.0.0)", "(,1.0.0)"},
+		{"1.2.3+build", "[1.2.3,1.2.3]", "[1.2.3,1.2.3]"},
+		{">=1.0.0 <2.0.0 || >=1.5.0 <3.0.0", "[1.0.0,3.0.0)", "[1.0.0,3.0.0)"},
+		{">2.0.0 <1.0.0", "", ""},
+	}
+	for _, tc := range tests {
+		r := MustParseRange(tc.r)
+		intervals, err := r.Intervals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parts []string
+		for _, i := range intervals {
+			parts = append(parts, i.String())
+		}
+		if s := strings.Join(parts, " "); s != tc.intervals {
+			t.Errorf("Invalid for case %q: Expected intervals %q, got: %q", tc.r, tc.intervals, s)
+		}
+
+		hull, err := r.Hull()
+		if tc.hull == "" {
+			if err != ErrNoSatisfyingVersion {
+				t.Errorf("Invalid for case %q: Expected ErrNoSatisfyingVersion, got: %v", tc.r, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+		} else if s := hull.String(); s != tc.hull {
+			t.Errorf("Invalid for case %q: Expected hull %q, got: %q", tc.r, tc.hull, s)
+		}
+	}
+}
+
+func TestRangeIntervalsContains(t *testing.T) {
+	r := MustParseRange(">=1.0.0 <3.0.0 !=2.0.0 || 5.0.0")
+	intervals, err := r.Intervals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range parseVersions("0.9.0", "1.0.0", "2.0.0", "2.5.0", "3.0.0", "5.0.0", "5.0.1") {
+		in := false
+		for _, i := range intervals {
+			in = in || i.Contains(v)
+		}
+		if in != r(v) {
+			t.Errorf("Invalid for case %q: Expected %t, got: %t", v, r(v), in)
+		}
+	}
+}
+
+func TestRangeIntervalsNotInspectable(t *testing.T) {
+	r := Range(func(Version) bool { return true })
+	if _, err := r.Intervals(); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+	if _, err := r.Hull(); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+}
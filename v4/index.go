@@ -0,0 +1,245 @@
+package semver
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"sync"
+)
+
+// minIndexBuffer is the least number of added versions a VersionIndex
+// buffers before merging them into its sorted versions.
+const minIndexBuffer = 64
+
+// VersionIndex is a set of versions, e.g. the published versions of a
+// package in a registry, answering range queries without scanning every
+// version. Versions are identified by their string form, so versions which
+// only differ in build meta data are distinct.
+//
+// Add and Remove update the index incrementally: added versions are
+// buffered and merged into the sorted versions once the buffer exceeds the
+// square root of the index size, removed versions are marked and dropped on
+// the next merge. So long running services need not rebuild the index on
+// every publish. A VersionIndex is safe for concurrent use.
+type VersionIndex struct {
+	mu      sync.RWMutex
+	sorted  []Version
+	added   []Version           // sorted, not yet merged into sorted
+	removed map[string]struct{} // marked entries of sorted
+	members map[string]struct{} // string forms of all versions
+	pending map[string]struct{} // string forms of added
+}
+
+// NewVersionIndex creates a VersionIndex of versions, duplicates are
+// ignored.
+func NewVersionIndex(versions []Version) *VersionIndex {
+	idx := &VersionIndex{
+		removed: make(map[string]struct{}),
+		members: make(map[string]struct{}, len(versions)),
+		pending: make(map[string]struct{}),
+	}
+	for _, v := range versions {
+		if s := v.String(); !idx.contains(s) {
+			idx.members[s] = struct{}{}
+			idx.sorted = append(idx.sorted, v)
+		}
+	}
+	sort.Slice(idx.sorted, func(i, j int) bool {
+		return indexLess(idx.sorted[i], idx.sorted[j])
+	})
+	return idx
+}
+
+// indexLess orders versions by precedence, versions of equal precedence by
+// their string form.
+func indexLess(a, b Version) bool {
+	if c := a.Compare(b); c != 0 {
+		return c < 0
+	}
+	return a.String() < b.String()
+}
+
+func (idx *VersionIndex) contains(s string) bool {
+	_, ok := idx.members[s]
+	return ok
+}
+
+// Len returns the number of versions in the index.
+func (idx *VersionIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.members)
+}
+
+// Add adds v to the index and reports whether it was added, it is not if the
+// index already contains v.
+func (idx *VersionIndex) Add(v Version) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	s := v.String()
+	if idx.contains(s) {
+		return false
+	}
+	if _, ok := idx.removed[s]; ok {
+		delete(idx.removed, s)
+		idx.members[s] = struct{}{}
+		return true
+	}
+	i := sort.Search(len(idx.added), func(i int) bool {
+		return indexLess(v, idx.added[i])
+	})
+	idx.added = append(idx.added, Version{})
+	copy(idx.added[i+1:], idx.added[i:])
+	idx.added[i] = v
+	idx.members[s] = struct{}{}
+	idx.pending[s] = struct{}{}
+	if len(idx.added) > minIndexBuffer && float64(len(idx.added)) > math.Sqrt(float64(len(idx.sorted))) {
+		idx.merge()
+	}
+	return true
+}
+
+// Remove removes v from the index and reports whether it was removed, it is
+// not if the index does not contain v.
+func (idx *VersionIndex) Remove(v Version) bool {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	s := v.String()
+	if !idx.contains(s) {
+		return false
+	}
+	delete(idx.members, s)
+	if _, ok := idx.pending[s]; ok {
+		delete(idx.pending, s)
+		i := sort.Search(len(idx.added), func(i int) bool {
+			return !indexLess(idx.added[i], v)
+		})
+		idx.added = append(idx.added[:i], idx.added[i+1:]...)
+		return true
+	}
+	idx.removed[s] = struct{}{}
+	if len(idx.removed) > minIndexBuffer && len(idx.removed) > len(idx.sorted)/2 {
+		idx.merge()
+	}
+	return true
+}
+
+// merge merges the added versions into the sorted versions and drops the
+// removed ones.
+func (idx *VersionIndex) merge() {
+	merged := make([]Version, 0, len(idx.members))
+	idx.scan(idx.sorted, idx.added, func(v Version) bool {
+		merged = append(merged, v)
+		return true
+	})
+	idx.sorted = merged
+	idx.added = nil
+	idx.removed = make(map[string]struct{})
+	idx.pending = make(map[string]struct{})
+}
+
+// scan calls f for the versions of a and b in ascending order, skipping
+// removed versions, until f returns false. a and b must be sorted.
+func (idx *VersionIndex) scan(a, b []Version, f func(Version) bool) {
+	for len(a) > 0 || len(b) > 0 {
+		var v Version
+		if len(b) == 0 || (len(a) > 0 && indexLess(a[0], b[0])) {
+			v, a = a[0], a[1:]
+			if len(idx.removed) > 0 {
+				if _, ok := idx.removed[v.String()]; ok {
+					continue
+				}
+			}
+		} else {
+			v, b = b[0], b[1:]
+		}
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// Versions returns the versions of the index in ascending order.
+func (idx *VersionIndex) Versions() []Version {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	versions := make([]Version, 0, len(idx.members))
+	idx.scan(idx.sorted, idx.added, func(v Version) bool {
+		versions = append(versions, v)
+		return true
+	})
+	return versions
+}
+
+// Query returns the versions of the index satisfying r in ascending order.
+// Only the versions within the bounds of r are visited, unless r can not be
+// inspected.
+func (idx *VersionIndex) Query(r Range) []Version {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var result []Version
+	collect := func(v Version) bool {
+		if r(v) {
+			result = append(result, v)
+		}
+		return true
+	}
+	c, ok := constraintsOf(r)
+	if !ok {
+		idx.scan(idx.sorted, idx.added, collect)
+		return result
+	}
+	for _, i := range c.intervals() {
+		idx.scan(intervalSpan(idx.sorted, i), intervalSpan(idx.added, i), collect)
+	}
+	return result
+}
+
+// Snapshot writes the versions of the index to w in the format of
+// EncodeVersionList, so that a restarted process can Restore a warm index.
+func (idx *VersionIndex) Snapshot(w io.Writer) error {
+	_, err := w.Write(EncodeVersionList(idx.Versions()))
+	return err
+}
+
+// Restore replaces the versions of the index by the ones of a snapshot
+// written by Snapshot. The index is unchanged if the snapshot is invalid.
+func (idx *VersionIndex) Restore(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	versions, err := DecodeVersionList(data)
+	if err != nil {
+		return err
+	}
+	restored := NewVersionIndex(versions)
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.sorted, idx.added = restored.sorted, nil
+	idx.removed, idx.members, idx.pending = restored.removed, restored.members, restored.pending
+	return nil
+}
+
+// intervalSpan returns the versions of sorted within the bounds of i.
+func intervalSpan(sorted []Version, i Interval) []Version {
+	start := 0
+	if !i.Lower.Unbounded {
+		start = sort.Search(len(sorted), func(j int) bool {
+			c := sorted[j].Compare(i.Lower.Version)
+			return c > 0 || (c == 0 && i.Lower.Inclusive)
+		})
+	}
+	end := len(sorted)
+	if !i.Upper.Unbounded {
+		end = sort.Search(len(sorted), func(j int) bool {
+			c := sorted[j].Compare(i.Upper.Version)
+			return c > 0 || (c == 0 && !i.Upper.Inclusive)
+		})
+	}
+	if end < start {
+		return nil
+	}
+	return sorted[start:end]
+}
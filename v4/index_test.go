@@ -0,0 +1,158 @@
+package semver
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestVersionIndexQuery(t *testing.T) {
+	idx := NewVersionIndex(parseVersions("1.0.0", "1.2.3", "1.2.3+b", "1.5.0-beta.1", "1.5.0", "2.0.0-rc.1", "2.0.0", "3.1.0", "1.2.3"))
+	tests := []struct {
+		r        Range
+		versions string
+	}{
+		{MustParseRange("^1.2"), "[1.2.3 1.2.3+b 1.5.0-beta.1 1.5.0 2.0.0-rc.1]"},
+		{MustParseRange("1.2.3"), "[1.2.3 1.2.3+b]"},
+		{MustParseRange("<1.2.3 || >2.0.0"), "[1.0.0 3.1.0]"},
+		{MustParseRange("!=1.5.0 >=1.5.0-0 <3.0.0"), "[1.5.0-beta.1 2.0.0-rc.1 2.0.0]"},
+		{MustParseRange(">4.0.0"), "[]"},
+		{Range(func(v Version) bool { return v.Minor > 0 }), "[1.2.3 1.2.3+b 1.5.0-beta.1 1.5.0 3.1.0]"},
+	}
+	for _, tc := range tests {
+		if s := fmtVersions(idx.Query(tc.r)); s != tc.versions {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.r, tc.versions, s)
+		}
+	}
+	if n := idx.Len(); n != 8 {
+		t.Errorf("Expected 8 versions, got: %d", n)
+	}
+}
+
+func TestVersionIndexAddRemove(t *testing.T) {
+	idx := NewVersionIndex(parseVersions("1.0.0", "2.0.0"))
+	if !idx.Add(MustParse("1.5.0")) || idx.Add(MustParse("1.5.0")) || idx.Add(MustParse("1.0.0")) {
+		t.Errorf("Unexpected result of Add")
+	}
+	if !idx.Remove(MustParse("1.0.0")) || idx.Remove(MustParse("1.0.0")) || idx.Remove(MustParse("3.0.0")) {
+		t.Errorf("Unexpected result of Remove")
+	}
+	if !idx.Remove(MustParse("1.5.0")) || !idx.Add(MustParse("1.0.0")) {
+		t.Errorf("Unexpected result of Remove and Add")
+	}
+	if s := fmtVersions(idx.Versions()); s != "[1.0.0 2.0.0]" {
+		t.Errorf("Expected [1.0.0 2.0.0], got: %s", s)
+	}
+}
+
+// TestVersionIndexRandom checks random incremental updates against a
+// rebuilt index, across several merges.
+func TestVersionIndexRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() Version {
+		v := Version{Major: uint64(rnd.Intn(5)), Minor: uint64(rnd.Intn(10)), Patch: uint64(rnd.Intn(10))}
+		if rnd.Intn(4) == 0 {
+			v.Pre = []PRVersion{{VersionStr: "beta"}, {VersionNum: uint64(rnd.Intn(3)), IsNum: true}}
+		}
+		return v
+	}
+
+	idx := NewVersionIndex(nil)
+	set := map[string]Version{}
+	r := MustParseRange(">=1.2.0 <3.0.0 || 4.5.x")
+	for i := 0; i < 5000; i++ {
+		v := random()
+		_, exists := set[v.String()]
+		if rnd.Intn(3) == 0 {
+			if idx.Remove(v) != exists {
+				t.Fatalf("Invalid for case %s: Remove expected %t", v, exists)
+			}
+			delete(set, v.String())
+		} else {
+			if idx.Add(v) == exists {
+				t.Fatalf("Invalid for case %s: Add expected %t", v, !exists)
+			}
+			set[v.String()] = v
+		}
+		if i%500 != 0 {
+			continue
+		}
+		var all []Version
+		for _, v := range set {
+			all = append(all, v)
+		}
+		rebuilt := NewVersionIndex(all)
+		if got, want := fmtVersions(idx.Versions()), fmtVersions(rebuilt.Versions()); got != want {
+			t.Fatalf("Invalid after %d updates: Expected %s, got: %s", i, want, got)
+		}
+		if got, want := fmtVersions(idx.Query(r)), fmtVersions(rebuilt.Query(r)); got != want {
+			t.Fatalf("Invalid query after %d updates: Expected %s, got: %s", i, want, got)
+		}
+	}
+}
+
+func TestVersionIndexConcurrent(t *testing.T) {
+	idx := NewVersionIndex(nil)
+	r := MustParseRange("^1")
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				v := MustParse(fmt.Sprintf("1.%d.%d", w, i))
+				idx.Add(v)
+				idx.Query(r)
+				if i%2 == 0 {
+					idx.Remove(v)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if n := len(idx.Query(r)); n != 400 {
+		t.Errorf("Expected 400 versions, got: %d", n)
+	}
+}
+
+func BenchmarkVersionIndexAdd(b *testing.B) {
+	var versions []Version
+	for i := 0; i < 100000; i++ {
+		versions = append(versions, Version{Major: uint64(i / 1000), Minor: uint64(i % 1000)})
+	}
+	idx := NewVersionIndex(versions)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		idx.Add(Version{Major: 1000, Patch: uint64(n)})
+	}
+}
+
+func TestVersionIndexSnapshot(t *testing.T) {
+	idx := NewVersionIndex(parseVersions("1.0.0", "1.2.3-beta.1+b", "2.0.0"))
+	idx.Add(MustParse("1.5.0"))
+	idx.Remove(MustParse("2.0.0"))
+	var buf bytes.Buffer
+	if err := idx.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewVersionIndex(parseVersions("3.0.0"))
+	if err := restored.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if s := fmtVersions(restored.Versions()); s != "[1.0.0 1.2.3-beta.1+b 1.5.0]" {
+		t.Errorf("Expected [1.0.0 1.2.3-beta.1+b 1.5.0], got: %s", s)
+	}
+	if !restored.Add(MustParse("2.0.0")) || restored.Add(MustParse("1.5.0")) {
+		t.Errorf("Unexpected result of Add after Restore")
+	}
+
+	if err := restored.Restore(bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Errorf("Expected error for a truncated snapshot")
+	}
+	if n := restored.Len(); n != 4 {
+		t.Errorf("Expected the index to be unchanged, got %d versions", n)
+	}
+}
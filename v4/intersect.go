@@ -0,0 +1,63 @@
+package semver
+
+// Intersects checks if a version can satisfy both the range and other,
+// computed from the comparators without enumerating versions: "^1.2" and
+// ">=1.5.0 <3.0.0" intersect, "^1.2" and "^2" do not. Ranges are compared by
+// precedence, the prerelease rule of RangeOptions.NPMCompat is not taken into
+// account. It returns false if either range can not be inspected.
+func (rf Range) Intersects(other Range) bool {
+	a, b, ok := inspectBoth(rf, other)
+	if !ok {
+		return false
+	}
+	for _, i := range a {
+		for _, j := range b {
+			if !i.Intersect(j).Empty() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Subset checks if every version satisfying the range satisfies other as
+// well, computed from the comparators without enumerating versions: "~1.2.3"
+// is a subset of "^1.2", but not of ">=1.2.4". A range no version satisfies
+// is a subset of every range. Ranges are compared by precedence, the
+// prerelease rule of RangeOptions.NPMCompat is not taken into account. It
+// returns false if either range can not be inspected.
+func (rf Range) Subset(other Range) bool {
+	a, b, ok := inspectBoth(rf, other)
+	if !ok {
+		return false
+	}
+	// The intervals of other are disjoint and not adjacent, so every interval
+	// of the range must lie within one of them.
+	for _, i := range a {
+		covered := false
+		for _, j := range b {
+			if compareLower(j.Lower, i.Lower) <= 0 && compareUpper(i.Upper, j.Upper) <= 0 {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// inspectBoth returns the intervals of a and b, ok is false if either range
+// can not be inspected.
+func inspectBoth(a, b Range) (ia, ib []Interval, ok bool) {
+	ca, ok := constraintsOf(a)
+	if !ok {
+		return nil, nil, false
+	}
+	cb, ok := constraintsOf(b)
+	if !ok {
+		return nil, nil, false
+	}
+	return ca.intervals(), cb.intervals(), true
+}
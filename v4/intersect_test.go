@@ -0,0 +1,66 @@
+package semver
+
+import "testing"
+
+func TestRangeIntersects(t *testing.T) {
+	tests := []struct {
+		a, b string
+		ok   bool
+	}{
+		{"^1.2", ">=1.5.0 <3.0.0", true},
+		{"^1.2", "^2", false},
+		{"<1.0.0", ">=1.0.0", false},
+		{"<=1.0.0", ">=1.0.0", true},
+		{"1.2.3", "1.2.x", true},
+		{"1.2.3", "!=1.2.3", false},
+		{">=1.0.0 <2.0.0 || >=3.0.0", "2.x", false},
+		{">=1.0.0 <2.0.0 || >=3.0.0", "2.x || 3.1.0", true},
+		{">1.0.0 <1.0.1", ">=1.0.1-beta <1.0.1", true},
+		{"*", "0.0.0", true},
+		{">2.0.0 <1.0.0", "*", false},
+	}
+	for _, tc := range tests {
+		a, b := MustParseRange(tc.a), MustParseRange(tc.b)
+		if ok := a.Intersects(b); ok != tc.ok {
+			t.Errorf("Invalid for case %q and %q: Expected %t, got: %t", tc.a, tc.b, tc.ok, ok)
+		}
+		if ok := b.Intersects(a); ok != tc.ok {
+			t.Errorf("Invalid for case %q and %q: Expected %t, got: %t", tc.b, tc.a, tc.ok, ok)
+		}
+	}
+
+	opaque := Range(func(Version) bool { return true })
+	if MustParseRange("*").Intersects(opaque) || opaque.Intersects(MustParseRange("*")) {
+		t.Errorf("Expected no intersection with an opaque range")
+	}
+}
+
+func TestRangeSubset(t *testing.T) {
+	tests := []struct {
+		a, b string
+		ok   bool
+	}{
+		{"~1.2.3", "^1.2", true},
+		{"~1.2.3", ">=1.2.4", false},
+		{"^1.2", "~1.2.3", false},
+		{"1.2.3", "1.x", true},
+		{"1.2.3", "!=1.2.3", false},
+		{">=1.0.0 <2.0.0", "<1.5.0 || >=1.5.0 <3.0.0", true},
+		{">=1.0.0 <2.0.0", "<1.5.0 || >1.5.0 <3.0.0", false},
+		{"1.x || 3.x", ">=1.0.0", true},
+		{"1.x || 3.x", "<3.5.0", false},
+		{">2.0.0 <1.0.0", "1.2.3", true},
+		{"*", ">=0.0.0-0", true},
+		{"*", "*", true},
+	}
+	for _, tc := range tests {
+		if ok := MustParseRange(tc.a).Subset(MustParseRange(tc.b)); ok != tc.ok {
+			t.Errorf("Invalid for case %q and %q: Expected %t, got: %t", tc.a, tc.b, tc.ok, ok)
+		}
+	}
+
+	opaque := Range(func(Version) bool { return true })
+	if MustParseRange("1.2.3").Subset(opaque) || opaque.Subset(MustParseRange("*")) {
+		t.Errorf("Expected no subset with an opaque range")
+	}
+}
@@ -0,0 +1,220 @@
+package semver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Endpoint is the lower or upper end of an Interval. An unbounded lower
+// endpoint is below every version, an unbounded upper endpoint above every
+// version, Version and Inclusive are ignored then.
+type Endpoint struct {
+	Version   Version
+	Inclusive bool
+	Unbounded bool
+}
+
+// Interval is a contiguous set of versions between two endpoints, as
+// returned by Range.Intervals. For sets of intervals and their complements
+// see package interval.
+type Interval struct {
+	Lower Endpoint
+	Upper Endpoint
+}
+
+// fullInterval contains every version.
+var fullInterval = Interval{
+	Lower: Endpoint{Unbounded: true},
+	Upper: Endpoint{Unbounded: true},
+}
+
+// compareLower orders lower endpoints by the least version they admit: an
+// unbounded endpoint comes first, an inclusive endpoint before an exclusive
+// one of the same version.
+func compareLower(a, b Endpoint) int {
+	if a.Unbounded || b.Unbounded {
+		return boolCompare(!a.Unbounded, !b.Unbounded)
+	}
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	return boolCompare(!a.Inclusive, !b.Inclusive)
+}
+
+// compareUpper orders upper endpoints by the greatest version they admit: an
+// unbounded endpoint comes last, an exclusive endpoint before an inclusive
+// one of the same version.
+func compareUpper(a, b Endpoint) int {
+	if a.Unbounded || b.Unbounded {
+		return boolCompare(a.Unbounded, b.Unbounded)
+	}
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	return boolCompare(a.Inclusive, b.Inclusive)
+}
+
+func boolCompare(a, b bool) int {
+	if a == b {
+		return 0
+	}
+	if a {
+		return 1
+	}
+	return -1
+}
+
+// Empty checks if the interval contains no version.
+func (i Interval) Empty() bool {
+	if i.Lower.Unbounded || i.Upper.Unbounded {
+		return false
+	}
+	c := i.Lower.Version.Compare(i.Upper.Version)
+	return c > 0 || (c == 0 && !(i.Lower.Inclusive && i.Upper.Inclusive))
+}
+
+// Contains checks if v lies within the interval.
+func (i Interval) Contains(v Version) bool {
+	if !i.Lower.Unbounded {
+		c := v.Compare(i.Lower.Version)
+		if c < 0 || (c == 0 && !i.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !i.Upper.Unbounded {
+		c := v.Compare(i.Upper.Version)
+		if c > 0 || (c == 0 && !i.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns the intersection of i and o, which may be empty.
+func (i Interval) Intersect(o Interval) Interval {
+	r := i
+	if compareLower(o.Lower, r.Lower) > 0 {
+		r.Lower = o.Lower
+	}
+	if compareUpper(o.Upper, r.Upper) < 0 {
+		r.Upper = o.Upper
+	}
+	return r
+}
+
+// touches checks if the union of i and o, with i starting first, is
+// contiguous.
+func (i Interval) touches(o Interval) bool {
+	if i.Upper.Unbounded || o.Lower.Unbounded {
+		return true
+	}
+	c := i.Upper.Version.Compare(o.Lower.Version)
+	return c > 0 || (c == 0 && (i.Upper.Inclusive || o.Lower.Inclusive))
+}
+
+// String returns the interval in the mathematical notation, e.g.
+// "[1.2.3,2.0.0)", an unbounded endpoint is left empty: "(,2.0.0)". An empty
+// interval is "{}".
+func (i Interval) String() string {
+	if i.Empty() {
+		return "{}"
+	}
+	var b strings.Builder
+	if i.Lower.Inclusive && !i.Lower.Unbounded {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if !i.Lower.Unbounded {
+		b.WriteString(i.Lower.Version.String())
+	}
+	b.WriteByte(',')
+	if !i.Upper.Unbounded {
+		b.WriteString(i.Upper.Version.String())
+	}
+	if i.Upper.Inclusive && !i.Upper.Unbounded {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// intervals returns the sorted, disjoint intervals of the versions
+// satisfying vr.
+func (vr versionRange) intervals() []Interval {
+	point := Endpoint{Version: vr.v, Inclusive: true}
+	switch vr.op {
+	case OpNE:
+		return []Interval{
+			{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Version: vr.v}},
+			{Lower: Endpoint{Version: vr.v}, Upper: Endpoint{Unbounded: true}},
+		}
+	case OpGT:
+		return []Interval{{Lower: Endpoint{Version: vr.v}, Upper: Endpoint{Unbounded: true}}}
+	case OpGTE:
+		return []Interval{{Lower: point, Upper: Endpoint{Unbounded: true}}}
+	case OpLT:
+		return []Interval{{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Version: vr.v}}}
+	case OpLTE:
+		return []Interval{{Lower: Endpoint{Unbounded: true}, Upper: point}}
+	}
+	return []Interval{{Lower: point, Upper: point}}
+}
+
+// setIntervals returns the sorted, disjoint intervals of the versions
+// satisfying all comparators of set.
+func setIntervals(set []versionRange) []Interval {
+	result := []Interval{fullInterval}
+	for _, vr := range set {
+		var next []Interval
+		for _, a := range result {
+			for _, b := range vr.intervals() {
+				if i := a.Intersect(b); !i.Empty() {
+					next = append(next, i)
+				}
+			}
+		}
+		result = next
+	}
+	return mergeIntervals(result)
+}
+
+// intervals returns the sorted, disjoint intervals of the versions
+// satisfying c.
+func (c *Constraints) intervals() []Interval {
+	var all []Interval
+	for _, set := range c.sets {
+		all = append(all, setIntervals(set)...)
+	}
+	return mergeIntervals(all)
+}
+
+// mergeIntervals returns the union of the intervals, sorted by version,
+// disjoint and not adjacent, so that every set of versions has exactly one
+// representation. Empty intervals are dropped, in is not modified.
+func mergeIntervals(in []Interval) []Interval {
+	if len(in) == 0 {
+		return nil
+	}
+	sorted := make([]Interval, 0, len(in))
+	for _, i := range in {
+		if !i.Empty() {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return compareLower(sorted[a].Lower, sorted[b].Lower) < 0
+	})
+	var out []Interval
+	for _, i := range sorted {
+		if n := len(out); n > 0 && out[n-1].touches(i) {
+			if compareUpper(i.Upper, out[n-1].Upper) > 0 {
+				out[n-1].Upper = i.Upper
+			}
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
@@ -0,0 +1,256 @@
+// Package interval implements the algebra of version intervals: endpoints
+// which are open, closed or unbounded, and sets of disjoint intervals with
+// intersection, union and complement. Endpoints and intervals are those of
+// package semver, as returned by Range.Intervals. Ranges of package semver
+// can be converted to interval sets with FromConstraints.
+package interval
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+// Endpoint is the lower or upper end of an interval, see semver.Endpoint.
+type Endpoint = semver.Endpoint
+
+// Interval is a contiguous set of versions between two endpoints, see
+// semver.Interval.
+type Interval = semver.Interval
+
+// Full contains every version.
+var Full = Interval{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Unbounded: true}}
+
+// Closed returns the interval [a, b].
+func Closed(a, b semver.Version) Interval {
+	return Interval{Lower: Endpoint{Version: a, Inclusive: true}, Upper: Endpoint{Version: b, Inclusive: true}}
+}
+
+// HalfOpen returns the interval [a, b), like the range ">=a <b".
+func HalfOpen(a, b semver.Version) Interval {
+	return Interval{Lower: Endpoint{Version: a, Inclusive: true}, Upper: Endpoint{Version: b}}
+}
+
+// Point returns the interval containing only v.
+func Point(v semver.Version) Interval {
+	return Closed(v, v)
+}
+
+// AtLeast returns the interval of versions greater than or equal to v.
+func AtLeast(v semver.Version) Interval {
+	return Interval{Lower: Endpoint{Version: v, Inclusive: true}, Upper: Endpoint{Unbounded: true}}
+}
+
+// Above returns the interval of versions greater than v.
+func Above(v semver.Version) Interval {
+	return Interval{Lower: Endpoint{Version: v}, Upper: Endpoint{Unbounded: true}}
+}
+
+// AtMost returns the interval of versions less than or equal to v.
+func AtMost(v semver.Version) Interval {
+	return Interval{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Version: v, Inclusive: true}}
+}
+
+// Below returns the interval of versions less than v.
+func Below(v semver.Version) Interval {
+	return Interval{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Version: v}}
+}
+
+// PrereleaseFloor returns the least version with the major, minor and patch
+// number of v, which is v with the prerelease "0". It precedes all other
+// prereleases of the release, so Below(PrereleaseFloor(v)) excludes the
+// prereleases of v which Below(v) contains, and AtLeast(PrereleaseFloor(v))
+// includes them, like the range ">=1.2.3-0".
+func PrereleaseFloor(v semver.Version) semver.Version {
+	return semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: []semver.PRVersion{{VersionNum: 0, IsNum: true}}}
+}
+
+// CompareLower orders lower endpoints by the least version they admit: an
+// unbounded endpoint comes first, an inclusive endpoint before an exclusive
+// one of the same version.
+func CompareLower(a, b Endpoint) int {
+	if a.Unbounded || b.Unbounded {
+		return boolCompare(!a.Unbounded, !b.Unbounded)
+	}
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	return boolCompare(!a.Inclusive, !b.Inclusive)
+}
+
+// CompareUpper orders upper endpoints by the greatest version they admit: an
+// unbounded endpoint comes last, an exclusive endpoint before an inclusive
+// one of the same version.
+func CompareUpper(a, b Endpoint) int {
+	if a.Unbounded || b.Unbounded {
+		return boolCompare(a.Unbounded, b.Unbounded)
+	}
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	return boolCompare(a.Inclusive, b.Inclusive)
+}
+
+func boolCompare(a, b bool) int {
+	if a == b {
+		return 0
+	}
+	if a {
+		return 1
+	}
+	return -1
+}
+
+// Set is a union of intervals. Sets returned by this package are
+// normalized: sorted, free of empty intervals, and disjoint and
+// non-adjacent, so that every set of versions has exactly one
+// representation.
+type Set []Interval
+
+// Of returns the normalized union of the intervals, intervals is not
+// modified.
+func Of(intervals ...Interval) Set {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sorted := make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		if !i.Empty() {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return CompareLower(sorted[a].Lower, sorted[b].Lower) < 0
+	})
+	var out Set
+	for _, i := range sorted {
+		if n := len(out); n > 0 && touches(out[n-1], i) {
+			if CompareUpper(i.Upper, out[n-1].Upper) > 0 {
+				out[n-1].Upper = i.Upper
+			}
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// touches checks if the union of a and b, with a starting first, is
+// contiguous.
+func touches(a, b Interval) bool {
+	if a.Upper.Unbounded || b.Lower.Unbounded {
+		return true
+	}
+	c := a.Upper.Version.Compare(b.Lower.Version)
+	return c > 0 || (c == 0 && (a.Upper.Inclusive || b.Lower.Inclusive))
+}
+
+// Contains checks if v lies within any interval of s.
+func (s Set) Contains(v semver.Version) bool {
+	for _, i := range s {
+		if i.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the versions in s or o.
+func (s Set) Union(o Set) Set {
+	all := make([]Interval, 0, len(s)+len(o))
+	all = append(all, s...)
+	all = append(all, o...)
+	return Of(all...)
+}
+
+// Intersect returns the versions in both s and o.
+func (s Set) Intersect(o Set) Set {
+	var all []Interval
+	for _, a := range s {
+		for _, b := range o {
+			if i := a.Intersect(b); !i.Empty() {
+				all = append(all, i)
+			}
+		}
+	}
+	return Of(all...)
+}
+
+// Complement returns the versions not in s.
+func (s Set) Complement() Set {
+	var out Set
+	lower := Endpoint{Unbounded: true}
+	for _, i := range Of(s...) {
+		if !i.Lower.Unbounded {
+			gap := Interval{Lower: lower, Upper: Endpoint{Version: i.Lower.Version, Inclusive: !i.Lower.Inclusive}}
+			if !gap.Empty() {
+				out = append(out, gap)
+			}
+		}
+		if i.Upper.Unbounded {
+			return out
+		}
+		lower = Endpoint{Version: i.Upper.Version, Inclusive: !i.Upper.Inclusive}
+	}
+	return append(out, Interval{Lower: lower, Upper: Endpoint{Unbounded: true}})
+}
+
+// Equal checks if s and o contain the same versions.
+func (s Set) Equal(o Set) bool {
+	a, b := Of(s...), Of(o...)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if CompareLower(a[i].Lower, b[i].Lower) != 0 || CompareUpper(a[i].Upper, b[i].Upper) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the intervals of s joined by ",", e.g.
+// "[1.0.0,2.0.0),[3.0.0,)". An empty set is "{}".
+func (s Set) String() string {
+	if len(s) == 0 {
+		return "{}"
+	}
+	parts := make([]string, len(s))
+	for i, interval := range s {
+		parts[i] = interval.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// FromConstraints returns the set of versions satisfying the comparators of
+// c. The prerelease rule of semver.RangeOptions.NPMCompat is not expressed
+// by intervals and ignored.
+func FromConstraints(c *semver.Constraints) Set {
+	var all Set
+	for _, comparators := range c.Sets() {
+		set := Set{Full}
+		for _, cmp := range comparators {
+			set = set.Intersect(comparator(cmp.Op, cmp.Version))
+		}
+		all = append(all, set...)
+	}
+	return Of(all...)
+}
+
+// comparator returns the set of versions satisfying the comparator op v.
+func comparator(op semver.Operator, v semver.Version) Set {
+	switch op {
+	case semver.OpNE:
+		return Set{Below(v), Above(v)}
+	case semver.OpGT:
+		return Set{Above(v)}
+	case semver.OpGTE:
+		return Set{AtLeast(v)}
+	case semver.OpLT:
+		return Set{Below(v)}
+	case semver.OpLTE:
+		return Set{AtMost(v)}
+	}
+	return Set{Point(v)}
+}
@@ -0,0 +1,168 @@
+package interval
+
+import (
+	"testing"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+func fromRange(s string) Set {
+	return FromConstraints(semver.MustParseConstraints(s))
+}
+
+func TestFromConstraints(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{"^1.2.3", "[1.2.3,2.0.0)"},
+		{"<=1.0.0 || >2.0.0", "(,1.0.0],(2.0.0,)"},
+		{"1.x || 2.x", "[1.0.0,3.0.0)"},
+		{"!=1.5.0", "(,1.5.0),(1.5.0,)"},
+		{"1.2.3", "[1.2.3,1.2.3]"},
+		{">2.0.0 <1.0.0", "{}"},
+		{"*", "[0.0.0,)"},
+	}
+	for _, tc := range tests {
+		if s := fromRange(tc.r).String(); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, s)
+		}
+	}
+}
+
+func TestFromConstraintsIntervals(t *testing.T) {
+	for _, r := range []string{"^1.2 || >=3 !=3.1.0", "~1.2.3-beta.2 || 2.x", ">=1.0.0 <2.0.0 || >=1.5.0 <3.0.0", "1.2.3 - 1.4", "<1.0.0 || >=1.0.0"} {
+		intervals, err := semver.MustParseRange(r).Intervals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s, want := fromRange(r).String(), Set(intervals).String(); s != want {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", r, want, s)
+		}
+	}
+}
+
+func TestOf(t *testing.T) {
+	v := semver.MustParse
+	tests := []struct {
+		in []Interval
+		s  string
+	}{
+		{nil, "{}"},
+		{[]Interval{Closed(v("2.0.0"), v("3.0.0")), Closed(v("1.0.0"), v("2.0.0"))}, "[1.0.0,3.0.0]"},
+		{[]Interval{HalfOpen(v("1.0.0"), v("2.0.0")), AtLeast(v("2.0.0"))}, "[1.0.0,)"},
+		{[]Interval{Below(v("2.0.0")), Above(v("2.0.0"))}, "(,2.0.0),(2.0.0,)"},
+		{[]Interval{Closed(v("2.0.0"), v("1.0.0")), Full}, "(,)"},
+		{[]Interval{Closed(v("2.0.0"), v("1.0.0"))}, "{}"},
+	}
+	for _, tc := range tests {
+		if s := Of(tc.in...).String(); s != tc.s {
+			t.Errorf("Invalid for case %v: Expected %q, got: %q", tc.in, tc.s, s)
+		}
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	tests := []struct {
+		a, b         string
+		union        string
+		intersection string
+	}{
+		{"<2.0.0", ">=2.0.0", "(,)", "{}"},
+		{"<=2.0.0", ">=2.0.0", "(,)", "[2.0.0,2.0.0]"},
+		{"<2.0.0", ">2.0.0", "(,2.0.0),(2.0.0,)", "{}"},
+		{"^1.0.0", "~1.5.0 || ^3.0.0", "[1.0.0,2.0.0),[3.0.0,4.0.0)", "[1.5.0,1.6.0)"},
+		{"<2.0.0", "<2.0.0-0", "(,2.0.0)", "(,2.0.0-0)"},
+	}
+	for _, tc := range tests {
+		a, b := fromRange(tc.a), fromRange(tc.b)
+		if s := a.Union(b).String(); s != tc.union {
+			t.Errorf("Invalid for case %q | %q: Expected %q, got: %q", tc.a, tc.b, tc.union, s)
+		}
+		if s := a.Intersect(b).String(); s != tc.intersection {
+			t.Errorf("Invalid for case %q & %q: Expected %q, got: %q", tc.a, tc.b, tc.intersection, s)
+		}
+	}
+}
+
+func TestComplement(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{"^1.2.3", "(,1.2.3),[2.0.0,)"},
+		{"<=1.0.0 || >2.0.0", "(1.0.0,2.0.0]"},
+		{"*", "(,0.0.0)"},
+		{">2.0.0 <1.0.0", "(,)"},
+		{"1.2.3", "(,1.2.3),(1.2.3,)"},
+	}
+	for _, tc := range tests {
+		set := fromRange(tc.r)
+		c := set.Complement()
+		if s := c.String(); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, s)
+		}
+		if !c.Complement().Equal(set) {
+			t.Errorf("Invalid for case %q: double complement differs: %q", tc.r, c.Complement())
+		}
+		for _, v := range []string{"0.0.0", "1.0.0", "1.2.3", "1.9.9", "2.0.0-rc.1", "2.0.0", "2.0.1"} {
+			version := semver.MustParse(v)
+			if set.Contains(version) == c.Contains(version) {
+				t.Errorf("Invalid for case %q: %q in both or neither of set and complement", tc.r, v)
+			}
+		}
+	}
+}
+
+func TestEndpointOrder(t *testing.T) {
+	v := semver.MustParse("1.0.0")
+	w := semver.MustParse("2.0.0")
+	lowers := []Endpoint{{Unbounded: true}, {Version: v, Inclusive: true}, {Version: v}, {Version: w, Inclusive: true}}
+	for i := 1; i < len(lowers); i++ {
+		if CompareLower(lowers[i-1], lowers[i]) >= 0 || CompareLower(lowers[i], lowers[i-1]) <= 0 {
+			t.Errorf("Lower endpoints %d and %d are not ordered", i-1, i)
+		}
+	}
+	uppers := []Endpoint{{Version: v}, {Version: v, Inclusive: true}, {Version: w}, {Unbounded: true}}
+	for i := 1; i < len(uppers); i++ {
+		if CompareUpper(uppers[i-1], uppers[i]) >= 0 || CompareUpper(uppers[i], uppers[i-1]) <= 0 {
+			t.Errorf("Upper endpoints %d and %d are not ordered", i-1, i)
+		}
+	}
+}
+
+func TestPrereleaseFloor(t *testing.T) {
+	v := semver.MustParse("2.0.0+build")
+	floor := PrereleaseFloor(v)
+	if floor.String() != "2.0.0-0" {
+		t.Errorf("Expected %q, got: %q", "2.0.0-0", floor)
+	}
+	rc := semver.MustParse("2.0.0-rc.1")
+	if !Below(v).Contains(rc) || Below(floor).Contains(rc) {
+		t.Errorf("Expected only Below(v) to contain %q", rc)
+	}
+	if !AtLeast(floor).Contains(rc) || AtLeast(v).Contains(rc) {
+		t.Errorf("Expected only AtLeast(floor) to contain %q", rc)
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	a, b := semver.MustParse("1.0.0"), semver.MustParse("2.0.0")
+	tests := []struct {
+		i Interval
+		s string
+	}{
+		{Closed(a, b), "[1.0.0,2.0.0]"},
+		{HalfOpen(a, b), "[1.0.0,2.0.0)"},
+		{Point(a), "[1.0.0,1.0.0]"},
+		{Above(a), "(1.0.0,)"},
+		{AtMost(a), "(,1.0.0]"},
+		{Full, "(,)"},
+		{HalfOpen(b, a), "{}"},
+	}
+	for _, tc := range tests {
+		if s := tc.i.String(); s != tc.s {
+			t.Errorf("Expected %q, got: %q", tc.s, s)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package semver
+
+import "testing"
+
+func formatIntervals(intervals []Interval) string {
+	s := ""
+	for i, iv := range intervals {
+		if i > 0 {
+			s += " "
+		}
+		if iv.Lower.Unbounded {
+			s += "(*"
+		} else if iv.Lower.Inclusive {
+			s += "[" + iv.Lower.Version.String()
+		} else {
+			s += "(" + iv.Lower.Version.String()
+		}
+		s += ","
+		if iv.Upper.Unbounded {
+			s += "*)"
+		} else if iv.Upper.Inclusive {
+			s += iv.Upper.Version.String() + "]"
+		} else {
+			s += iv.Upper.Version.String() + ")"
+		}
+	}
+	return s
+}
+
+func TestConstraintsIntervals(t *testing.T) {
+	tests := []struct {
+		r string
+		i string
+	}{
+		{">=1.0.0 <2.0.0", "[1.0.0,2.0.0)"},
+		{">1.0.0 <=2.0.0", "(1.0.0,2.0.0]"},
+		{"1.2.3", "[1.2.3,1.2.3]"},
+		{"!=1.2.3", "(*,1.2.3) (1.2.3,*)"},
+		{">=1.0.0 <3.0.0 !=2.0.0", "[1.0.0,2.0.0) (2.0.0,3.0.0)"},
+		{">=1.0.0 <2.0.0 || >=1.5.0 <3.0.0", "[1.0.0,3.0.0)"},
+		{"<1.0.0 || >=1.0.0", "(*,*)"},
+		{"<1.0.0 || >1.0.0", "(*,1.0.0) (1.0.0,*)"},
+		{">=2.0.0 || <1.0.0", "(*,1.0.0) [2.0.0,*)"},
+		{">2.0.0 <1.0.0", ""},
+		{">1.0.0 <1.0.0", ""},
+		{">=1.0.0 <=1.0.0", "[1.0.0,1.0.0]"},
+	}
+	for _, tc := range tests {
+		if i := formatIntervals(MustParseConstraints(tc.r).intervals()); i != tc.i {
+			t.Errorf("Invalid for case %q: Expected %q, got %q", tc.r, tc.i, i)
+		}
+	}
+}
+
+func TestIntervalContains(t *testing.T) {
+	c := MustParseConstraints(">=1.0.0 <3.0.0 !=2.0.0 || 5.0.0")
+	for _, s := range []string{"0.9.0", "1.0.0", "2.0.0", "2.5.0", "3.0.0", "5.0.0", "5.0.1"} {
+		v := MustParse(s)
+		in := false
+		for _, i := range c.intervals() {
+			in = in || i.Contains(v)
+		}
+		if in != c.Check(v) {
+			t.Errorf("Intervals disagree with Check for %q", s)
+		}
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	v := func(s string) Endpoint { return Endpoint{Version: MustParse(s), Inclusive: true} }
+	tests := []struct {
+		in  []Interval
+		out string
+	}{
+		{nil, ""},
+		{[]Interval{{Lower: v("2.0.0"), Upper: v("3.0.0")}, {Lower: v("1.0.0"), Upper: v("2.0.0")}}, "[1.0.0,3.0.0]"},
+		{[]Interval{{Lower: v("1.0.0"), Upper: Endpoint{Version: MustParse("2.0.0")}}, {Lower: Endpoint{Version: MustParse("2.0.0")}, Upper: v("3.0.0")}}, "[1.0.0,2.0.0) (2.0.0,3.0.0]"},
+		{[]Interval{{Lower: v("2.0.0"), Upper: v("1.0.0")}, fullInterval}, "(*,*)"},
+		{[]Interval{{Lower: v("2.0.0"), Upper: v("1.0.0")}}, ""},
+	}
+	for _, tc := range tests {
+		if s := formatIntervals(mergeIntervals(tc.in)); s != tc.out {
+			t.Errorf("Invalid for case %v: Expected %q, got: %q", tc.in, tc.out, s)
+		}
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	tests := []struct {
+		i Interval
+		s string
+	}{
+		{Interval{Lower: Endpoint{Version: MustParse("1.0.0"), Inclusive: true}, Upper: Endpoint{Version: MustParse("2.0.0")}}, "[1.0.0,2.0.0)"},
+		{Interval{Lower: Endpoint{Unbounded: true}, Upper: Endpoint{Version: MustParse("2.0.0"), Inclusive: true}}, "(,2.0.0]"},
+		{fullInterval, "(,)"},
+		{Interval{Lower: Endpoint{Version: MustParse("1.0.0")}, Upper: Endpoint{Version: MustParse("1.0.0"), Inclusive: true}}, "{}"},
+	}
+	for _, tc := range tests {
+		if s := tc.i.String(); s != tc.s {
+			t.Errorf("Invalid for case %q: got: %q", tc.s, s)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package semver
+
+import (
+	"errors"
+	"sync"
+)
+
+// A Range is an opaque func. To inspect a Range created by this package, it
+// is called with a probe: a Version whose only build identifier is
+// probeMarker. The Range recognizes the probe and reports the Constraints it
+// was compiled from instead of evaluating them. Parse never produces the
+// probe, as build identifiers can not contain a NUL byte.
+const probeMarker = "\x00probe"
+
+// ErrRangeNotInspectable is returned by functions which need the comparators
+// of a Range, if the Range was not created by this package, e.g. a plain func
+// literal.
+var ErrRangeNotInspectable = errors.New("range can not be inspected")
+
+// rangeProbe receives the Constraints reported by a probed Range.
+type rangeProbe struct {
+	c *Constraints
+}
+
+// probes maps the address of a probe's build identifier to its rangeProbe,
+// so that concurrent probes do not interfere.
+var probes sync.Map
+
+// constraintsOf returns the Constraints r was compiled from, ok is false if
+// r was not created by this package, e.g. by a plain func literal.
+func constraintsOf(r Range) (c *Constraints, ok bool) {
+	if r == nil {
+		return nil, false
+	}
+	build := []string{probeMarker}
+	p := &rangeProbe{}
+	probes.Store(&build[0], p)
+	defer probes.Delete(&build[0])
+	r(Version{Build: build})
+	return p.c, p.c != nil
+}
+
+// reportProbe reports c if v is a probe and returns whether it was.
+func reportProbe(v Version, c *Constraints) bool {
+	if len(v.Build) != 1 || v.Build[0] != probeMarker {
+		return false
+	}
+	if p, ok := probes.Load(&v.Build[0]); ok {
+		p.(*rangeProbe).c = c
+		return true
+	}
+	return false
+}
+
+// isProbe checks if v is a probe.
+func isProbe(v Version) bool {
+	if len(v.Build) != 1 || v.Build[0] != probeMarker {
+		return false
+	}
+	_, ok := probes.Load(&v.Build[0])
+	return ok
+}
+
+// combineOR and combineAND return the Constraints of ranges combined by
+// Range.OR and Range.AND. ok is false if a and b follow different prerelease
+// rules, which one Constraints can not express, or if the conjunction would
+// exceed maxGroupedSets like a range with groups. The combined Range can
+// not be inspected then.
+func combineOR(a, b *Constraints) (*Constraints, bool) {
+	if a.npm != b.npm {
+		return nil, false
+	}
+	return orConstraints(a, b), true
+}
+
+func combineAND(a, b *Constraints) (*Constraints, bool) {
+	if a.npm != b.npm || len(a.sets)*len(b.sets) > maxGroupedSets {
+		return nil, false
+	}
+	return andConstraints(a, b), true
+}
+
+// orConstraints returns the disjunction of a and b.
+func orConstraints(a, b *Constraints) *Constraints {
+	sets := make([][]versionRange, 0, len(a.sets)+len(b.sets))
+	sets = append(sets, a.sets...)
+	sets = append(sets, b.sets...)
+	return &Constraints{sets: sets, npm: a.npm}
+}
+
+// andConstraints returns the conjunction of a and b, distributing the sets
+// of b over the sets of a to keep the disjunctive form.
+func andConstraints(a, b *Constraints) *Constraints {
+	sets := make([][]versionRange, 0, len(a.sets)*len(b.sets))
+	for _, sa := range a.sets {
+		for _, sb := range b.sets {
+			set := make([]versionRange, 0, len(sa)+len(sb))
+			set = append(set, sa...)
+			set = append(set, sb...)
+			sets = append(sets, set)
+		}
+	}
+	return &Constraints{sets: sets, npm: a.npm}
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConstraintsOf(t *testing.T) {
+	tests := []struct {
+		r Range
+		s string
+	}{
+		{MustParseRange("^1.2.3"), ">=1.2.3 <2.0.0"},
+		{MustParseRange("<2.0.0").OR(MustParseRange(">=3.0.0")), "<2.0.0 || >=3.0.0"},
+		{MustParseRange("1.x || 3.x").AND(MustParseRange("!=1.5.0")), ">=1.0.0 <2.0.0 !=1.5.0 || >=3.0.0 <4.0.0 !=1.5.0"},
+	}
+	for _, tc := range tests {
+		c, ok := constraintsOf(tc.r)
+		if !ok {
+			t.Errorf("Invalid for case %q: range can not be inspected", tc.s)
+		} else if c.String() != tc.s {
+			t.Errorf("Invalid for case %q: got: %q", tc.s, c)
+		}
+	}
+
+	plain := Range(func(Version) bool { return true })
+	for _, r := range []Range{nil, plain, plain.AND(MustParseRange("1.0.0"))} {
+		if _, ok := constraintsOf(r); ok {
+			t.Errorf("Range must not be inspectable")
+		}
+	}
+}
+
+func TestConstraintsOfConcurrent(t *testing.T) {
+	ranges := []Range{MustParseRange("1.0.0"), MustParseRange("2.0.0")}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(r Range, s string) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if c, ok := constraintsOf(r); !ok || c.String() != s {
+					t.Errorf("Expected %q, got: %v", s, c)
+					return
+				}
+			}
+		}(ranges[i%2], []string{"1.0.0", "2.0.0"}[i%2])
+	}
+	wg.Wait()
+}
+
+func TestProbeNotMatched(t *testing.T) {
+	build := []string{probeMarker}
+	if MustParseRange(">=0.0.0")(Version{Build: build}) != true {
+		t.Errorf("An unregistered probe marker must be evaluated as version")
+	}
+}
+
+func TestConstraintsOfCombined(t *testing.T) {
+	npm, err := ParseRangeWithOptions(">=1.0.0", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := MustParseRange("<2.0.0")
+	for _, r := range []Range{npm.OR(plain), plain.OR(npm), npm.AND(plain), plain.AND(npm)} {
+		if _, ok := constraintsOf(r); ok {
+			t.Errorf("Range of different prerelease rules must not be inspectable")
+		}
+	}
+	if c, ok := constraintsOf(npm.AND(npm)); !ok || !c.npm {
+		t.Errorf("Range of the same prerelease rule must be inspectable, got: %v", c)
+	}
+
+	// 33 * 33 sets exceed maxGroupedSets
+	var wide []string
+	for i := 0; i < 33; i++ {
+		wide = append(wide, fmt.Sprintf("%d.x", i))
+	}
+	r := MustParseRange(strings.Join(wide, " || "))
+	huge := r.AND(r)
+	if _, ok := constraintsOf(huge); ok {
+		t.Errorf("Range with more than %d sets must not be inspectable", maxGroupedSets)
+	}
+	if !huge(MustParse("32.1.0")) || huge(MustParse("33.0.0")) {
+		t.Errorf("Range with more than %d sets must still be evaluated", maxGroupedSets)
+	}
+}
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"bytes"
 	"encoding/json"
 )
 
@@ -21,3 +22,85 @@ func (v *Version) UnmarshalJSON(data []byte) (err error) {
 
 	return
 }
+
+// constraintsJSON is the JSON form of constraints with the prerelease policy
+// of RangeOptions.NPMCompat, which the range string can not express.
+type constraintsJSON struct {
+	Range     string `json:"range"`
+	NPMCompat bool   `json:"npmCompat"`
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface. Constraints
+// are written as their expanded normal form, e.g. "^1.2" as
+// ">=1.2.0 <2.0.0". Constraints parsed with RangeOptions.NPMCompat, but
+// without IncludePrerelease, are written as an object
+// {"range": ">=1.2.0 <2.0.0", "npmCompat": true} to keep their prerelease
+// policy.
+func (c *Constraints) MarshalJSON() ([]byte, error) {
+	if c.npm {
+		return marshalUnescaped(constraintsJSON{Range: c.String(), NPMCompat: true})
+	}
+	return marshalUnescaped(c.String())
+}
+
+// marshalUnescaped is like json.Marshal but does not escape the comparison
+// operators as HTML, so that an Encoder with SetEscapeHTML(false) writes
+// readable ranges.
+func marshalUnescaped(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface. It
+// accepts a range string, see ParseRange, or an object written by
+// MarshalJSON.
+func (c *Constraints) UnmarshalJSON(data []byte) error {
+	var s constraintsJSON
+	if len(data) > 0 && data[0] == '{' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &s.Range); err != nil {
+		return err
+	}
+	parsed, err := ParseConstraintsWithOptions(s.Range, RangeOptions{NPMCompat: s.NPMCompat})
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface like
+// Constraints.MarshalJSON, e.g. to embed ranges in policy configs. A nil
+// Range is written as null. It is an error if the Range can not be
+// inspected.
+func (rf Range) MarshalJSON() ([]byte, error) {
+	if rf == nil {
+		return []byte("null"), nil
+	}
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return nil, ErrRangeNotInspectable
+	}
+	return c.MarshalJSON()
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface like
+// Constraints.UnmarshalJSON. A null leaves the Range unchanged.
+func (rf *Range) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var c Constraints
+	if err := c.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*rf = c.Range()
+	return nil
+}
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"bytes"
 	"encoding/json"
 	"strconv"
 	"testing"
@@ -47,3 +48,66 @@ func TestJSONUnmarshal(t *testing.T) {
 		t.Fatal("expected JSON unmarshal error, got nil")
 	}
 }
+
+func TestRangeJSON(t *testing.T) {
+	type policy struct {
+		Allowed Range `json:"allowed"`
+	}
+	tests := []struct {
+		r    string
+		opts RangeOptions
+		json string
+	}{
+		{"^1.2", RangeOptions{}, `{"allowed":">=1.2.0 <2.0.0"}`},
+		{"1.x || >=3.0.0-beta !3.1.0", RangeOptions{}, `{"allowed":">=1.0.0 <2.0.0 || >=3.0.0-beta !=3.1.0"}`},
+		{"~1.2.3", RangeOptions{NPMCompat: true}, `{"allowed":{"range":">=1.2.3 <1.3.0-0","npmCompat":true}}`},
+		{"~1.2.3", RangeOptions{NPMCompat: true, IncludePrerelease: true}, `{"allowed":">=1.2.3 <1.3.0-0"}`},
+	}
+	for _, tc := range tests {
+		r, err := ParseRangeWithOptions(tc.r, tc.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(policy{Allowed: r}); err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+			continue
+		}
+		data := bytes.TrimSpace(buf.Bytes())
+		if string(data) != tc.json {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.r, tc.json, data)
+		}
+		var p policy
+		if err := json.Unmarshal(data, &p); err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+			continue
+		}
+		for _, s := range []string{"1.2.0", "1.2.5-rc.1", "1.9.9", "2.0.0", "3.0.0-beta", "3.1.0", "3.2.0"} {
+			v := MustParse(s)
+			if p.Allowed(v) != r(v) {
+				t.Errorf("Invalid for case %q: Round trip disagrees on %q", tc.r, s)
+			}
+		}
+	}
+}
+
+func TestRangeJSONInvalid(t *testing.T) {
+	if _, err := json.Marshal(Range(func(Version) bool { return true })); err == nil {
+		t.Errorf("Expected an error for a func literal")
+	}
+	if data, err := json.Marshal(Range(nil)); err != nil || string(data) != "null" {
+		t.Errorf("Expected null, got: %s (%v)", data, err)
+	}
+	for _, data := range []string{`""`, `">=a"`, `5`, `{"range": 5}`} {
+		var r Range
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error", data)
+		}
+	}
+	var r Range
+	if err := json.Unmarshal([]byte("null"), &r); err != nil || r != nil {
+		t.Errorf("Expected null to leave the Range unchanged, got: %v", err)
+	}
+}
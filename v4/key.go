@@ -0,0 +1,45 @@
+package semver
+
+import "strings"
+
+// VersionKey identifies a version by precedence. Unlike Version, which holds
+// slices, it is comparable, so it can be used as a map key: the keys of two
+// versions are equal exactly if the versions compare equal, build meta data
+// is not part of the key.
+type VersionKey struct {
+	Major uint64
+	Minor uint64
+	Patch uint64
+	Pre   string // dot separated prerelease identifiers, "" for releases
+}
+
+// Key returns the comparable key of v, e.g. to count versions in a map
+// where 1.2.3+build.1 and 1.2.3+build.2 are the same version.
+func (v Version) Key() VersionKey {
+	k := VersionKey{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if len(v.Pre) > 0 {
+		pre := make([]string, len(v.Pre))
+		for i, pr := range v.Pre {
+			pre[i] = pr.String()
+		}
+		k.Pre = strings.Join(pre, ".")
+	}
+	return k
+}
+
+// Version returns the version identified by k, without build meta data.
+func (k VersionKey) Version() Version {
+	v := Version{Major: k.Major, Minor: k.Minor, Patch: k.Patch}
+	if k.Pre != "" {
+		for _, s := range strings.Split(k.Pre, ".") {
+			// The identifiers of a key created by Key are valid
+			pr, _ := NewPRVersion(s)
+			v.Pre = append(v.Pre, pr)
+		}
+	}
+	return v
+}
+
+func (k VersionKey) String() string {
+	return k.Version().String()
+}
@@ -0,0 +1,51 @@
+package semver
+
+import "testing"
+
+func TestVersionKey(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3+build.1", "1.2.3+build.2", true},
+		{"1.2.3-rc.1", "1.2.3-rc.1+x", true},
+		{"1.2.3", "1.2.4", false},
+		{"1.2.3-rc.1", "1.2.3", false},
+		{"1.2.3-rc.1", "1.2.3-rc.1.0", false},
+		{"1.2.3-1", "1.2.3-a", false},
+		{"1.2.3-a.b", "1.2.3-a-b", false},
+	}
+	for _, tc := range tests {
+		a, b := MustParse(tc.a), MustParse(tc.b)
+		if equal := a.Key() == b.Key(); equal != tc.equal {
+			t.Errorf("Invalid for case %q, %q: Expected %t, got: %t", tc.a, tc.b, tc.equal, equal)
+		}
+		if equal := a.Compare(b) == 0; equal != tc.equal {
+			t.Errorf("Invalid for case %q, %q: Key disagrees with Compare", tc.a, tc.b)
+		}
+	}
+
+	counts := map[VersionKey]int{}
+	for _, s := range []string{"1.0.0+a", "1.0.0+b", "2.0.0-rc.1"} {
+		counts[MustParse(s).Key()]++
+	}
+	if counts[MustParse("1.0.0").Key()] != 2 || len(counts) != 2 {
+		t.Errorf("Unexpected counts: %v", counts)
+	}
+}
+
+func TestVersionKeyVersion(t *testing.T) {
+	for _, s := range []string{"0.0.0", "1.2.3", "1.2.3-rc.1.x-y.0"} {
+		v := MustParse(s)
+		if back := v.Key().Version(); !back.Equals(v) || back.String() != s {
+			t.Errorf("Invalid for case %q: Got %q", s, back)
+		}
+		if k := v.Key().String(); k != s {
+			t.Errorf("Invalid for case %q: Got %q", s, k)
+		}
+	}
+	if s := MustParse("1.2.3-rc+build").Key().String(); s != "1.2.3-rc" {
+		t.Errorf("Expected %q, got: %q", "1.2.3-rc", s)
+	}
+}
@@ -0,0 +1,59 @@
+package semver
+
+import (
+	"strconv"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Limits bounds the size of version strings accepted by Parse, protecting
+// services which parse untrusted input. A zero field disables the limit.
+type Limits struct {
+	// MaxLength is the maximum length of a version string in bytes.
+	MaxLength int
+	// MaxIdentifierLength is the maximum length of a single prerelease or
+	// build meta data identifier in bytes.
+	MaxIdentifierLength int
+}
+
+// DefaultLimits are in effect until SetLimits is called, they impose no
+// limits. Services parsing untrusted input can opt in, e.g. with a MaxLength
+// of 256 like npm.
+var DefaultLimits = Limits{}
+
+var currentLimits atomic.Value
+
+// SetLimits sets the package-level limits used by Parse and all functions
+// parsing versions.
+func SetLimits(l Limits) {
+	currentLimits.Store(l)
+}
+
+func loadLimits() Limits {
+	if l, ok := currentLimits.Load().(Limits); ok {
+		return l
+	}
+	return DefaultLimits
+}
+
+// maxErrorInputLength is the maximum length of input quoted in errors.
+const maxErrorInputLength = 64
+
+// quote quotes s for an error message. Long inputs are truncated with an
+// ellipsis at a rune boundary, so that adversarial strings can not flood
+// logs.
+func quote(s string) string {
+	return strconv.Quote(truncate(s, maxErrorInputLength))
+}
+
+// truncate shortens s to at most n bytes plus an ellipsis, without splitting
+// a multi-byte rune.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,79 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLimits(t *testing.T) {
+	defer SetLimits(DefaultLimits)
+
+	long := "1.0.0-" + strings.Repeat("a", 300)
+	if _, err := Parse(long); err != nil {
+		t.Errorf("Unexpected error without limits by default: %s", err)
+	}
+
+	SetLimits(Limits{MaxLength: 256})
+	if _, err := Parse(long); err == nil {
+		t.Errorf("Expected error for version longer than MaxLength")
+	} else if len(err.Error()) > 2*maxErrorInputLength {
+		t.Errorf("Error message not truncated: %s", err)
+	}
+
+	SetLimits(Limits{MaxIdentifierLength: 8})
+	if _, err := Parse(long); err == nil {
+		t.Errorf("Expected error for too long prerelease identifier")
+	}
+	tests := []struct {
+		v  string
+		ok bool
+	}{
+		{"1.0.0-abcdefgh", true},
+		{"1.0.0-abcdefghi", false},
+		{"1.0.0+abcdefgh.1", true},
+		{"1.0.0+1.abcdefghi", false},
+	}
+	for _, tc := range tests {
+		if _, err := Parse(tc.v); (err == nil) != tc.ok {
+			t.Errorf("Invalid for case %q: Expected ok %t, got: %v", tc.v, tc.ok, err)
+		}
+	}
+
+	SetLimits(Limits{})
+	if _, err := Parse(long); err != nil {
+		t.Errorf("Unexpected error without limits: %s", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s string
+		n int
+		o string
+	}{
+		{"1.2.3", 5, "1.2.3"},
+		{"1.2.3-beta", 5, "1.2.3..."},
+		{"1.0.0-äöü", 7, "1.0.0-..."},
+		{"1.0.0-äöü", 8, "1.0.0-ä..."},
+	}
+	for _, tc := range tests {
+		o := truncate(tc.s, tc.n)
+		if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.o, o)
+		}
+		if !utf8.ValidString(o) {
+			t.Errorf("Invalid for case %q: truncated to invalid UTF-8 %q", tc.s, o)
+		}
+	}
+}
+
+func TestRangeErrorTruncated(t *testing.T) {
+	_, err := ParseRange(">=1.0.0 " + strings.Repeat("<", 1000))
+	if err == nil {
+		t.Fatalf("Expected error")
+	}
+	if len(err.Error()) > 2*maxErrorInputLength {
+		t.Errorf("Error message not truncated: %s", err)
+	}
+}
@@ -0,0 +1,170 @@
+package semver
+
+import "strings"
+
+// looseOperators are the operators of the comparators of node-semver's loose
+// mode, longer ones first.
+var looseOperators = []string{"<=", ">=", "~>", "<", ">", "=", "~", "^"}
+
+// looseRange rewrites the range s of node-semver's loose mode to the default
+// dialect, see RangeOptions.Loose: every comparator is normalized, and
+// comparators which are invalid even in loose mode are dropped.
+func looseRange(s string) (string, error) {
+	var sets []string
+	for _, part := range strings.Split(s, "||") {
+		fields := looseFields(part)
+		if len(fields) == 0 {
+			// An empty set matches every version like in npm
+			sets = append(sets, "*")
+			continue
+		}
+		if len(fields) == 3 && fields[1] == "-" {
+			from, fromOK := looseVersion(fields[0])
+			to, toOK := looseVersion(fields[2])
+			if fromOK && toOK {
+				sets = append(sets, from+" - "+to)
+				continue
+			}
+		}
+		var comparators []string
+		for _, f := range fields {
+			if c, ok := looseComparator(f); ok {
+				comparators = append(comparators, c)
+			}
+		}
+		if len(comparators) > 0 {
+			sets = append(sets, strings.Join(comparators, " "))
+		}
+	}
+	if len(sets) == 0 {
+		sc := &rangeScanner{s: s}
+		return "", sc.errorf(ErrEmptyRange, 0, "", "no valid comparator in loose mode")
+	}
+	return strings.Join(sets, " || "), nil
+}
+
+// looseFields splits the set s into its comparators. Operators and "v" or
+// "=" prefixes separated from their version by white space are joined with
+// it, ">= v1.2.3" is one comparator.
+func looseFields(s string) []string {
+	var fields []string
+	prefix := ""
+	for _, f := range strings.Fields(s) {
+		if containsOnly(f, "<>=~^v") {
+			prefix += f
+			continue
+		}
+		fields = append(fields, prefix+f)
+		prefix = ""
+	}
+	if prefix != "" {
+		fields = append(fields, prefix)
+	}
+	return fields
+}
+
+// looseComparator normalizes the loose comparator s, ok is false if it is
+// invalid even in loose mode.
+func looseComparator(s string) (c string, ok bool) {
+	op := ""
+	for _, o := range looseOperators {
+		if strings.HasPrefix(s, o) {
+			op, s = o, s[len(o):]
+			break
+		}
+	}
+	if op == "~>" {
+		op = "~"
+	}
+	v, ok := looseVersion(s)
+	if !ok {
+		return "", false
+	}
+	return op + v, true
+}
+
+// looseVersion normalizes the loose version s, which may be partial or a
+// wildcard: it removes "v" and "=" prefixes and leading zeroes, and adds the
+// "-" of a prerelease written without it, "v1.02.3beta" becomes
+// "1.2.3-beta". ok is false if s is invalid even in loose mode.
+func looseVersion(s string) (v string, ok bool) {
+	s = strings.TrimLeft(s, "v=")
+	var b strings.Builder
+	for parts := 1; ; parts++ {
+		n := 0
+		if s != "" && strings.ContainsRune("xX*", rune(s[0])) {
+			n = 1
+			b.WriteByte(s[0])
+		} else {
+			for n < len(s) && isDigit(s[n]) {
+				n++
+			}
+			if n == 0 {
+				return "", false
+			}
+			b.WriteString(trimLeadingZeroes(s[:n]))
+		}
+		s = s[n:]
+		if parts == 3 || !strings.HasPrefix(s, ".") {
+			if s != "" && parts < 3 {
+				return "", false
+			}
+			break
+		}
+		b.WriteByte('.')
+		s = s[1:]
+	}
+	if s == "" {
+		return b.String(), true
+	}
+
+	pre, build := s, ""
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		pre, build = s[:i], s[i+1:]
+		if !looseIdentifiers(build) {
+			return "", false
+		}
+	}
+	if pre != "" {
+		pre = strings.TrimPrefix(pre, "-")
+		if !looseIdentifiers(pre) {
+			return "", false
+		}
+		b.WriteByte('-')
+		for i, id := range strings.Split(pre, ".") {
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			if containsOnly(id, numbers) {
+				id = trimLeadingZeroes(id)
+			}
+			b.WriteString(id)
+		}
+	}
+	if build != "" {
+		b.WriteByte('+')
+		b.WriteString(build)
+	}
+	return b.String(), true
+}
+
+// looseIdentifiers checks if s consists of dot separated identifiers of
+// alphanumerics and hyphens.
+func looseIdentifiers(s string) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" || !containsOnly(id, alphanum) {
+			return false
+		}
+	}
+	return true
+}
+
+// trimLeadingZeroes removes the leading zeroes of the number s, keeping a
+// single zero.
+func trimLeadingZeroes(s string) string {
+	t := strings.TrimLeft(s, "0")
+	if t == "" && s != "" {
+		return "0"
+	}
+	return t
+}
@@ -0,0 +1,60 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLooseRange(t *testing.T) {
+	tests := []struct {
+		r        string
+		expanded string
+	}{
+		{">= 1.0.0 <2", ">=1.0.0 <2.0.0"},
+		{"1.2.3 - v2.0.0", ">=1.2.3 <=2.0.0"},
+		{"1.2.3 - 2", ">=1.2.3 <3.0.0"},
+		{" ~1.x ", ">=1.0.0 <2.0.0"},
+		{"=v1.2.3", "1.2.3"},
+		{"= v1.2.3", "1.2.3"},
+		{"> = 1.2.3", ">=1.2.3"},
+		{"1.2.3beta", "1.2.3-beta"},
+		{">=1.2.3-beta.01", ">=1.2.3-beta.1"},
+		{"01.02.03", "1.2.3"},
+		{"~> 1.2", ">=1.2.0 <1.3.0"},
+		{"^ 1.2.3", ">=1.2.3 <2.0.0"},
+		{"1.2.3 foo", "1.2.3"},
+		{">=1.2.3 <2.0.0 !=1.5.0", ">=1.2.3 <2.0.0"},
+		{"1.2.3 ||", "1.2.3 || >=0.0.0"},
+		{"", ">=0.0.0"},
+		{"foo || ^2", ">=2.0.0 <3.0.0"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.r, RangeOptions{Loose: true})
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+			continue
+		}
+		if s := c.String(); s != tc.expanded {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.expanded, s)
+		}
+	}
+}
+
+func TestLooseRangeInvalid(t *testing.T) {
+	for _, r := range []string{"foo", "1.2.3.4.5", "!=1.2.3", "1.2-beta"} {
+		_, err := ParseRangeWithOptions(r, RangeOptions{Loose: true})
+		var se *RangeSyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Invalid for case %q: Expected a RangeSyntaxError, got: %v", r, err)
+		}
+	}
+}
+
+func TestLooseRangeStrict(t *testing.T) {
+	// The loose ranges are errors in the default strict mode
+	for _, r := range []string{"1.2.3beta", "1.2.3 foo", "=v1.2.3 ||"} {
+		if _, err := ParseRange(r); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error in strict mode", r)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package semver
+
+// MatrixStrategy selects the versions of a CI compatibility test matrix, see
+// ExpandMatrix.
+type MatrixStrategy int
+
+const (
+	// MatrixAll selects every matching version.
+	MatrixAll MatrixStrategy = iota
+	// MatrixLatestPerMinor selects the latest matching version of each
+	// major.minor.
+	MatrixLatestPerMinor
+	// MatrixLatestPerMajor selects the latest matching version of each major.
+	MatrixLatestPerMajor
+	// MatrixOldestAndNewest selects the oldest and the newest matching
+	// version.
+	MatrixOldestAndNewest
+)
+
+// ExpandMatrix selects the versions of available satisfying r according to
+// strategy, e.g. to drive a CI compatibility test matrix from a support
+// range. The result is sorted ascending and free of duplicates.
+func ExpandMatrix(r Range, available []Version, strategy MatrixStrategy) []Version {
+	var matching []Version
+	for _, v := range available {
+		if r(v) {
+			matching = append(matching, v)
+		}
+	}
+	Sort(matching)
+	unique := matching[:0]
+	for i, v := range matching {
+		if i == 0 || !v.Equals(matching[i-1]) {
+			unique = append(unique, v)
+		}
+	}
+	matching = unique
+
+	var matrix []Version
+	for i, v := range matching {
+		last := i == len(matching)-1
+		var next Version
+		if !last {
+			next = matching[i+1]
+		}
+		switch strategy {
+		case MatrixLatestPerMinor:
+			if last || next.Major != v.Major || next.Minor != v.Minor {
+				matrix = append(matrix, v)
+			}
+		case MatrixLatestPerMajor:
+			if last || next.Major != v.Major {
+				matrix = append(matrix, v)
+			}
+		case MatrixOldestAndNewest:
+			if len(matrix) == 0 || last {
+				matrix = append(matrix, v)
+			}
+		default:
+			matrix = append(matrix, v)
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,32 @@
+package semver
+
+import "testing"
+
+func TestExpandMatrix(t *testing.T) {
+	available := []Version{}
+	for _, s := range []string{"2.1.0", "1.0.0", "1.0.1", "1.1.0", "1.1.3", "2.0.0", "2.1.0", "2.2.0-beta.1", "3.0.0", "0.9.0"} {
+		available = append(available, MustParse(s))
+	}
+	r := MustParseRange(">=1.0.0 <3.0.0")
+	tests := []struct {
+		strategy MatrixStrategy
+		matrix   string
+	}{
+		{MatrixAll, "[1.0.0 1.0.1 1.1.0 1.1.3 2.0.0 2.1.0 2.2.0-beta.1]"},
+		{MatrixLatestPerMinor, "[1.0.1 1.1.3 2.0.0 2.1.0 2.2.0-beta.1]"},
+		{MatrixLatestPerMajor, "[1.1.3 2.2.0-beta.1]"},
+		{MatrixOldestAndNewest, "[1.0.0 2.2.0-beta.1]"},
+	}
+	for _, tc := range tests {
+		if matrix := fmtVersions(ExpandMatrix(r, available, tc.strategy)); matrix != tc.matrix {
+			t.Errorf("Invalid for strategy %d: Expected %s, got: %s", tc.strategy, tc.matrix, matrix)
+		}
+	}
+
+	if matrix := ExpandMatrix(MustParseRange("1.0.0"), available, MatrixOldestAndNewest); fmtVersions(matrix) != "[1.0.0]" {
+		t.Errorf("Expected a single version, got: %s", fmtVersions(matrix))
+	}
+	if matrix := ExpandMatrix(MustParseRange(">5.0.0"), available, MatrixAll); matrix != nil {
+		t.Errorf("Expected empty matrix, got: %s", fmtVersions(matrix))
+	}
+}
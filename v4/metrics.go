@@ -0,0 +1,36 @@
+package semver
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// MetricsSink receives measurements from the package, e.g. to monitor parse
+// failure rates and range evaluation latency of a service. Implementations
+// must be safe for concurrent use.
+type MetricsSink interface {
+	// ObserveParse is called after every Parse.
+	ObserveParse(d time.Duration, ok bool)
+	// ObserveRangeParse is called after every ParseRange and ParseConstraints.
+	ObserveRangeParse(d time.Duration, ok bool)
+	// ObserveRangeEval is called after every evaluation of a parsed Range.
+	ObserveRangeEval(d time.Duration)
+}
+
+// metricsHolder wraps the sink, atomic.Value requires a consistent type.
+type metricsHolder struct {
+	sink MetricsSink
+}
+
+var metrics atomic.Value
+
+// SetMetricsSink installs s as the package-level metrics sink, nil removes
+// the current sink. Without a sink no measurements are taken.
+func SetMetricsSink(s MetricsSink) {
+	metrics.Store(metricsHolder{sink: s})
+}
+
+func loadMetricsSink() MetricsSink {
+	h, _ := metrics.Load().(metricsHolder)
+	return h.sink
+}
@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingSink struct {
+	mu                   sync.Mutex
+	parseOK, parseFailed int
+	rangeOK, rangeFailed int
+	evals                int
+}
+
+func (s *countingSink) ObserveParse(d time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ok {
+		s.parseOK++
+	} else {
+		s.parseFailed++
+	}
+}
+
+func (s *countingSink) ObserveRangeParse(d time.Duration, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ok {
+		s.rangeOK++
+	} else {
+		s.rangeFailed++
+	}
+}
+
+func (s *countingSink) ObserveRangeEval(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evals++
+}
+
+func TestMetricsSink(t *testing.T) {
+	sink := &countingSink{}
+	SetMetricsSink(sink)
+	defer SetMetricsSink(nil)
+
+	v, _ := Parse("1.2.3")
+	_, _ = Parse("invalid")
+	r, _ := ParseRange(">=1.0.0")
+	_, _ = ParseRange(">>1.0.0")
+	r(v)
+	r(v)
+
+	if sink.parseOK != 1 || sink.parseFailed != 1 {
+		t.Errorf("Expected 1 successful and 1 failed parse, got %d and %d", sink.parseOK, sink.parseFailed)
+	}
+	if sink.rangeOK != 1 || sink.rangeFailed != 1 {
+		t.Errorf("Expected 1 successful and 1 failed range parse, got %d and %d", sink.rangeOK, sink.rangeFailed)
+	}
+	if sink.evals != 2 {
+		t.Errorf("Expected 2 range evaluations, got %d", sink.evals)
+	}
+
+	SetMetricsSink(nil)
+	_, _ = Parse("1.2.3")
+	r(v)
+	if sink.parseOK != 1 || sink.evals != 2 {
+		t.Error("Removed sink must not receive measurements")
+	}
+}
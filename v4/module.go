@@ -0,0 +1,66 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckModuleMajor checks Go's rule for the major versions of modules, e.g.
+// in module proxies or vanity import servers: a module path ending in "/vN"
+// only has versions of major version N, and a module path without such a
+// suffix only versions of major version 0 or 1, or versions marked
+// "+incompatible" of modules which predate modules. gopkg.in paths carry the
+// major version as ".vN" suffix, e.g. "gopkg.in/yaml.v2". It is an error if
+// the suffix itself is invalid, like "/v1" or "/v02".
+func CheckModuleMajor(modulePath string, v Version) error {
+	pathMajor, ok := splitPathMajor(modulePath)
+	if !ok {
+		return fmt.Errorf("module path %s has an invalid major version suffix", quote(modulePath))
+	}
+	incompatible := len(v.Build) == 1 && v.Build[0] == "incompatible"
+	switch {
+	case pathMajor == "" && incompatible:
+		if v.Major < 2 {
+			return fmt.Errorf("version %s of module %s must not be +incompatible below major version 2", v, quote(modulePath))
+		}
+	case pathMajor == "":
+		if v.Major > 1 {
+			return fmt.Errorf("version %s of module %s requires the module path suffix \"/v%d\"", v, quote(modulePath), v.Major)
+		}
+	case incompatible:
+		return fmt.Errorf("version %s of module %s must not be +incompatible with a major version suffix", v, quote(modulePath))
+	case pathMajor == ".v1" && v.Major == 0:
+		// gopkg.in modules once got v0.0.0 pseudo-versions, Go still
+		// accepts them
+		if _, pseudo := ParsePseudoVersion(v); !pseudo || v.Minor != 0 || v.Patch != 0 {
+			return fmt.Errorf("version %s does not match the major version of module %s", v, quote(modulePath))
+		}
+	case pathMajor[2:] != fmt.Sprint(v.Major):
+		return fmt.Errorf("version %s does not match the major version of module %s", v, quote(modulePath))
+	}
+	return nil
+}
+
+// splitPathMajor returns the major version suffix of the module path, "/vN"
+// or ".vN" for gopkg.in paths, or "" if it has none. ok is false if the
+// suffix is invalid.
+func splitPathMajor(path string) (pathMajor string, ok bool) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		// The suffix is required, "-unstable" marks unstable versions
+		path = strings.TrimSuffix(path, "-unstable")
+		i := strings.LastIndex(path, ".v")
+		if i < 0 || i+2 == len(path) || !containsOnly(path[i+2:], numbers) || hasLeadingZeroes(path[i+2:]) {
+			return "", false
+		}
+		return path[i:], true
+	}
+	i := strings.LastIndexByte(path, '/')
+	last := path[i+1:]
+	if i < 0 || len(last) < 2 || last[0] != 'v' || !containsOnly(last[1:], numbers+".") {
+		return "", true
+	}
+	if strings.Contains(last, ".") || last[1] == '0' || last == "v1" {
+		return "", false
+	}
+	return "/" + last, true
+}
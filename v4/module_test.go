@@ -0,0 +1,42 @@
+package semver
+
+import "testing"
+
+func TestCheckModuleMajor(t *testing.T) {
+	tests := []struct {
+		path string
+		v    string
+		ok   bool
+	}{
+		{"example.com/mod", "1.2.3", true},
+		{"example.com/mod", "0.1.0", true},
+		{"example.com/mod", "2.0.0", false},
+		{"example.com/mod", "2.0.0+incompatible", true},
+		{"example.com/mod", "1.0.0+incompatible", false},
+		{"example.com/mod/v2", "2.1.0", true},
+		{"example.com/mod/v2", "2.1.0-rc.1", true},
+		{"example.com/mod/v2", "3.0.0", false},
+		{"example.com/mod/v2", "1.0.0", false},
+		{"example.com/mod/v2", "2.0.0+incompatible", false},
+		{"example.com/mod/v10", "10.0.0", true},
+		{"example.com/mod/v1", "1.0.0", false},
+		{"example.com/mod/v0", "0.1.0", false},
+		{"example.com/mod/v02", "2.0.0", false},
+		{"example.com/mod/v2.1", "2.1.0", false},
+		{"example.com/v2mod", "1.0.0", true},
+		{"example.com/mod/vendor", "1.0.0", true},
+		{"gopkg.in/yaml.v2", "2.4.0", true},
+		{"gopkg.in/yaml.v2", "3.0.0", false},
+		{"gopkg.in/check.v1", "1.0.0-20201130134442-10cb98267c6c", true},
+		{"gopkg.in/check.v1", "0.0.0-20161208181325-20d25e280405", true},
+		{"gopkg.in/check.v1", "0.1.0", false},
+		{"gopkg.in/src-d/go-git.v4-unstable", "4.0.0-rc.1", true},
+		{"gopkg.in/yaml", "1.0.0", false},
+	}
+	for _, tc := range tests {
+		err := CheckModuleMajor(tc.path, MustParse(tc.v))
+		if (err == nil) != tc.ok {
+			t.Errorf("Invalid for case %q %q: Expected ok %t, got: %v", tc.path, tc.v, tc.ok, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package semver
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReleaseType is the grain of a version change.
+type ReleaseType int
+
+const (
+	// ReleaseMajor steps between X.0.0 versions.
+	ReleaseMajor ReleaseType = iota
+	// ReleaseMinor steps between X.Y.0 versions.
+	ReleaseMinor
+	// ReleasePatch steps between X.Y.Z release versions.
+	ReleasePatch
+)
+
+func (t ReleaseType) String() string {
+	switch t {
+	case ReleaseMajor:
+		return "major"
+	case ReleaseMinor:
+		return "minor"
+	case ReleasePatch:
+		return "patch"
+	}
+	return fmt.Sprintf("ReleaseType(%d)", int(t))
+}
+
+// NextVersion returns the least version of the grain greater than v: the
+// least X.0.0 for ReleaseMajor, X.Y.0 for ReleaseMinor and release X.Y.Z for
+// ReleasePatch. For releases this increments the component of the grain
+// and resets the lower ones, 1.2.3 becomes 2.0.0, 1.3.0 and 1.2.4. A
+// prerelease precedes its release, so the next patch of 1.2.3-beta is 1.2.3
+// and the next minor of 1.3.0-beta is 1.3.0. A component at its greatest
+// number carries into the next higher one. Build meta data is dropped.
+// If there is no greater version of the grain, because the major version
+// is math.MaxUint64, an *OverflowError is returned.
+func NextVersion(v Version, grain ReleaseType) (Version, error) {
+	next, ok := nextVersion(v, grain)
+	if !ok {
+		return Version{}, &OverflowError{Component: "major"}
+	}
+	return next, nil
+}
+
+func nextVersion(v Version, grain ReleaseType) (Version, bool) {
+	next := truncateVersion(v, grain)
+	if next.GT(v) {
+		return next, true
+	}
+	switch {
+	case grain >= ReleasePatch && next.Patch < math.MaxUint64:
+		next.Patch++
+	case grain >= ReleaseMinor && next.Minor < math.MaxUint64:
+		next.Minor, next.Patch = next.Minor+1, 0
+	case next.Major < math.MaxUint64:
+		next.Major, next.Minor, next.Patch = next.Major+1, 0, 0
+	default:
+		return Version{}, false
+	}
+	return next, true
+}
+
+// PrevVersion returns the greatest version of the grain less than v, the
+// counterpart of NextVersion: 1.2.3 becomes 1.0.0, 1.2.0 and 1.2.2. The
+// previous patch of a prerelease 1.2.3-beta is 1.2.2. A zero component
+// borrows from the next higher one, lower components become the greatest
+// number, so the previous patch of 2.0.0 is
+// 1.18446744073709551615.18446744073709551615. Build meta data is dropped.
+// ok is false if there is no lower version of the grain, e.g. for 0.0.0.
+func PrevVersion(v Version, grain ReleaseType) (prev Version, ok bool) {
+	prev = truncateVersion(v, grain)
+	if prev.LT(v) {
+		return prev, true
+	}
+	switch {
+	case grain >= ReleasePatch && prev.Patch > 0:
+		prev.Patch--
+	case grain >= ReleaseMinor && prev.Minor > 0:
+		prev.Minor--
+		if grain >= ReleasePatch {
+			prev.Patch = math.MaxUint64
+		}
+	case prev.Major > 0:
+		prev.Major--
+		if grain >= ReleaseMinor {
+			prev.Minor = math.MaxUint64
+		}
+		if grain >= ReleasePatch {
+			prev.Patch = math.MaxUint64
+		}
+	default:
+		return Version{}, false
+	}
+	return prev, true
+}
+
+// truncateVersion returns the release of v with the components below grain
+// set to zero.
+func truncateVersion(v Version, grain ReleaseType) Version {
+	t := Version{Major: v.Major}
+	if grain >= ReleaseMinor {
+		t.Minor = v.Minor
+	}
+	if grain >= ReleasePatch {
+		t.Patch = v.Patch
+	}
+	return t
+}
@@ -0,0 +1,66 @@
+package semver
+
+import "testing"
+
+func TestNextPrevVersion(t *testing.T) {
+	max := "18446744073709551615"
+	tests := []struct {
+		v     string
+		grain ReleaseType
+		next  string
+		prev  string
+	}{
+		{"1.2.3", ReleaseMajor, "2.0.0", "1.0.0"},
+		{"1.2.3", ReleaseMinor, "1.3.0", "1.2.0"},
+		{"1.2.3", ReleasePatch, "1.2.4", "1.2.2"},
+		{"1.0.0", ReleaseMajor, "2.0.0", "0.0.0"},
+		{"1.0.0", ReleaseMinor, "1.1.0", "0." + max + ".0"},
+		{"1.0.0", ReleasePatch, "1.0.1", "0." + max + "." + max},
+		{"1.2.0", ReleasePatch, "1.2.1", "1.1." + max},
+		{"2.0.0-beta", ReleaseMajor, "2.0.0", "1.0.0"},
+		{"1.3.0-beta", ReleaseMinor, "1.3.0", "1.2.0"},
+		{"1.3.1-beta", ReleaseMinor, "1.4.0", "1.3.0"},
+		{"1.2.3-beta", ReleasePatch, "1.2.3", "1.2.2"},
+		{"1.2.3+build", ReleasePatch, "1.2.4", "1.2.2"},
+		{"0.0.0", ReleasePatch, "0.0.1", ""},
+		{"0.0.0-0", ReleaseMajor, "0.0.0", ""},
+		{"0.5.0", ReleaseMajor, "1.0.0", "0.0.0"},
+		{"1.2." + max, ReleasePatch, "1.3.0", "1.2.18446744073709551614"},
+		{"1." + max + ".0", ReleaseMinor, "2.0.0", "1.18446744073709551614.0"},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if next, err := NextVersion(v, tc.grain); err != nil || next.String() != tc.next {
+			t.Errorf("Invalid for case %q %s: Expected next %q, got: %q (%v)", tc.v, tc.grain, tc.next, next, err)
+		}
+		if prev, ok := PrevVersion(v, tc.grain); ok != (tc.prev != "") || (ok && prev.String() != tc.prev) {
+			t.Errorf("Invalid for case %q %s: Expected prev %q, got: %q", tc.v, tc.grain, tc.prev, prev)
+		}
+	}
+}
+
+func TestNextVersionOverflow(t *testing.T) {
+	max := "18446744073709551615"
+	tests := []struct {
+		v     string
+		grain ReleaseType
+	}{
+		{max + ".0.0", ReleaseMajor},
+		{max + "." + max + ".0", ReleaseMinor},
+		{max + "." + max + "." + max, ReleasePatch},
+	}
+	for _, tc := range tests {
+		next, err := NextVersion(MustParse(tc.v), tc.grain)
+		if _, ok := err.(*OverflowError); !ok {
+			t.Errorf("Invalid for case %q %s: Expected an *OverflowError, got: %q (%v)", tc.v, tc.grain, next, err)
+		}
+	}
+}
+
+func TestReleaseTypeString(t *testing.T) {
+	for grain, s := range map[ReleaseType]string{ReleaseMajor: "major", ReleaseMinor: "minor", ReleasePatch: "patch", 7: "ReleaseType(7)"} {
+		if grain.String() != s {
+			t.Errorf("Expected %q, got: %q", s, grain)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizePartial converts a partial or wildcard version into the complete
+// version the range parser uses as its lower bound:
+//
+//   - missing parts are 0: "1" becomes "1.0.0", "1.2" becomes "1.2.0"
+//   - the wildcards "x" and "*" are 0: "1.x" becomes "1.0.0", "1.*.3"
+//     becomes "1.0.3"
+//   - prerelease and build meta data are kept, but only allowed after three
+//     numeric parts: "1.2.3-beta+build" is unchanged
+//
+// Anything else, e.g. leading zeroes, more than three parts or other
+// characters, is an error.
+func NormalizePartial(s string) (string, error) {
+	core, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i != -1 {
+		core, suffix = s[:i], s[i:]
+	}
+	elems := strings.Split(core, ".")
+	if len(elems) > 3 {
+		return "", fmt.Errorf("partial version %s has more than three parts", quote(s))
+	}
+
+	var parts versionParts
+	wildcard := false
+	for i, elem := range elems {
+		switch {
+		case elem == "x" || elem == "*":
+			wildcard = true
+		case elem != "" && containsOnly(elem, numbers):
+			parts[i] = elem
+		default:
+			return "", fmt.Errorf("invalid part %s in partial version %s", quote(elem), quote(s))
+		}
+	}
+	if suffix != "" && (wildcard || len(elems) < 3) {
+		return "", fmt.Errorf("partial version %s can only have prerelease or build meta data after three numbers", quote(s))
+	}
+	parts[3] = suffix
+
+	v, err := partsVersion(parts)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,33 @@
+package semver
+
+import "testing"
+
+func TestNormalizePartial(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{"1", "1.0.0"},
+		{"1.2", "1.2.0"},
+		{"1.x", "1.0.0"},
+		{"1.*.3", "1.0.3"},
+		{"*", "0.0.0"},
+		{"x.x.x", "0.0.0"},
+		{"1.2.3", "1.2.3"},
+		{"1.2.3-beta.1+build", "1.2.3-beta.1+build"},
+	}
+	for _, tc := range tests {
+		o, err := NormalizePartial(tc.i)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if o != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
+		}
+	}
+
+	for _, s := range []string{"", "1.", "1..2", "1.2.3.4", "01.2", "1.x-beta", "1.2-beta", "1.2.3-", "1.2.3-01", "1x", "X", "v1.2", "1.2.3abc", "18446744073709551616"} {
+		if o, err := NormalizePartial(s); err == nil {
+			t.Errorf("Expected error for case %q, got: %q", s, o)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package semver
+
+// OutdatedKind classifies the result of Outdated like the colors of
+// "npm outdated".
+type OutdatedKind int
+
+const (
+	// UpToDate means neither wanted nor latest are newer than current.
+	UpToDate OutdatedKind = iota
+	// UpdateWanted means a newer version satisfying the spec is available,
+	// shown red by npm.
+	UpdateWanted
+	// UpdateLatest means current is the wanted version, but a newer latest
+	// version outside the spec is available, shown yellow by npm.
+	UpdateLatest
+)
+
+func (k OutdatedKind) String() string {
+	switch k {
+	case UpdateWanted:
+		return "update wanted"
+	case UpdateLatest:
+		return "update latest"
+	}
+	return "up to date"
+}
+
+// Outdated computes the "wanted" and "latest" versions of a dependency with
+// the semantics of "npm outdated": wanted is the highest available version
+// satisfying spec, latest the highest available release. Prereleases are
+// only wanted if spec names a prerelease of the same major.minor.patch, see
+// RangeOptions.NPMCompat, and are only latest if there is no release at all.
+// wanted is the zero Version if no available version satisfies spec.
+func Outdated(current Version, spec Range, available []Version) (wanted, latest Version, kind OutdatedKind) {
+	c, ok := constraintsOf(spec)
+	if ok {
+		c = &Constraints{sets: c.sets, npm: true}
+	}
+	wants := func(v Version) bool {
+		if len(v.Pre) == 0 {
+			return spec(v)
+		}
+		return ok && c.Check(v)
+	}
+	wanted, _ = MaxSatisfying(available, wants)
+
+	latest, _ = latestVersion(available)
+
+	switch {
+	case wanted.GT(current):
+		kind = UpdateWanted
+	case latest.GT(current):
+		kind = UpdateLatest
+	}
+	return wanted, latest, kind
+}
+
+// latestVersion returns the highest release of available, or the highest
+// prerelease if there is no release at all, like the "latest" dist-tag of
+// npm. ok is false if available is empty.
+func latestVersion(available []Version) (latest Version, ok bool) {
+	if latest, ok = MaxSatisfying(available, func(v Version) bool { return len(v.Pre) == 0 }); ok {
+		return latest, true
+	}
+	return MaxSatisfying(available, func(Version) bool { return true })
+}
@@ -0,0 +1,37 @@
+package semver
+
+import "testing"
+
+func TestOutdated(t *testing.T) {
+	available := []Version{}
+	for _, s := range []string{"1.0.0", "1.1.0", "1.2.0", "1.3.0-beta.1", "2.0.0", "2.1.0", "3.0.0-rc.1"} {
+		available = append(available, MustParse(s))
+	}
+	tests := []struct {
+		current string
+		spec    string
+		wanted  string
+		latest  string
+		kind    OutdatedKind
+	}{
+		{"1.1.0", "^1.0.0", "1.2.0", "2.1.0", UpdateWanted},
+		{"1.2.0", "^1.0.0", "1.2.0", "2.1.0", UpdateLatest},
+		{"2.1.0", "^2.0.0", "2.1.0", "2.1.0", UpToDate},
+		{"1.3.0-beta.1", "^1.3.0-beta.1", "1.3.0-beta.1", "2.1.0", UpdateLatest},
+		{"1.2.0", ">=1.2.0 <1.4.0", "1.2.0", "2.1.0", UpdateLatest},
+		{"1.0.0", "^1.3.0-beta.0", "1.3.0-beta.1", "2.1.0", UpdateWanted},
+		{"1.0.0", "^4.0.0", "0.0.0", "2.1.0", UpdateLatest},
+		{"3.0.0-rc.1", "*", "2.1.0", "2.1.0", UpToDate},
+	}
+	for _, tc := range tests {
+		wanted, latest, kind := Outdated(MustParse(tc.current), MustParseRange(tc.spec), available)
+		if wanted.String() != tc.wanted || latest.String() != tc.latest || kind != tc.kind {
+			t.Errorf("Invalid for case %q %q: Expected %s %s (%s), got: %s %s (%s)", tc.current, tc.spec, tc.wanted, tc.latest, tc.kind, wanted, latest, kind)
+		}
+	}
+
+	_, latest, _ := Outdated(MustParse("1.0.0-alpha"), MustParseRange("*"), []Version{MustParse("1.0.0-beta")})
+	if latest.String() != "1.0.0-beta" {
+		t.Errorf("Expected prerelease latest without releases, got: %s", latest)
+	}
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PreciseVersion is a version together with its precision, the number of
+// components of Major.Minor.Patch the version string specified: 1 for "1" or
+// "1.x", 2 for "v1.2" and 3 for "1.2.3". It is written back with the same
+// components, e.g. to rewrite a manifest as it was or to read a bare "1.2"
+// as the range "1.2.x". The Version is a named field, so that the encodings
+// of Version, which write all three components, are not promoted.
+type PreciseVersion struct {
+	Version   Version
+	Precision int
+}
+
+// ParseWithPrecision parses a version string like ParseTolerant and keeps
+// its precision.
+func ParseWithPrecision(s string) (PreciseVersion, error) {
+	v, precision, err := parseWithOptions(s, TolerantParseOptions())
+	if err != nil {
+		return PreciseVersion{}, err
+	}
+	return PreciseVersion{Version: v, Precision: precision}, nil
+}
+
+// String returns the version with the components of its precision, "1.2"
+// for precision 2 and "*" for precision 0. The prerelease and build meta
+// data are kept. A "v" prefix or leading zeroes of the parsed string are
+// not.
+func (p PreciseVersion) String() string {
+	if p.Precision >= 3 {
+		return p.Version.String()
+	}
+	var b strings.Builder
+	switch p.Precision {
+	case 2:
+		b.WriteString(strconv.FormatUint(p.Version.Major, 10) + "." + strconv.FormatUint(p.Version.Minor, 10))
+	case 1:
+		b.WriteString(strconv.FormatUint(p.Version.Major, 10))
+	default:
+		b.WriteString("*")
+	}
+	s := p.Version.String()
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		b.WriteString(s[i:])
+	}
+	return b.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, writing
+// String.
+func (p PreciseVersion) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, see
+// ParseWithPrecision.
+func (p *PreciseVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseWithPrecision(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
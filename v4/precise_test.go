@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseWithPrecision(t *testing.T) {
+	tests := []struct {
+		s         string
+		v         string
+		precision int
+		str       string
+	}{
+		{"1.2.3", "1.2.3", 3, "1.2.3"},
+		{"1.2.3-beta.1+build.5", "1.2.3-beta.1+build.5", 3, "1.2.3-beta.1+build.5"},
+		{"1.2", "1.2.0", 2, "1.2"},
+		{"1", "1.0.0", 1, "1"},
+		{"1.x", "1.0.0", 1, "1"},
+		{"1.2.*", "1.2.0", 2, "1.2"},
+		{"x", "0.0.0", 0, "*"},
+		{"v1.2.3", "1.2.3", 3, "1.2.3"},
+		{"v1.2", "1.2.0", 2, "1.2"},
+		{" 1 ", "1.0.0", 1, "1"},
+		{"01.02", "1.2.0", 2, "1.2"},
+	}
+	for _, tc := range tests {
+		p, err := ParseWithPrecision(tc.s)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.s, err)
+			continue
+		}
+		if p.Version.String() != tc.v || p.Precision != tc.precision {
+			t.Errorf("Invalid for case %q: Expected %q %d, got: %q %d", tc.s, tc.v, tc.precision, p.Version, p.Precision)
+		}
+		if s := p.String(); s != tc.str {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.str, s)
+		}
+	}
+
+	if _, err := ParseWithPrecision("1.2-beta"); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestPreciseVersionJSON(t *testing.T) {
+	type manifest struct {
+		Engine PreciseVersion `json:"engine"`
+	}
+	in := `{"engine":"1.2"}`
+	var m manifest
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Engine.Precision != 2 || !m.Engine.Version.EQ(MustParse("1.2.0")) {
+		t.Errorf("Unexpected version %+v", m.Engine)
+	}
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("Expected %s, got: %s", in, out)
+	}
+}
@@ -0,0 +1,46 @@
+package semver
+
+// PrereleaseAnchors returns the versions of all comparators naming a
+// prerelease, sorted and without duplicates, e.g. [1.2.3-beta.2] for
+// "^1.2.3-beta.2 || >=2.0.0".
+func (c *Constraints) PrereleaseAnchors() []Version {
+	var anchors []Version
+	for _, set := range c.sets {
+		for _, vr := range set {
+			if len(vr.v.Pre) > 0 {
+				anchors = append(anchors, vr.v)
+			}
+		}
+	}
+	Sort(anchors)
+	unique := anchors[:0]
+	for i, v := range anchors {
+		if i == 0 || !v.Equals(anchors[i-1]) {
+			unique = append(unique, v)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	return unique
+}
+
+// AllowsPrereleases checks if r contains a comparator naming a prerelease,
+// which opts into prereleases under npm's matching rule, see
+// RangeOptions.NPMCompat. Policy checks can use it to reject prerelease
+// constraints in production manifests. A Range not created by this package
+// can not be inspected and reports false.
+func AllowsPrereleases(r Range) bool {
+	return len(PrereleaseAnchors(r)) > 0
+}
+
+// PrereleaseAnchors returns the versions of all comparators of r naming a
+// prerelease, see Constraints.PrereleaseAnchors. A Range not created by this
+// package can not be inspected and has no anchors.
+func PrereleaseAnchors(r Range) []Version {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return nil
+	}
+	return c.PrereleaseAnchors()
+}
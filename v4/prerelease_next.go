@@ -0,0 +1,57 @@
+package semver
+
+import "strings"
+
+// NextPrereleaseFor returns the next free prerelease of base on channel, e.g.
+// "1.2.0-beta.7" if "1.2.0-beta.6" is the highest existing beta of 1.2.0.
+// The counter starts at 0 like npm's "prerelease" increment, an empty channel
+// yields plain counters like "1.2.0-3". Only existing versions with the same
+// major.minor.patch and exactly the channel identifiers followed by a
+// numeric counter are taken into account, the prerelease and build meta data
+// of base are ignored.
+//
+// The channel must consist of valid dot separated prerelease identifiers,
+// NextPrereleaseFor panics otherwise.
+func NextPrereleaseFor(base Version, existing []Version, channel string) Version {
+	var prefix []PRVersion
+	if channel != "" {
+		for _, s := range strings.Split(channel, ".") {
+			pr, err := NewPRVersion(s)
+			if err != nil {
+				panic(`semver: NextPrereleaseFor(` + truncate(channel, maxErrorInputLength) + `): ` + err.Error())
+			}
+			prefix = append(prefix, pr)
+		}
+	}
+
+	var next uint64
+	for _, v := range existing {
+		if v.Major != base.Major || v.Minor != base.Minor || v.Patch != base.Patch || len(v.Pre) != len(prefix)+1 {
+			continue
+		}
+		counter := v.Pre[len(prefix)]
+		if !counter.IsNum || counter.VersionNum < next {
+			continue
+		}
+		if samePrerelease(v.Pre[:len(prefix)], prefix) {
+			next = counter.VersionNum + 1
+		}
+	}
+
+	pre := make([]PRVersion, len(prefix), len(prefix)+1)
+	copy(pre, prefix)
+	pre = append(pre, PRVersion{VersionNum: next, IsNum: true})
+	return Version{Major: base.Major, Minor: base.Minor, Patch: base.Patch, Pre: pre}
+}
+
+func samePrerelease(a, b []PRVersion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Compare(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
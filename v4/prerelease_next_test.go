@@ -0,0 +1,37 @@
+package semver
+
+import "testing"
+
+func TestNextPrereleaseFor(t *testing.T) {
+	existing := []Version{}
+	for _, s := range []string{"1.2.0-beta.6", "1.2.0-beta.2", "1.2.0-beta.x", "1.2.0-beta.9.1", "1.2.0-alpha.11", "1.2.0-rc.1.0", "1.1.0-beta.20", "1.2.0-4", "1.2.0"} {
+		existing = append(existing, MustParse(s))
+	}
+	tests := []struct {
+		base    string
+		channel string
+		next    string
+	}{
+		{"1.2.0", "beta", "1.2.0-beta.7"},
+		{"1.2.0-beta.3+build", "beta", "1.2.0-beta.7"},
+		{"1.2.0", "alpha", "1.2.0-alpha.12"},
+		{"1.2.0", "rc", "1.2.0-rc.0"},
+		{"1.2.0", "rc.1", "1.2.0-rc.1.1"},
+		{"1.2.0", "", "1.2.0-5"},
+		{"1.3.0", "beta", "1.3.0-beta.0"},
+	}
+	for _, tc := range tests {
+		if next := NextPrereleaseFor(MustParse(tc.base), existing, tc.channel); next.String() != tc.next {
+			t.Errorf("Invalid for case %q %q: Expected %q, got: %q", tc.base, tc.channel, tc.next, next)
+		}
+	}
+}
+
+func TestNextPrereleaseFor_panic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Should have panicked")
+		}
+	}()
+	_ = NextPrereleaseFor(MustParse("1.0.0"), nil, "beta..1")
+}
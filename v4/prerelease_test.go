@@ -0,0 +1,39 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPrereleaseAnchors(t *testing.T) {
+	tests := []struct {
+		r       string
+		anchors string
+	}{
+		{"^1.2.3-beta.2 || >=2.0.0", "[1.2.3-beta.2]"},
+		{">=1.0.0-rc.1 <1.0.0-rc.5 || 1.0.0-rc.1", "[1.0.0-rc.1 1.0.0-rc.5]"},
+		{">=1.0.0 <2.0.0", "[]"},
+		{"1.2.3+build", "[]"},
+	}
+	for _, tc := range tests {
+		r := MustParseRange(tc.r)
+		if anchors := fmtVersions(PrereleaseAnchors(r)); anchors != tc.anchors {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s", tc.r, tc.anchors, anchors)
+		}
+		if allows := AllowsPrereleases(r); allows != (tc.anchors != "[]") {
+			t.Errorf("Invalid for case %q: Expected AllowsPrereleases %t", tc.r, !allows)
+		}
+	}
+
+	combined := MustParseRange(">=1.0.0").AND(MustParseRange("<2.0.0-beta"))
+	if anchors := fmtVersions(PrereleaseAnchors(combined)); anchors != "[2.0.0-beta]" {
+		t.Errorf("Invalid anchors of combined range: %s", anchors)
+	}
+	if AllowsPrereleases(func(Version) bool { return true }) {
+		t.Errorf("A plain func can not be inspected")
+	}
+}
+
+func fmtVersions(versions []Version) string {
+	return fmt.Sprint(versions)
+}
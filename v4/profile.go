@@ -0,0 +1,117 @@
+package semver
+
+import "strings"
+
+// Profile bundles the range dialect of an ecosystem, so that callers select
+// one value instead of combining options, see ParseWith.
+type Profile struct {
+	Name string
+
+	// Options are the parse options, e.g. the prerelease rule of npm.
+	Options RangeOptions
+
+	// BareVersion is the constraint of a version without operator, e.g.
+	// "1.2.3" means "^1.2.3" in Cargo.
+	BareVersion ConstraintStyle
+
+	// Pessimistic makes "~" and "~>" the pessimistic operator of RubyGems
+	// and Composer: the last given number may increase, "~>1.2" allows
+	// versions below 2.0.0 and "~>1.2.3" versions below 1.3.0.
+	Pessimistic bool
+
+	// CommaAND makes "," separate comparators like a space, e.g.
+	// ">= 1.2, < 2.0".
+	CommaAND bool
+}
+
+var (
+	// NPMProfile parses ranges like npm.
+	NPMProfile = Profile{Name: "npm", Options: RangeOptions{NPMCompat: true}, BareVersion: StyleExact}
+	// CargoProfile parses version requirements like Cargo, a bare version
+	// is a caret requirement.
+	CargoProfile = Profile{Name: "cargo", Options: RangeOptions{NPMCompat: true}, BareVersion: StyleCaret, CommaAND: true}
+	// GemProfile parses requirements like RubyGems.
+	GemProfile = Profile{Name: "gem", BareVersion: StyleExact, Pessimistic: true, CommaAND: true}
+	// ComposerProfile parses version constraints like Composer.
+	ComposerProfile = Profile{Name: "composer", BareVersion: StyleExact, Pessimistic: true, CommaAND: true}
+	// StrictProfile admits only pure SemVer 2.0.0 comparators, see
+	// RangeOptions.SemVerOnly.
+	StrictProfile = Profile{Name: "strict", Options: RangeOptions{SemVerOnly: true}, BareVersion: StyleExact}
+)
+
+// ParseWith parses the range s in the dialect of profile. The default
+// dialect of ParseRange is the zero Profile with BareVersion StyleExact.
+func ParseWith(profile Profile, s string) (Range, error) {
+	c, err := ParseConstraintsWithOptions(profile.rewrite(s), profile.Options)
+	if err != nil {
+		return nil, err
+	}
+	return c.Range(), nil
+}
+
+// rewrite translates the dialect features of p in s to the default
+// dialect. s is returned unchanged if it does not scan, the parser reports
+// the error then.
+func (p Profile) rewrite(s string) string {
+	if p.CommaAND {
+		s = strings.Replace(s, ",", " ", -1)
+	}
+	if p.BareVersion == StyleExact && !p.Pessimistic {
+		return s
+	}
+	tokens, err := scanRange(s)
+	if err != nil {
+		return s
+	}
+	parts := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.Kind {
+		case TokenOperator:
+			op := t.Text
+			if i+1 < len(tokens) && tokens[i+1].Kind == TokenVersion {
+				i++
+				if p.Pessimistic && (op == "~" || op == "~>") {
+					parts = append(parts, pessimisticComparator(tokens[i].Text))
+					continue
+				}
+				parts = append(parts, op+tokens[i].Text)
+				continue
+			}
+			parts = append(parts, op)
+		case TokenVersion:
+			hyphen := (i+1 < len(tokens) && tokens[i+1].Kind == TokenHyphen) || (i > 0 && tokens[i-1].Kind == TokenHyphen)
+			switch {
+			case hyphen:
+				parts = append(parts, t.Text)
+			case p.BareVersion == StyleCaret:
+				parts = append(parts, "^"+t.Text)
+			case p.BareVersion == StyleTilde:
+				parts = append(parts, "~"+t.Text)
+			default:
+				parts = append(parts, t.Text)
+			}
+		default:
+			parts = append(parts, t.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// pessimisticComparator returns the comparators of the default dialect
+// matching the pessimistic operator applied to version: tilde if all three
+// numbers are given, caret otherwise. Caret ranges of major version zero only
+// allow changes right of the minor version, so "~>0.2" becomes ">=0.2 <1".
+func pessimisticComparator(version string) string {
+	core := version
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	if strings.Count(core, ".") >= 2 {
+		return "~" + version
+	}
+	if major := strings.SplitN(core, ".", 2)[0]; major != "" && containsOnly(major, "0") {
+		return ">=" + version + " <1"
+	}
+	return "^" + version
+}
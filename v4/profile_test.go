@@ -0,0 +1,67 @@
+package semver
+
+import "testing"
+
+func TestParseWith(t *testing.T) {
+	tests := []struct {
+		profile  Profile
+		r        string
+		expanded string
+	}{
+		{NPMProfile, "^1.2.3", ">=1.2.3 <2.0.0-0"},
+		{NPMProfile, "1.2.3", "1.2.3"},
+		{CargoProfile, "1.2.3", ">=1.2.3 <2.0.0-0"},
+		{CargoProfile, ">= 1.2, < 1.5", ">=1.2.0 <1.5.0"},
+		{CargoProfile, "=1.2.3", "1.2.3"},
+		{CargoProfile, "1.0.0 - 2", ">=1.0.0 <3.0.0-0"},
+		{GemProfile, "~> 1.2", ">=1.2.0 <2.0.0"},
+		{GemProfile, "~> 1.2.3", ">=1.2.3 <1.3.0"},
+		{GemProfile, "~> 0.2", ">=0.2.0 <1.0.0"},
+		{GemProfile, "~> 0.0.3", ">=0.0.3 <0.1.0"},
+		{CargoProfile, "0.2.3", ">=0.2.3 <0.3.0-0"},
+		{GemProfile, ">= 1.0, != 1.5.0", ">=1.0.0 !=1.5.0"},
+		{ComposerProfile, "~1.2 || ~2.0.1", ">=1.2.0 <2.0.0 || >=2.0.1 <2.1.0"},
+		{ComposerProfile, ">=1.0,<2.0", ">=1.0.0 <2.0.0"},
+		{StrictProfile, ">=1.0.0 <2.0.0", ">=1.0.0 <2.0.0"},
+		{Profile{}, "~>1.2", ">=1.2.0 <1.3.0"},
+	}
+	for _, tc := range tests {
+		r, err := ParseWith(tc.profile, tc.r)
+		if err != nil {
+			t.Errorf("Invalid for case %s %q: unexpected error: %s", tc.profile.Name, tc.r, err)
+			continue
+		}
+		c, _ := constraintsOf(r)
+		if c.String() != tc.expanded {
+			t.Errorf("Invalid for case %s %q: Expected %q, got: %q", tc.profile.Name, tc.r, tc.expanded, c)
+		}
+	}
+}
+
+func TestParseWithPrerelease(t *testing.T) {
+	v := MustParse("1.5.0-beta.1")
+	if r, _ := ParseWith(CargoProfile, "1.2.3"); r(v) {
+		t.Errorf("Cargo requirements must not match unrelated prereleases")
+	}
+	if r, _ := ParseWith(GemProfile, "~> 1.2"); !r(v) {
+		t.Errorf("Gem requirements match prereleases by precedence")
+	}
+}
+
+func TestParseWithInvalid(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		r       string
+	}{
+		{StrictProfile, "^1.2.3"},
+		{StrictProfile, "1.2"},
+		{NPMProfile, ">=1.0.0,<2.0.0"},
+		{CargoProfile, ">=1.0.0 garbage"},
+		{GemProfile, "~>"},
+	}
+	for _, tc := range tests {
+		if _, err := ParseWith(tc.profile, tc.r); err == nil {
+			t.Errorf("Invalid for case %s %q: Expected error", tc.profile.Name, tc.r)
+		}
+	}
+}
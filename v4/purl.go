@@ -0,0 +1,134 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FromPurl parses the package-url purl, e.g. "pkg:npm/%40scope/name@1.2.3",
+// and returns its type as ecosystem, the name including its namespace, e.g.
+// "@scope/name", and the version. Qualifiers and subpath are ignored. The
+// version is coerced by the rules of the ecosystem:
+//
+//   - golang: the "v" prefix is removed, "v1.2.3" is 1.2.3
+//   - gem: a segment starting with a letter starts the prerelease, missing
+//     numbers are zero, "1.2.pre.1" is 1.2.0-pre.1
+//   - npm, cargo, composer, hex, nuget, pub and swift: versions must be valid
+//     semantic versions
+//   - other ecosystems are parsed like ParseTolerant
+func FromPurl(purl string) (ecosystem string, name string, v Version, err error) {
+	rest := strings.TrimSpace(purl)
+	if !strings.HasPrefix(rest, "pkg:") {
+		return "", "", Version{}, fmt.Errorf("purl %s must start with \"pkg:\"", quote(purl))
+	}
+	rest = strings.TrimLeft(rest[len("pkg:"):], "/")
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		rest = rest[:i]
+	}
+	slash := strings.IndexByte(rest, '/')
+	if slash <= 0 {
+		return "", "", Version{}, fmt.Errorf("purl %s has no type and name", quote(purl))
+	}
+	ecosystem, rest = strings.ToLower(rest[:slash]), strings.Trim(rest[slash+1:], "/")
+
+	at := strings.LastIndexByte(rest, '@')
+	if at < 0 || at < strings.LastIndexByte(rest, '/') {
+		return "", "", Version{}, fmt.Errorf("purl %s has no version", quote(purl))
+	}
+	rawVersion, err := url.PathUnescape(rest[at+1:])
+	if err != nil {
+		return "", "", Version{}, fmt.Errorf("purl %s: %s", quote(purl), err)
+	}
+	segments := strings.Split(rest[:at], "/")
+	for i, segment := range segments {
+		if segments[i], err = url.PathUnescape(segment); err != nil {
+			return "", "", Version{}, fmt.Errorf("purl %s: %s", quote(purl), err)
+		}
+	}
+	name = strings.Join(segments, "/")
+	if name == "" {
+		return "", "", Version{}, fmt.Errorf("purl %s has no name", quote(purl))
+	}
+
+	if v, err = parseEcosystemVersion(ecosystem, rawVersion); err != nil {
+		return "", "", Version{}, fmt.Errorf("purl %s: invalid %s version %s: %s", quote(purl), ecosystem, quote(rawVersion), err)
+	}
+	return ecosystem, name, v, nil
+}
+
+// ToPurl returns the package-url of the package name at version v in
+// ecosystem, the reverse of FromPurl, e.g. "pkg:golang/github.com/a/b@v1.2.3".
+// The version is formatted by the rules of the ecosystem.
+func ToPurl(ecosystem string, name string, v Version) string {
+	ecosystem = strings.ToLower(ecosystem)
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = purlEscape(segment)
+	}
+	return "pkg:" + ecosystem + "/" + strings.Join(segments, "/") + "@" + purlEscape(formatEcosystemVersion(ecosystem, v))
+}
+
+// purlEscape percent-encodes s for a purl, which keeps only unreserved
+// characters.
+func purlEscape(s string) string {
+	return strings.NewReplacer("+", "%2B", "@", "%40").Replace(url.PathEscape(s))
+}
+
+var errEmptyVersion = errors.New("version is empty")
+
+func parseEcosystemVersion(ecosystem, s string) (Version, error) {
+	if s == "" {
+		return Version{}, errEmptyVersion
+	}
+	switch ecosystem {
+	case "golang":
+		return parseFull(strings.TrimPrefix(s, "v"))
+	case "gem":
+		return parseGemVersion(s)
+	case "npm", "cargo", "composer", "hex", "nuget", "pub", "swift":
+		return parseFull(s)
+	}
+	return ParseTolerant(s)
+}
+
+// parseGemVersion converts a RubyGems version to a semantic version: the
+// numeric segments before the first segment starting with a letter are the
+// release, the remaining segments the prerelease.
+func parseGemVersion(s string) (Version, error) {
+	segments := strings.Split(s, ".")
+	n := 0
+	for n < len(segments) && segments[n] != "" && isDigit(segments[n][0]) {
+		n++
+	}
+	if n == 0 || n > 3 {
+		return Version{}, fmt.Errorf("expected 1 to 3 numeric segments")
+	}
+	release := append([]string(nil), segments[:n]...)
+	for len(release) < 3 {
+		release = append(release, "0")
+	}
+	version := strings.Join(release, ".")
+	if n < len(segments) {
+		version += "-" + strings.Join(segments[n:], ".")
+	}
+	return Parse(version)
+}
+
+func formatEcosystemVersion(ecosystem string, v Version) string {
+	switch ecosystem {
+	case "golang":
+		return "v" + v.String()
+	case "gem":
+		gem := Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}.String()
+		for _, pre := range v.Pre {
+			gem += "." + pre.String()
+		}
+		return gem
+	}
+	return v.String()
+}
@@ -0,0 +1,81 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromPurl(t *testing.T) {
+	tests := []struct {
+		purl      string
+		ecosystem string
+		name      string
+		v         string
+	}{
+		{"pkg:npm/left-pad@1.3.0", "npm", "left-pad", "1.3.0"},
+		{"pkg:npm/%40angular/core@16.2.0-rc.1", "npm", "@angular/core", "16.2.0-rc.1"},
+		{"pkg:npm/@angular/core@16.2.0", "npm", "@angular/core", "16.2.0"},
+		{"pkg:NPM/foo@1.0.0%2Bbuild.5?repository_url=x#lib", "npm", "foo", "1.0.0+build.5"},
+		{"pkg:golang/github.com/Jarred-Sumner/semver@v4.0.0", "golang", "github.com/Jarred-Sumner/semver", "4.0.0"},
+		{"pkg:gem/rails@7.1.0.beta1", "gem", "rails", "7.1.0-beta1"},
+		{"pkg:gem/rack@2.1.pre.2", "gem", "rack", "2.1.0-pre.2"},
+		{"pkg:cargo/serde@1.0.188", "cargo", "serde", "1.0.188"},
+		{"pkg:pypi/django@4.2", "pypi", "django", "4.2.0"},
+	}
+	for _, tc := range tests {
+		ecosystem, name, v, err := FromPurl(tc.purl)
+		if err != nil {
+			t.Errorf("Invalid for case %q: unexpected error: %s", tc.purl, err)
+			continue
+		}
+		if ecosystem != tc.ecosystem || name != tc.name || v.String() != tc.v {
+			t.Errorf("Invalid for case %q: Expected %q %q %q, got: %q %q %q", tc.purl, tc.ecosystem, tc.name, tc.v, ecosystem, name, v)
+		}
+	}
+}
+
+func TestFromPurlInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"npm/foo@1.0.0",
+		"pkg:npm",
+		"pkg:npm/foo",
+		"pkg:npm/@1.0.0",
+		"pkg:npm/%40scope/foo",
+		"pkg:npm/foo@",
+		"pkg:npm/foo@1.0",
+		"pkg:npm/foo@v1.0.0",
+		"pkg:golang/example.com/mod@1.x",
+		"pkg:gem/rails@beta",
+		"pkg:npm/foo@1.0.0%zz",
+	}
+	for _, purl := range tests {
+		if _, _, _, err := FromPurl(purl); err == nil {
+			t.Errorf("Invalid for case %q: Expected error", purl)
+		}
+	}
+}
+
+func TestToPurl(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		name      string
+		v         string
+		purl      string
+	}{
+		{"npm", "left-pad", "1.3.0", "pkg:npm/left-pad@1.3.0"},
+		{"npm", "@angular/core", "16.2.0+build.1", "pkg:npm/%40angular/core@16.2.0%2Bbuild.1"},
+		{"golang", "github.com/Jarred-Sumner/semver", "4.0.0", "pkg:golang/github.com/Jarred-Sumner/semver@v4.0.0"},
+		{"Gem", "rails", "7.1.0-beta1", "pkg:gem/rails@7.1.0.beta1"},
+	}
+	for _, tc := range tests {
+		purl := ToPurl(tc.ecosystem, tc.name, MustParse(tc.v))
+		if purl != tc.purl {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.name, tc.purl, purl)
+		}
+		ecosystem, name, v, err := FromPurl(purl)
+		if err != nil || ecosystem != strings.ToLower(tc.ecosystem) || name != tc.name || v.String() != tc.v {
+			t.Errorf("Invalid for case %q: round trip gives %q %q %q %v", tc.name, ecosystem, name, v, err)
+		}
+	}
+}
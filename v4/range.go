@@ -2,6 +2,7 @@ package semver
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -39,8 +40,9 @@ var (
 )
 
 type versionRange struct {
-	v Version
-	c comparator
+	v  Version
+	c  comparator
+	op Operator
 }
 
 // rangeFunc creates a Range from the given versionRange.
@@ -55,22 +57,68 @@ func (vr *versionRange) rangeFunc() Range {
 //
 //     range, err := semver.ParseRange(">1.0.0 <2.0.0")
 //     range(semver.MustParse("1.1.1") // returns true
+//
+// Evaluating a Range created by this package does not allocate, so it can
+// be called on hot paths like request handlers.
 type Range func(Version) bool
 
-// OR combines the existing Range with another Range using logical OR.
+// OR combines the existing Range with another Range using logical OR. The
+// result can not be inspected if only one of both ranges follows the
+// prerelease rule of RangeOptions.NPMCompat.
 func (rf Range) OR(f Range) Range {
 	return Range(func(v Version) bool {
+		if isProbe(v) {
+			return probeCombined(v, rf, f, combineOR)
+		}
 		return rf(v) || f(v)
 	})
 }
 
-// AND combines the existing Range with another Range using logical AND.
+// AND combines the existing Range with another Range using logical AND. The
+// result can not be inspected if only one of both ranges follows the
+// prerelease rule of RangeOptions.NPMCompat, or if it would have more sets
+// of comparators than a range with groups may have.
 func (rf Range) AND(f Range) Range {
 	return Range(func(v Version) bool {
+		if isProbe(v) {
+			return probeCombined(v, rf, f, combineAND)
+		}
 		return rf(v) && f(v)
 	})
 }
 
+// String returns the expanded normal form of the range, see
+// Constraints.String, e.g. ">=1.2.0 <2.0.0 || >=3.0.0" for "^1.2 || >=3".
+// ParseRange reads the result back as an equivalent range, except for a
+// range parsed with RangeOptions.NPMCompat: the prerelease rule is not part
+// of the string, so it must be read back with the same options. A Range
+// which can not be inspected, e.g. a plain func literal, is returned as
+// "<not inspectable>".
+func (rf Range) String() string {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return "<not inspectable>"
+	}
+	return c.String()
+}
+
+// probeCombined reports the combined Constraints of a and b to the probe v,
+// if both can be inspected and combined.
+func probeCombined(v Version, a, b Range, combine func(a, b *Constraints) (*Constraints, bool)) bool {
+	ca, ok := constraintsOf(a)
+	if !ok {
+		return false
+	}
+	cb, ok := constraintsOf(b)
+	if !ok {
+		return false
+	}
+	if c, ok := combine(ca, cb); ok {
+		reportProbe(v, c)
+	}
+	return false
+}
+
 // ParseRange parses a range and returns a Range.
 // If the range could not be parsed an error is returned.
 //
@@ -90,50 +138,125 @@ func (rf Range) AND(f Range) Range {
 // Ranges can also be linked by logical OR:
 //   - "<2.0.0 || >=3.0.0" would match "1.x.x" and "3.x.x" but not "2.x.x"
 //
-// AND has a higher precedence than OR. It's not possible to use brackets.
+// AND has a higher precedence than OR, see RangeOptions.Precedence for
+// alternatives. Parentheses group ranges, and a group prefixed by "!"
+// matches the versions the group does not match:
+//   - "!(>=2.0.0 <3.0.0) || >=4.0.0" would match "1.x.x" and "3.x.x" and
+//     every version from "4.0.0" on, but not "2.x.x"
+//   - "(1.x || 3.x) !=1.5.0" would match "1.x.x" and "3.x.x" except "1.5.0"
+//
+// Hyphen ranges like npm's match the versions between both bounds, both
+// included. Either bound may be a prerelease, and a partial upper bound
+// matches all versions it names:
+//   - "1.2.3-alpha.1 - 2.0.0" would match "1.2.3-alpha.2" and "2.0.0"
+//   - "1.2.3 - 2.3" would match every version up to "2.3.x" but not "2.4.0"
+//
+// The legacy parser of RangeOptions.Tolerant does not support groups or
+// hyphen ranges.
 //
 // Ranges can be combined by both AND and OR
 //
 //  - `>1.0.0 <2.0.0 || >3.0.0 !4.2.1` would match `1.2.3`, `1.9.9`, `3.1.1`, but not `4.2.1`, `2.1.1`
+//
+// Malformed ranges like ">=1.2.3garbage" or "1.2.3 extra" are rejected with a
+// *RangeSyntaxError giving the offset of the offending input.
 func ParseRange(s string) (Range, error) {
-	parts := splitAndTrim(s)
-	orParts, err := splitORParts(parts)
-	if err != nil {
-		return nil, err
-	}
-	expandedParts, err := expandWildcardVersion(orParts)
-	if err != nil {
-		return nil, err
-	}
-	var orFn Range
-	for _, p := range expandedParts {
-		var andFn Range
-		for _, ap := range p {
-			opStr, vStr, err := splitComparatorVersion(ap)
-			if err != nil {
-				return nil, err
-			}
-			vr, err := buildVersionRange(opStr, vStr)
-			if err != nil {
-				return nil, fmt.Errorf("Could not parse Range %q: %s", ap, err)
-			}
-			rf := vr.rangeFunc()
+	return ParseRangeWithOptions(s, RangeOptions{})
+}
 
-			// Set function
-			if andFn == nil {
-				andFn = rf
-			} else { // Combine with existing function
-				andFn = andFn.AND(rf)
-			}
-		}
-		if orFn == nil {
-			orFn = andFn
-		} else {
-			orFn = orFn.OR(andFn)
-		}
+// RangeOptions changes how ParseRangeWithOptions parses a range.
+type RangeOptions struct {
+	// Tolerant restores the lenient parsing of earlier releases, which
+	// ignores or misreads trailing garbage in range parts, e.g. "1.2.3xyz".
+	// It exists for compatibility with stored ranges only. It also removes
+	// quotes around the parts of a range, e.g. `>= "1.2.3"`, which
+	// constraints copied from TOML or YAML files often keep.
+	Tolerant bool
+
+	// Loose reads ranges like the loose mode of node-semver, for the sloppy
+	// ranges of published npm packages like ">= 1.0.0 <2", "=v1.2.3" or
+	// "1.2.3beta". Unlike the default strict mode it:
+	//   - allows white space between an operator and its version,
+	//   - removes "v" and "=" prefixes of versions and leading zeroes,
+	//   - reads a prerelease without "-", "1.2.3beta" is "1.2.3-beta",
+	//   - reads an empty set as "*",
+	//   - drops comparators it can not read instead of failing, e.g. the
+	//     "foo" of "1.2.3 foo", and the operators "!=", "==" and "!" which
+	//     node-semver does not know. Only a range without any valid
+	//     comparator is an error.
+	// Groups are not supported. Combine it with NPMCompat to also match
+	// versions like npm.
+	Loose bool
+
+	// NPMCompat enables the range semantics of npm where they differ from
+	// the default ones. A prerelease version only satisfies a set of
+	// comparators if one of them names a prerelease of the same
+	// major.minor.patch: "^1.2.3-beta.2" allows "1.2.3-beta.4" but not
+	// "1.2.4-beta.1". Use the prerelease floor "-0" to opt into all
+	// prereleases of a release: ">=1.2.3-0" allows "1.2.3-beta.1".
+	//
+	// The exclusive upper bounds of tilde, caret, wildcard and hyphen
+	// ranges exclude the prereleases of the bound, "^1.2.3" expands to
+	// ">=1.2.3 <2.0.0-0". By default they are plain comparators, so that
+	// "^1.2.3" and "<2.0.0" allow "2.0.0-rc.1" by precedence.
+	//
+	NPMCompat bool
+
+	// IncludePrerelease disables the prerelease rule of NPMCompat like the
+	// includePrerelease option of npm: prerelease versions satisfy a set of
+	// comparators by precedence. The upper bounds of NPMCompat still
+	// exclude the prereleases of the bound, so "^1.2.3" allows
+	// "1.5.0-beta.1" but not "2.0.0-rc.1", while "<2.0.0" allows both.
+	// It has no effect without NPMCompat.
+	IncludePrerelease bool
+
+	// SemVerOnly admits only pure SemVer 2.0.0 constructs, for validators
+	// which must reject every extension of the dialect: each comparator
+	// must be a full version, optionally preceded by one of the comparison
+	// operators "=", "!=", ">", ">=", "<" or "<=". Partial versions,
+	// wildcards, hyphen ranges, the operators "~", "~>" and "^" and the
+	// aliases "==" and "!" are rejected. Implies strict parsing.
+	SemVerOnly bool
+
+	// OnDivergence, if set, enables the differential mode: every range is
+	// parsed by both the legacy and the current parser, OnDivergence is
+	// called if they disagree. The result is still determined by Tolerant.
+	// Use it to canary parser changes in production, see CompareParsers.
+	OnDivergence func(Divergence)
+
+	// Precedence selects how "||" and AND combine in a range which mixes
+	// them without parentheses. It has no effect with Tolerant.
+	Precedence Precedence
+
+	// OnAmbiguousPrecedence, if set, is called for every part of a range
+	// which mixes "||" and AND without parentheses, e.g. "a || b c", with
+	// an error pointing at the first operator of the other kind. Use it to
+	// warn authors whose ranges may not combine as they expect.
+	OnAmbiguousPrecedence func(*RangeSyntaxError)
+}
+
+// Precedence selects how "||" and AND combine without parentheses.
+type Precedence int
+
+const (
+	// PrecedenceAND binds AND tighter than "||" like npm: "a || b c" is
+	// "a || (b c)".
+	PrecedenceAND Precedence = iota
+	// PrecedenceOR binds "||" tighter than AND: "a || b c" is
+	// "(a || b) c".
+	PrecedenceOR
+	// PrecedenceExplicit rejects ranges mixing "||" and AND without
+	// parentheses, "a || (b c)" must be written instead of "a || b c".
+	PrecedenceExplicit
+)
 
+// ParseRangeWithOptions parses a range like ParseRange using opts.
+func ParseRangeWithOptions(s string, opts RangeOptions) (Range, error) {
+	c, err := ParseConstraintsWithOptions(s, opts)
+	if err != nil {
+		return nil, err
 	}
-	return orFn, nil
+	return c.Range(), nil
 }
 
 // splitORParts splits the already cleaned parts by '||'.
@@ -161,18 +284,19 @@ func splitORParts(parts []string) ([][]string, error) {
 // buildVersionRange takes a slice of 2: operator and version
 // and builds a versionRange, otherwise an error.
 func buildVersionRange(opStr, vStr string) (*versionRange, error) {
-	c := parseComparator(opStr)
-	if c == nil {
-		return nil, fmt.Errorf("Could not parse comparator %q in %q", opStr, strings.Join([]string{opStr, vStr}, ""))
+	op, ok := parseOperator(opStr)
+	if !ok {
+		return nil, fmt.Errorf("Could not parse comparator %s in %s", quote(opStr), quote(opStr+vStr))
 	}
-	v, err := Parse(vStr)
+	v, err := parse(vStr)
 	if err != nil {
-		return nil, fmt.Errorf("Could not parse version %q in %q: %s", vStr, strings.Join([]string{opStr, vStr}, ""), err)
+		return nil, fmt.Errorf("Could not parse version %s in %s: %w", quote(vStr), quote(opStr+vStr), err)
 	}
 
 	return &versionRange{
-		v: v,
-		c: c,
+		v:  v,
+		c:  op.comparator(),
+		op: op,
 	}, nil
 
 }
@@ -194,7 +318,7 @@ func splitAndTrim(s string) []string {
 		lastChar = r
 	}
 
-	if i > 0 && lastChar != ' ' && lastChar != '<' && lastChar != '>' && lastChar != '=' {
+	if len(s) > 0 && lastChar != ' ' && lastChar != '<' && lastChar != '>' && lastChar != '=' {
 		count++
 	}
 
@@ -213,7 +337,7 @@ func splitAndTrim(s string) []string {
 		lastChar = r
 	}
 
-	if i > 0 && lastChar != ' ' && lastChar != '<' && lastChar != '>' && lastChar != '=' {
+	if len(s) > 0 && lastChar != ' ' && lastChar != '<' && lastChar != '>' && lastChar != '=' {
 
 		// TODO: use string builder to prevent memory allocations
 		content := strings.ReplaceAll(s[head:i+1], " ", "")
@@ -250,13 +374,13 @@ func splitComparatorVersion(s string) (string, string, error) {
 
 	var i int
 	i = strings.IndexRune(s, '-')
-	if i != -1 && !strings.ContainsAny(s, "^+|><=") {
+	if i != -1 && !strings.ContainsAny(s, "^~!+|><=") {
 		return "-", strings.TrimSpace(s[0:i]), nil
 	}
 
 	i = strings.IndexFunc(s, isDigitOrWildcardDigit)
 	if i == -1 {
-		return "", "", fmt.Errorf("could not get version from string: %q", s)
+		return "", "", fmt.Errorf("could not get version from string: %s", quote(s))
 	}
 	return strings.TrimSpace(s[0:i]), strings.TrimSpace(s[i:]), nil
 }
@@ -312,6 +436,10 @@ func createVersionFromWildcard(vStr string) (versionParts, wildcardType, bool) {
 	isValid := true
 	isDone := false
 
+	if vStr == "" {
+		return parts, _wildcard, false
+	}
+
 	for i, char := range vStr {
 		if isDone {
 			break
@@ -393,11 +521,14 @@ func createVersionFromWildcard(vStr string) (versionParts, wildcardType, bool) {
 		}
 	}
 
-	if isValid {
-		isValid = partI > -1
+	// "1." and "1.1." end without a part after the last dot
+	if partStartI == -1 && partI > 0 && partI < 3 {
+		isValid = false
 	}
 
-	if partStartI == -1 {
+	// "fo.o.b.ar" has no part at all
+	started := partStartI != -1
+	if !started {
 		partStartI = 0
 	}
 
@@ -413,7 +544,7 @@ func createVersionFromWildcard(vStr string) (versionParts, wildcardType, bool) {
 	// So its a wildcard minor
 	case 0:
 		{
-			if _wildcard == noneWildcard {
+			if _wildcard == noneWildcard && started {
 				_wildcard = minorWildcard
 			}
 
@@ -472,29 +603,97 @@ func joinParts(elems versionParts, sep string) string {
 
 }
 
+// partsVersion converts the parts of a wildcard version, as returned by
+// createVersionFromWildcard, into a Version.
+func partsVersion(parts versionParts) (Version, error) {
+	var v Version
+	var err error
+	if v.Major, err = parseVersionPart(parts[0], "major"); err != nil {
+		return Version{}, err
+	}
+	if v.Minor, err = parseVersionPart(parts[1], "minor"); err != nil {
+		return Version{}, err
+	}
+	if v.Patch, err = parseVersionPart(parts[2], "patch"); err != nil {
+		return Version{}, err
+	}
+	if parts[3] != "" {
+		suffix, err := parse("0.0.0" + parts[3])
+		if err != nil {
+			return Version{}, err
+		}
+		v.Pre = suffix.Pre
+		v.Build = suffix.Build
+	}
+	return v, nil
+}
+
+// parseVersionPart parses the numeric version part s of the named
+// component, an empty part is zero.
+func parseVersionPart(s string, component string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if hasLeadingZeroes(s) {
+		return 0, &NumberError{Component: component, Number: s, Err: ErrLeadingZeroes}
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
 // incrementMajorVersion will increment the major version
 // of the passed version
-func incrementMajorVersion(parts versionParts) (string, error) {
-	i, err := strconv.Atoi(parts[0])
-	if err != nil {
-		return "", err
+func incrementMajorVersion(v Version) (Version, error) {
+	if v.Major == math.MaxUint64 {
+		return Version{}, &OverflowError{Component: "major"}
 	}
-	parts[0] = strconv.Itoa(i + 1)
+	v.Major++
+	return v, nil
+}
 
-	return joinParts(parts, "."), nil
+// incrementMinorVersion will increment the minor version
+// of the passed version
+func incrementMinorVersion(v Version) (Version, error) {
+	if v.Minor == math.MaxUint64 {
+		return Version{}, &OverflowError{Component: "minor"}
+	}
+	v.Minor++
+	return v, nil
 }
 
-// incrementMajorVersion will increment the minor version
+// incrementPatchVersion will increment the patch version
 // of the passed version
-func incrementMinorVersion(parts versionParts) (string, error) {
-	i, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return "", err
+func incrementPatchVersion(v Version) (Version, error) {
+	if v.Patch == math.MaxUint64 {
+		return Version{}, &OverflowError{Component: "patch"}
+	}
+	v.Patch++
+	return v, nil
+}
+
+// caretUpperBound returns the exclusive upper bound of the caret range of v
+// like npm, of which precision numbers are given: the next version changing
+// the left-most non-zero given number. "^1.2.3" is below 2.0.0, "^0.2.3"
+// below 0.3.0 and "^0.0.3" below 0.0.4, while "^0.0" and "^0.0.x" are below
+// 0.1.0 and "^0" below 1.0.0.
+func caretUpperBound(v Version, precision int) (Version, error) {
+	switch {
+	case v.Major > 0 || precision == 1:
+		return incrementMajorVersion(Version{Major: v.Major})
+	case v.Minor > 0 || precision == 2:
+		return incrementMinorVersion(Version{Minor: v.Minor})
 	}
-	parts[1] = strconv.Itoa(i + 1)
-	// parts[2] = "0"
+	return incrementPatchVersion(Version{Patch: v.Patch})
+}
 
-	return joinParts(parts, "."), nil
+// tildeUpperBound returns the exclusive upper bound of the tilde range of v
+// like npm, of which precision numbers are given: the next minor version if
+// the minor version is given, "~1.2.3" and "~1.2" are below 1.3.0, the next
+// major version otherwise, "~1" is below 2.0.0.
+func tildeUpperBound(v Version, precision int) (Version, error) {
+	if precision == 1 {
+		return incrementMajorVersion(Version{Major: v.Major})
+	}
+	return incrementMinorVersion(Version{Major: v.Major, Minor: v.Minor})
 }
 
 // expandWildcardVersion will expand wildcards inside versions
@@ -508,97 +707,129 @@ func incrementMinorVersion(parts versionParts) (string, error) {
 // != 1.2.x    will become    <  1.2.0 >= 1.3.0
 //
 // * when dealing with minor wildcards:
-// ~> 1.x      will become    >= 1.0.0
-// ^  1.x      will become    >= 1.0.0 <= 2.0.0
+// ^  1.x      will become    >= 1.0.0 <  2.0.0
 // >= 1.x      will become    >= 1.0.0
 // <= 1.x      will become    <  2.0.0
 // >  1.x      will become    >= 2.0.0
 // <  1.0      will become    <  1.0.0
 // != 1.x      will become    <  1.0.0 >= 2.0.0
 //
+// * when dealing with major wildcards:
+// >= *, <= *  will become    >= 0.0.0
+// >  *, != *  will become    <  0.0.0-0
+// <  *        will become    <  0.0.0-0
+//
 // * when dealing with wildcards without
 // version operator:
 // 1.2.x       will become    >= 1.2.0 < 1.3.0
 // 1.x         will become    >= 1.0.0 < 2.0.0
 // 1.*         will become    >= 1.0.0 < 2.0.0
-func expandWildcardVersion(parts [][]string) ([][]string, error) {
+//
+// * caret ranges allow changes right of the left-most non-zero number:
+// ^1.2.3      will become    >= 1.2.3 < 2.0.0
+// ^0.2.3      will become    >= 0.2.3 < 0.3.0
+// ^0.0.3      will become    >= 0.0.3 < 0.0.4
+// ^0.0.x      will become    >= 0.0.0 < 0.1.0
+// ^*          will become    >= 0.0.0
+//
+// * tilde ranges allow patch level changes if the minor version is given,
+// "~>" is the same as "~" like in node-semver:
+// ~1.2.3      will become    >= 1.2.3 < 1.3.0
+// ~1.2        will become    >= 1.2.0 < 1.3.0
+// ~1          will become    >= 1.0.0 < 2.0.0
+// ~*          will become    >= 0.0.0
+//
+// Versions without wildcards are left unchanged for plain comparison
+// operators. All version arithmetic is done on the numeric components and
+// fails with an *OverflowError instead of wrapping around.
+//
+// If npm is set, see RangeOptions.NPMCompat, the exclusive upper bounds created by the expansion get the prerelease "0",
+// e.g. ^1.2.3 becomes >= 1.2.3 < 2.0.0-0, so that they exclude the
+// prereleases of the bound. Upper bounds written as plain comparators are
+// left unchanged.
+func expandWildcardVersion(parts [][]string, npm bool) ([][]string, error) {
+	upperBound := func(v Version) string {
+		if npm && len(v.Pre) == 0 {
+			return "<" + v.String() + "-0"
+		}
+		return "<" + v.String()
+	}
+
 	var expandedParts [][]string
 
 	for _, p := range parts {
 		var newParts []string
 		for _, ap := range p {
-			if strings.ContainsAny(ap, "x^~*-") {
+			if from, to, ok := splitHyphenRange(ap); ok {
+				bounds, err := expandHyphenRange(from, to, upperBound)
+				if err != nil {
+					return nil, err
+				}
+				newParts = append(newParts, bounds...)
+			} else if strings.ContainsAny(ap, "x^~*-") {
 				opStr, vStr, err := splitComparatorVersion(ap)
 				if err != nil {
 					return nil, err
 				}
 
-				var cachedParts = versionParts{"", "", "", ""}
 				defaultParts, versionWildcardType, _ := createVersionFromWildcard(vStr)
+				v, err := partsVersion(defaultParts)
+				if err != nil {
+					return nil, err
+				}
+
+				if versionWildcardType == noneWildcard {
+					if _, ok := parseOperator(opStr); ok {
+						newParts = append(newParts, opStr+v.String())
+						continue
+					}
+				}
+
 				var resultOperator string = ""
 				var shouldIncrementVersion bool = false
 
+				if opStr == "~>" {
+					opStr = "~"
+				}
 				switch opStr {
 				case "-":
 					{
 						resultOperator = ">="
 						secondaryParts, _, _ := createVersionFromWildcard(strings.TrimSpace(ap[strings.IndexRune(ap, '-')+1:]))
-						newParts = append(newParts, "<"+joinParts(secondaryParts, "."))
+						upper, err := partsVersion(secondaryParts)
+						if err != nil {
+							return nil, err
+						}
+						newParts = append(newParts, upperBound(upper))
 					}
 				case "^":
 					{
 						resultOperator = ">="
-						major, _ := strconv.Atoi(defaultParts[0])
-						newParts = append(newParts, "<"+strconv.Itoa(major+1)+".0.0")
+						if n := versionPrecision(vStr); n > 0 {
+							upper, err := caretUpperBound(v, n)
+							if err != nil {
+								return nil, err
+							}
+							newParts = append(newParts, upperBound(upper))
+						}
 					}
 				case "~":
 					{
-						switch versionWildcardType {
-
-						// This input doesn't make sense. But, its the internet.
+						resultOperator = ">="
+						// "~*" doesn't make sense. But, its the internet.
 						// People do things that don't make sense.
-						// ~*
-						case majorWildcard:
-							{
-								resultOperator = ">="
-								defaultParts[0] = "0"
-								defaultParts[1] = "0"
-								defaultParts[2] = "0"
-							}
-
-						case noneWildcard, patchWildcard:
-							{
-								resultOperator = ">="
-								cachedParts[0] = defaultParts[0]
-								cachedParts[2] = "0"
-
-								patch, _ := strconv.Atoi(defaultParts[1])
-
-								cachedParts[1] = strconv.Itoa(patch + 1)
-
-								newParts = append(newParts, "<"+joinParts(cachedParts, "."))
-							}
-
-						case minorWildcard:
-							{
-								resultOperator = ">="
-								cachedParts[1] = "0"
-								cachedParts[2] = "0"
-
-								patch, _ := strconv.Atoi(defaultParts[0])
-
-								cachedParts[0] = strconv.Itoa(patch + 1)
-
-								newParts = append(newParts, "<"+joinParts(cachedParts, "."))
+						if n := versionPrecision(vStr); n > 0 {
+							upper, err := tildeUpperBound(v, n)
+							if err != nil {
+								return nil, err
 							}
-
+							newParts = append(newParts, upperBound(upper))
 						}
-
 					}
 				case ">":
 					resultOperator = ">="
 					shouldIncrementVersion = true
-				case "~>", ">=":
+				case ">=":
 					resultOperator = ">="
 				case "<":
 					resultOperator = "<"
@@ -606,28 +837,50 @@ func expandWildcardVersion(parts [][]string) ([][]string, error) {
 					resultOperator = "<"
 					shouldIncrementVersion = true
 				case "", "=", "==":
-					newParts = append(newParts, ">="+joinParts(defaultParts, "."))
+					if versionWildcardType != majorWildcard {
+						newParts = append(newParts, ">="+v.String())
+					}
 					resultOperator = "<"
 					shouldIncrementVersion = true
 				case "!=", "!":
-					newParts = append(newParts, "<"+joinParts(defaultParts, "."))
+					if versionWildcardType != majorWildcard {
+						newParts = append(newParts, "<"+v.String())
+					}
 					resultOperator = ">="
 					shouldIncrementVersion = true
 				}
 
-				var resultVersion string
+				result := v
 				if shouldIncrementVersion {
 					switch versionWildcardType {
 					case patchWildcard:
-						resultVersion, _ = incrementMinorVersion(defaultParts)
+						result, err = incrementMinorVersion(v)
 					case minorWildcard:
-						resultVersion, _ = incrementMajorVersion(defaultParts)
+						result, err = incrementMajorVersion(v)
+					case majorWildcard:
+						// Nothing is above *, so the operator is flipped
+						// instead: <=* matches all versions, >* and !=*
+						// none.
+						if resultOperator == "<" {
+							resultOperator = ">="
+						} else {
+							resultOperator = "<"
+						}
+					}
+					if err != nil {
+						return nil, err
 					}
-				} else {
-					resultVersion = joinParts(defaultParts, ".")
 				}
 
-				newParts = append(newParts, resultOperator+resultVersion)
+				if resultOperator == "<" && versionWildcardType == majorWildcard {
+					// No version is below *, not even a prerelease of
+					// 0.0.0, like the empty range of Simplify
+					newParts = append(newParts, "<0.0.0-0")
+				} else if resultOperator == "<" && versionWildcardType != noneWildcard {
+					newParts = append(newParts, upperBound(result))
+				} else {
+					newParts = append(newParts, resultOperator+result.String())
+				}
 				// Handle "0", "1", "2", "3", "4", "5", "6", "7", "8", "9"
 			} else if isNumbersOrSpacesOnly(ap) {
 				defaultParts, _, _ := createVersionFromWildcard(ap)
@@ -643,6 +896,76 @@ func expandWildcardVersion(parts [][]string) ([][]string, error) {
 	return expandedParts, nil
 }
 
+// splitHyphenRange splits the hyphen range "A - B", as returned by
+// scanClause, into its bounds. The spaces around the '-' tell it apart from
+// the '-' of a prerelease.
+func splitHyphenRange(s string) (from, to string, ok bool) {
+	i := strings.Index(s, " - ")
+	if i < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+3:]), true
+}
+
+// expandHyphenRange expands the hyphen range from - to like npm:
+//
+// 1.2.3 - 2.3.4         will become    >= 1.2.3 <= 2.3.4
+// 1.2.3-beta - 2.3.4    will become    >= 1.2.3-beta <= 2.3.4
+// 1.2 - 2.3             will become    >= 1.2.0 < 2.4.0
+// 1.2.3 - 2             will become    >= 1.2.3 < 3.0.0
+// * - 2.3.4             will become    <= 2.3.4
+//
+// upperBound writes the exclusive upper bound of a partial to.
+func expandHyphenRange(from, to string, upperBound func(Version) string) ([]string, error) {
+	var bounds []string
+	if versionPrecision(from) > 0 {
+		fromParts, _, _ := createVersionFromWildcard(from)
+		lower, err := partsVersion(fromParts)
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, ">="+lower.String())
+	}
+	if n := versionPrecision(to); n > 0 {
+		toParts, _, _ := createVersionFromWildcard(to)
+		upper, err := partsVersion(toParts)
+		if err != nil {
+			return nil, err
+		}
+		switch n {
+		case 1:
+			upper, err = incrementMajorVersion(Version{Major: upper.Major})
+		case 2:
+			upper, err = incrementMinorVersion(Version{Major: upper.Major, Minor: upper.Minor})
+		default:
+			bounds = append(bounds, "<="+upper.String())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n < 3 {
+			bounds = append(bounds, upperBound(upper))
+		}
+	}
+	if len(bounds) == 0 {
+		bounds = append(bounds, ">=0.0.0")
+	}
+	return bounds, nil
+}
+
+// versionPrecision returns the number of leading numbers of the partial
+// version s, "2.3.x" has two and "*" none.
+func versionPrecision(s string) int {
+	n := 0
+	for _, p := range strings.SplitN(s, ".", 3) {
+		if p == "" || !isDigit(p[0]) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
 func isNumbersOrSpacesOnly(ap string) bool {
 	for _, r := range ap {
 		if !(r == ' ' || (r >= '0' && r <= '9')) {
@@ -654,35 +977,96 @@ func isNumbersOrSpacesOnly(ap string) bool {
 }
 
 func parseComparator(s string) comparator {
+	op, ok := parseOperator(s)
+	if !ok {
+		return nil
+	}
+	return op.comparator()
+}
+
+// Operator is the comparison of a comparator in a range, e.g. the ">=" of
+// ">=1.2.3". Tilde, caret, wildcard and hyphen ranges are expanded to these
+// comparisons when parsing.
+type Operator int
+
+const (
+	OpEQ  Operator = iota // "=", the exact version
+	OpNE                  // "!=", every version but the exact one
+	OpGT                  // ">"
+	OpGTE                 // ">="
+	OpLT                  // "<"
+	OpLTE                 // "<="
+)
+
+// ParseOperator parses the notation of a comparison operator as accepted in
+// ranges: "=", "!=", ">", ">=", "<" or "<=", and the aliases "==" and "!".
+func ParseOperator(s string) (Operator, error) {
+	if op, ok := parseOperator(s); ok && s != "" {
+		return op, nil
+	}
+	return 0, fmt.Errorf("invalid operator %s", quote(s))
+}
+
+// parseOperator parses the operator of a comparator, an empty operator of a
+// bare version is OpEQ.
+func parseOperator(s string) (Operator, bool) {
 	switch s {
-	case "==":
-		fallthrough
-	case "":
-		fallthrough
-	case "=":
-		return compEQ
+	case "", "=", "==":
+		return OpEQ, true
+	case "!", "!=":
+		return OpNE, true
 	case ">":
-		return compGT
+		return OpGT, true
 	case ">=":
-		return compGE
+		return OpGTE, true
 	case "<":
-		return compLT
+		return OpLT, true
 	case "<=":
-		return compLE
-	case "!":
-		fallthrough
-	case "!=":
+		return OpLTE, true
+	}
+	return 0, false
+}
+
+func (op Operator) comparator() comparator {
+	switch op {
+	case OpNE:
 		return compNE
+	case OpGT:
+		return compGT
+	case OpGTE:
+		return compGE
+	case OpLT:
+		return compLT
+	case OpLTE:
+		return compLE
 	}
+	return compEQ
+}
 
-	return nil
+// String returns the canonical notation of op, e.g. ">=".
+func (op Operator) String() string {
+	switch op {
+	case OpEQ:
+		return "="
+	case OpNE:
+		return "!="
+	case OpGT:
+		return ">"
+	case OpGTE:
+		return ">="
+	case OpLT:
+		return "<"
+	case OpLTE:
+		return "<="
+	}
+	return fmt.Sprintf("Operator(%d)", int(op))
 }
 
 // MustParseRange is like ParseRange but panics if the range cannot be parsed.
 func MustParseRange(s string) Range {
 	r, err := ParseRange(s)
 	if err != nil {
-		panic(`semver: ParseRange(` + s + `): ` + err.Error())
+		panic(`semver: ParseRange(` + truncate(s, maxErrorInputLength) + `): ` + err.Error())
 	}
 	return r
 }
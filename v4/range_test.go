@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -84,6 +85,7 @@ func TestSplitAndTrim(t *testing.T) {
 		{"  >=   1.2.3   <=  1.2.3   ", []string{">=1.2.3", "<=1.2.3"}}, // Spaces between operator and version
 		{"1.2.3 || >=1.2.3 <1.2.3", []string{"1.2.3", "||", ">=1.2.3", "<1.2.3"}},
 		{"      1.2.3      ||     >=1.2.3     <1.2.3    ", []string{"1.2.3", "||", ">=1.2.3", "<1.2.3"}},
+		{"*", []string{"*"}},
 	}
 
 	for _, tc := range tests {
@@ -236,33 +238,18 @@ func TestIncrementMajorVersion(t *testing.T) {
 		s string
 	}{
 		{"1.2.3", "2.2.3"},
-		{"1.2", "2.2"},
-		{"foo.bar", ""},
+		{"0.2.0", "1.2.0"},
+		{"18446744073709551615.0.0", ""},
 	}
 
 	for _, tc := range tests {
-		parts := versionParts{"", "", "", ""}
-		segments := strings.Split(tc.i, ".")
-		switch len(segments) {
-		case 1:
-			{
-				parts[0] = segments[0]
-			}
-		case 2:
-			{
-				parts[1] = segments[1]
-				parts[0] = segments[0]
-			}
-		case 3:
-			{
-				parts[1] = segments[1]
-				parts[0] = segments[0]
-				parts[2] = segments[2]
+		p, err := incrementMajorVersion(MustParse(tc.i))
+		if tc.s == "" {
+			if err == nil {
+				t.Errorf("Invalid for case %q: Expected error, got: %q", tc.i, p)
 			}
-		}
-		p, _ := incrementMajorVersion(parts)
-		if p != tc.s {
-			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.s, p)
+		} else if err != nil || p.String() != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.i, tc.s, p, err)
 		}
 	}
 }
@@ -273,33 +260,43 @@ func TestIncrementMinorVersion(t *testing.T) {
 		s string
 	}{
 		{"1.2.3", "1.3.3"},
-		{"1.2", "1.3"},
-		{"foo.bar", ""},
+		{"1.0.0", "1.1.0"},
+		{"1.18446744073709551615.0", ""},
 	}
 
 	for _, tc := range tests {
-		parts := versionParts{"", "", "", ""}
-		segments := strings.Split(tc.i, ".")
-		switch len(segments) {
-		case 1:
-			{
-				parts[0] = segments[0]
-			}
-		case 2:
-			{
-				parts[1] = segments[1]
-				parts[0] = segments[0]
-			}
-		case 3:
-			{
-				parts[1] = segments[1]
-				parts[0] = segments[0]
-				parts[2] = segments[2]
+		p, err := incrementMinorVersion(MustParse(tc.i))
+		if tc.s == "" {
+			if err == nil {
+				t.Errorf("Invalid for case %q: Expected error, got: %q", tc.i, p)
 			}
+		} else if err != nil || p.String() != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.i, tc.s, p, err)
 		}
-		p, _ := incrementMinorVersion(parts)
-		if p != tc.s {
-			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.s, p)
+	}
+}
+
+func TestPartsVersion(t *testing.T) {
+	tests := []struct {
+		i versionParts
+		s string
+	}{
+		{versionParts{"1", "2", "3", ""}, "1.2.3"},
+		{versionParts{"1", "", "", ""}, "1.0.0"},
+		{versionParts{"1", "2", "3", "-beta.1+build"}, "1.2.3-beta.1+build"},
+		{versionParts{"01", "2", "3", ""}, ""},
+		{versionParts{"1", "2", "3", "-01"}, ""},
+		{versionParts{"18446744073709551616", "0", "0", ""}, ""},
+	}
+
+	for _, tc := range tests {
+		v, err := partsVersion(tc.i)
+		if tc.s == "" {
+			if err == nil {
+				t.Errorf("Invalid for case %q: Expected error, got: %q", tc.i, v)
+			}
+		} else if err != nil || v.String() != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.i, tc.s, v, err)
 		}
 	}
 }
@@ -324,29 +321,66 @@ func TestExpandWildcardVersion(t *testing.T) {
 		{[][]string{{"1.x"}}, [][]string{{">=1.0.0", "<2.0.0"}}},
 		{[][]string{{"~1.2.1"}}, [][]string{{"<1.3.0", ">=1.2.1"}}},
 		{[][]string{{"^1.2.1"}}, [][]string{{"<2.0.0", ">=1.2.1"}}},
-		{[][]string{{"~>1.2.x"}}, [][]string{{">=1.2.0"}}},
-		{[][]string{{"~>1.x"}}, [][]string{{">=1.0.0"}}},
+		{[][]string{{"^0.2.3"}}, [][]string{{"<0.3.0", ">=0.2.3"}}},
+		{[][]string{{"^0.0.3"}}, [][]string{{"<0.0.4", ">=0.0.3"}}},
+		{[][]string{{"^0.0.3-beta"}}, [][]string{{"<0.0.4", ">=0.0.3-beta"}}},
+		{[][]string{{"^0.0.0"}}, [][]string{{"<0.0.1", ">=0.0.0"}}},
+		{[][]string{{"^1.2.x"}}, [][]string{{"<2.0.0", ">=1.2.0"}}},
+		{[][]string{{"^0.2.x"}}, [][]string{{"<0.3.0", ">=0.2.0"}}},
+		{[][]string{{"^0.0.x"}}, [][]string{{"<0.1.0", ">=0.0.0"}}},
+		{[][]string{{"^0.0"}}, [][]string{{"<0.1.0", ">=0.0.0"}}},
+		{[][]string{{"^0.x"}}, [][]string{{"<1.0.0", ">=0.0.0"}}},
+		{[][]string{{"^0"}}, [][]string{{"<1.0.0", ">=0.0.0"}}},
+		{[][]string{{"^*"}}, [][]string{{">=0.0.0"}}},
+		{[][]string{{"~>1.2.x"}}, [][]string{{"<1.3.0", ">=1.2.0"}}},
+		{[][]string{{"~>1.x"}}, [][]string{{"<2.0.0", ">=1.0.0"}}},
+		{[][]string{{"~1"}}, [][]string{{"<2.0.0", ">=1.0.0"}}},
+		{[][]string{{"~1.x.x"}}, [][]string{{"<2.0.0", ">=1.0.0"}}},
+		{[][]string{{"~1.2"}}, [][]string{{"<1.3.0", ">=1.2.0"}}},
+		{[][]string{{"~0.0.1"}}, [][]string{{"<0.1.0", ">=0.0.1"}}},
+		{[][]string{{"~*"}}, [][]string{{">=0.0.0"}}},
 		{[][]string{{"1.*"}}, [][]string{{">=1.0.0", "<2.0.0"}}},
 		{[][]string{{"1.2.*"}}, [][]string{{">=1.2.0", "<1.3.0"}}},
 		{[][]string{{"*"}}, [][]string{{">=0.0.0"}}},
-		{[][]string{{"8.0.0 - 10.0.0"}}, [][]string{{"<10.0.0", ">=8.0.0"}}},
-		{[][]string{{"8 - 10"}}, [][]string{{"<10.0.0", ">=8.0.0"}}},
-		{[][]string{{"8 - 10.1"}}, [][]string{{"<10.1.0", ">=8.0.0"}}},
+		{[][]string{{"8.0.0 - 10.0.0"}}, [][]string{{">=8.0.0", "<=10.0.0"}}},
+		{[][]string{{"8 - 10"}}, [][]string{{">=8.0.0", "<11.0.0"}}},
+		{[][]string{{"8 - 10.1"}}, [][]string{{">=8.0.0", "<10.2.0"}}},
+		{[][]string{{"8.0.0-beta.1 - 10.0.0-rc.1"}}, [][]string{{">=8.0.0-beta.1", "<=10.0.0-rc.1"}}},
+		{[][]string{{"* - 10.0.0"}}, [][]string{{"<=10.0.0"}}},
+		{[][]string{{"8.x - *"}}, [][]string{{">=8.0.0"}}},
+		{[][]string{{"* - x"}}, [][]string{{">=0.0.0"}}},
 		{[][]string{{" 8 "}}, [][]string{{"8.0.0"}}},
 		{[][]string{{" 800000 "}}, [][]string{{"800000.0.0"}}},
 		{[][]string{{" ~7.x "}}, [][]string{{"<8.0.0", ">=7.0.0"}}},
 		{[][]string{{" ~7.0.x "}}, [][]string{{"<7.1.0", ">=7.0.0"}}},
+		{[][]string{{">1.2.3-beta"}}, [][]string{{">1.2.3-beta"}}},
+		{[][]string{{"<=1.2.3-beta"}}, [][]string{{"<=1.2.3-beta"}}},
+		{[][]string{{"^1.2.3-beta.2"}}, [][]string{{"<2.0.0", ">=1.2.3-beta.2"}}},
+		{[][]string{{">=*"}}, [][]string{{">=0.0.0"}}},
+		{[][]string{{"<=*"}}, [][]string{{">=0.0.0"}}},
+		{[][]string{{">*"}}, [][]string{{"<0.0.0-0"}}},
+		{[][]string{{"!=*"}}, [][]string{{"<0.0.0-0"}}},
+		{[][]string{{"<*"}}, [][]string{{"<0.0.0-0"}}},
 		// {[][]string{{" ~* "}}, [][]string{{">=0.0.0"}}},
 	}
 
 	for _, tc := range tests {
-		o, _ := expandWildcardVersion(tc.i)
+		o, _ := expandWildcardVersion(tc.i, false)
 		if !reflect.DeepEqual(tc.o, o) {
 			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, o)
 		}
 	}
 }
 
+func TestExpandWildcardVersionOverflow(t *testing.T) {
+	for _, r := range []string{"^18446744073709551615.0.0", "~1.18446744073709551615.0", "18446744073709551615.x", "<=1.18446744073709551615.x"} {
+		_, err := expandWildcardVersion([][]string{{r}}, false)
+		if _, ok := err.(*OverflowError); !ok {
+			t.Errorf("Invalid for case %q: Expected overflow error, got %v", r, err)
+		}
+	}
+}
+
 func TestVersionRangeToRange(t *testing.T) {
 	vr := versionRange{
 		v: MustParse("1.2.3"),
@@ -405,6 +439,59 @@ func TestRangeOR(t *testing.T) {
 	}
 }
 
+func TestRangeString(t *testing.T) {
+	tests := []struct {
+		r Range
+		s string
+	}{
+		{MustParseRange(">=1.2.0 <2.0.0 || >=3.0.0"), ">=1.2.0 <2.0.0 || >=3.0.0"},
+		{MustParseRange("<2.0.0 ^1.2"), ">=1.2.0 <2.0.0 <2.0.0"},
+		{MustParseRange("1.2.3 || !1.2.4-beta.1"), "1.2.3 || !=1.2.4-beta.1"},
+		{MustParseRange("1.x").AND(MustParseRange("!=1.5.0")), ">=1.0.0 <2.0.0 !=1.5.0"},
+		{MustParseRange("1.x").OR(MustParseRange("3.0.0 - 3")), ">=1.0.0 <2.0.0 || >=3.0.0 <4.0.0"},
+		{Range(func(Version) bool { return true }), "<not inspectable>"},
+		{MustParseRange("1.x").XOR(MustParseRange("1.2.x")), "<not inspectable>"},
+	}
+	for _, tc := range tests {
+		if s := tc.r.String(); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.s, s)
+		}
+		if s := fmt.Sprint(tc.r); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.s, tc.s, s)
+		}
+		if tc.s == "<not inspectable>" {
+			continue
+		}
+		if s := MustParseRange(tc.s).String(); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected round trip, got: %q", tc.s, s)
+		}
+	}
+}
+
+func TestRangeStringNPMCompat(t *testing.T) {
+	opts := RangeOptions{NPMCompat: true}
+	r, err := ParseRangeWithOptions("^1.2.3", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := r.String()
+	pre := MustParse("1.5.0-beta.1")
+
+	// the prerelease rule is lost without the options
+	if back := MustParseRange(s); !back(pre) || r(pre) {
+		t.Errorf("Invalid for case %q: Expected %q to match only without NPMCompat", s, pre)
+	}
+	back, err := ParseRangeWithOptions(s, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"1.2.3", "1.5.0-beta.1", "2.0.0-rc.1", "2.0.0"} {
+		if back(MustParse(v)) != r(MustParse(v)) {
+			t.Errorf("Invalid for case %q: Expected the same result for %q", s, v)
+		}
+	}
+}
+
 func TestParseRange(t *testing.T) {
 	type tv struct {
 		v string
@@ -574,6 +661,20 @@ func TestParseRange(t *testing.T) {
 			{"5.2.0", true},
 			{"6.0.0", false},
 		}},
+		// Nothing is above *, not even prereleases of 0.0.0
+		{">*", []tv{
+			{"0.0.0-alpha", false},
+			{"0.0.0", false},
+			{"1.0.0", false},
+		}},
+		{"!=*", []tv{
+			{"0.0.0-alpha", false},
+			{"1.0.0", false},
+		}},
+		{"<*", []tv{
+			{"0.0.0-alpha", false},
+			{"0.0.0", false},
+		}},
 	}
 
 	for _, tc := range tests {
@@ -641,6 +742,205 @@ func TestParseRangeExotic(t *testing.T) {
 	}
 }
 
+func TestParseRangeTrailingGarbage(t *testing.T) {
+	tests := []struct {
+		i      string
+		offset int
+	}{
+		{">=1.2.3garbage", 7},
+		{"1.2.3 extra", 6},
+		{"1.2.3xyz", 5},
+		{"~1.2.3abc", 6},
+		{"^1.2.3junk", 6},
+		{"1.2.xjunk", 5},
+		{"1.2.3.4", 5},
+		{"1.x.3", 4},
+		{"1x", 1},
+		{"1.", 2},
+		{"~1.2.", 5},
+		{">=1.2.3 <", 9},
+		{"1.2.3-", 6},
+		{"1.2.3 - ", 8},
+		{"1.2.3 || ", 6},
+		{"", 0},
+	}
+
+	for _, tc := range tests {
+		_, err := ParseRange(tc.i)
+		serr, ok := err.(*RangeSyntaxError)
+		if !ok {
+			t.Errorf("Invalid for case %q: Expected *RangeSyntaxError, got: %v", tc.i, err)
+			continue
+		}
+		if serr.Offset != tc.offset {
+			t.Errorf("Invalid for case %q: Expected offset %d, got: %d (%s)", tc.i, tc.offset, serr.Offset, serr)
+		}
+	}
+}
+
+func TestParseRangeTolerant(t *testing.T) {
+	tests := []struct {
+		i string
+		o string
+	}{
+		{"~1.2.3abc", ">=1.2.3 <1.3.0"},
+		{"1.2.3.4", "1.2.3"},
+		{"1.2.xjunk", ">=1.2.0 <1.3.0"},
+		{"*", ">=0.0.0"},
+		{"1", "1.0.0"},
+	}
+
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.i, RangeOptions{Tolerant: true})
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if c.String() != tc.o {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.o, c)
+		}
+	}
+	if _, err := ParseRangeWithOptions("1.", RangeOptions{Tolerant: true}); err == nil {
+		t.Errorf("Expected error for dangling dot in tolerant mode")
+	}
+}
+
+func TestParseRangeNPMCompatPrerelease(t *testing.T) {
+	tests := []struct {
+		r    string
+		v    string
+		npm  bool
+		dflt bool
+	}{
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true, true},
+		{"^1.2.3-beta.2", "1.2.4-beta.1", false, true},
+		{"^1.2.3-beta.2", "1.2.3-beta.1", false, false},
+		{"^1.2.3-beta.2", "1.2.3", true, true},
+		{"^1.2.3-beta.2", "1.9.0", true, true},
+		{"^1.2.3-beta.2", "2.0.0-beta.1", false, true},
+		{">=1.0.0 <2.0.0", "1.5.0-alpha", false, true},
+		{"~1.2.3-alpha", "1.2.3-rc.1", true, true},
+		{"~1.2.3-alpha", "1.2.4-rc.1", false, true},
+		{"1.2.3-alpha || >=2.0.0", "1.2.3-alpha", true, true},
+		{"1.2.3-alpha || >=2.0.0", "2.1.0-alpha", false, true},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		npm, err := ParseRangeWithOptions(tc.r, RangeOptions{NPMCompat: true})
+		if err != nil {
+			t.Fatalf("Invalid for case %q: %s", tc.r, err)
+		}
+		if res := npm(v); res != tc.npm {
+			t.Errorf("Invalid for case %q matching %q in npm compat mode: Expected %t, got: %t", tc.r, tc.v, tc.npm, res)
+		}
+		if res := MustParseRange(tc.r)(v); res != tc.dflt {
+			t.Errorf("Invalid for case %q matching %q: Expected %t, got: %t", tc.r, tc.v, tc.dflt, res)
+		}
+	}
+}
+
+func TestParseRangePrereleaseUpperBounds(t *testing.T) {
+	tests := []struct {
+		r       string
+		v       string
+		dflt    bool
+		npm     bool
+		include bool
+	}{
+		{"<2.0.0", "2.0.0-rc.1", true, false, true},
+		{"<2.0.0", "1.5.0-rc.1", true, false, true},
+		{"^1.2.3", "2.0.0-rc.1", true, false, false},
+		{"^1.2.3", "1.5.0-beta.1", true, false, true},
+		{"~1.2.3", "1.3.0-rc.1", true, false, false},
+		{"1.x", "2.0.0-rc.1", true, false, false},
+		{"<1.2.x", "1.2.0-rc.1", true, false, false},
+		{"1.0.0 - 2", "2.0.0-rc.1", true, false, true},
+		{"1.0.0 - 1", "2.0.0-rc.1", true, false, false},
+		{"^1.2.3 <2.0.0-rc.2", "2.0.0-rc.1", true, false, false},
+		{">=1.2.3-0", "1.2.3-beta.1", true, true, true},
+		{">=1.2.3-0", "1.2.4-beta.1", true, false, true},
+		{">=1.2.3", "1.2.3-beta.1", false, false, false},
+		{">=1.2.3-0 <1.2.3", "1.2.3-beta.1", true, true, true},
+		{">=1.2.3-0 <1.2.3", "1.2.3", false, false, false},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		for _, mode := range []struct {
+			name string
+			opts RangeOptions
+			want bool
+		}{
+			{"default", RangeOptions{}, tc.dflt},
+			{"npm", RangeOptions{NPMCompat: true}, tc.npm},
+			{"npm includePrerelease", RangeOptions{NPMCompat: true, IncludePrerelease: true}, tc.include},
+		} {
+			r, err := ParseRangeWithOptions(tc.r, mode.opts)
+			if err != nil {
+				t.Fatalf("Invalid for case %q: %s", tc.r, err)
+			}
+			if res := r(v); res != mode.want {
+				t.Errorf("Invalid for case %q matching %q in %s mode: Expected %t, got: %t", tc.r, tc.v, mode.name, mode.want, res)
+			}
+		}
+	}
+
+	if r, _ := ParseRangeWithOptions("1.x", RangeOptions{IncludePrerelease: true}); !r(MustParse("2.0.0-rc.1")) {
+		t.Errorf("IncludePrerelease must have no effect without NPMCompat")
+	}
+}
+
+func TestExpandRangeNPMCompat(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{"^1.2.3", ">=1.2.3 <2.0.0-0"},
+		{"^0.2.3", ">=0.2.3 <0.3.0-0"},
+		{"^0.0.3", ">=0.0.3 <0.0.4-0"},
+		{"^0.0", ">=0.0.0 <0.1.0-0"},
+		{"~1.2", ">=1.2.0 <1.3.0-0"},
+		{"1.x", ">=1.0.0 <2.0.0-0"},
+		{"<=1.2.x", "<1.3.0-0"},
+		{"<2.0.0", "<2.0.0"},
+		{"1.0.0 - 2.0.0-beta", ">=1.0.0 <=2.0.0-beta"},
+		{"1.0.0 - 2.0", ">=1.0.0 <2.1.0-0"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.r, RangeOptions{NPMCompat: true})
+		if err != nil {
+			t.Fatalf("Invalid for case %q: %s", tc.r, err)
+		}
+		if c.String() != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, c)
+		}
+	}
+}
+
+func TestRangeEvaluationAllocs(t *testing.T) {
+	ranges := []string{"1.2.3", "^1.2.3 || ~2.0.0-beta.1", "!=1.5.0 >=1.0.0 <2.0.0", "1.x || >=3.0.0-rc.1 <4"}
+	versions := []Version{MustParse("1.2.3"), MustParse("1.5.0-beta.2+build.1"), MustParse("3.0.0-rc.2")}
+	for _, s := range ranges {
+		r := MustParseRange(s)
+		npm, _ := ParseRangeWithOptions(s, RangeOptions{NPMCompat: true})
+		combined := r.AND(npm).OR(MustParseRange(">=5.0.0"))
+		for _, v := range versions {
+			n := testing.AllocsPerRun(100, func() {
+				r(v)
+				npm(v)
+				combined(v)
+			})
+			if n != 0 {
+				t.Errorf("Invalid for case %q matching %q: Expected no allocations, got: %v", s, v, n)
+			}
+		}
+	}
+
+	SetMetricsSink(&countingSink{})
+	defer SetMetricsSink(nil)
+	r, v := MustParseRange("^1.2.3"), MustParse("1.5.0")
+	if n := testing.AllocsPerRun(100, func() { r(v) }); n != 0 {
+		t.Errorf("Expected no allocations with a metrics sink, got: %v", n)
+	}
+}
+
 func TestMustParseRange(t *testing.T) {
 	testCase := ">1.2.2 <1.2.4 || >=2.0.0 <3.0.0"
 	r := MustParseRange(testCase)
@@ -728,3 +1028,123 @@ func BenchmarkRangeMatchNPM(b *testing.B) {
 		r(v)
 	}
 }
+
+func TestParseOperator(t *testing.T) {
+	tests := []struct {
+		s  string
+		op Operator
+	}{
+		{"=", OpEQ},
+		{"==", OpEQ},
+		{"!=", OpNE},
+		{"!", OpNE},
+		{">", OpGT},
+		{">=", OpGTE},
+		{"<", OpLT},
+		{"<=", OpLTE},
+	}
+	for _, tc := range tests {
+		op, err := ParseOperator(tc.s)
+		if err != nil || op != tc.op {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s (%v)", tc.s, tc.op, op, err)
+		}
+	}
+	for _, s := range []string{"", "~", "^", "=>", ">=="} {
+		if op, err := ParseOperator(s); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error, got: %s", s, op)
+		}
+	}
+	for op := OpEQ; op <= OpLTE; op++ {
+		if parsed, err := ParseOperator(op.String()); err != nil || parsed != op {
+			t.Errorf("Invalid for case %q: Expected %s, got: %s (%v)", op.String(), op, parsed, err)
+		}
+	}
+	if s := Operator(42).String(); s != "Operator(42)" {
+		t.Errorf("Expected %q, got: %q", "Operator(42)", s)
+	}
+}
+
+func TestParseRangeHyphen(t *testing.T) {
+	tests := []struct {
+		r     string
+		match string
+		miss  string
+	}{
+		{"1.2.3 - 2.3.4", "1.2.3 2.3.4 2.3.4-rc.1", "1.2.2 2.3.5"},
+		{"1.2.3-alpha.1 - 2.0.0", "1.2.3-alpha.1 1.2.3-beta 2.0.0", "1.2.3-alpha.0 2.0.1"},
+		{"1.2.3 - 2.0.0-beta.2", "2.0.0-beta.2 2.0.0-alpha", "2.0.0-beta.3 2.0.0"},
+		{"1.2 - 2.3", "1.2.0 2.3.9", "1.1.9 2.4.0"},
+		{"1.2.3 - 2", "2.9.9", "3.0.0"},
+		{"* - 2.0.0", "0.0.0 2.0.0", "2.0.1"},
+		{"1.2.3-1 -2.0.0-1", "1.2.3-1 2.0.0-1", "1.2.3-0 2.0.0-2"},
+	}
+	for _, tc := range tests {
+		r, err := ParseRange(tc.r)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.r, err)
+			continue
+		}
+		for _, v := range strings.Fields(tc.match) {
+			if !r(MustParse(v)) {
+				t.Errorf("Invalid for case %q: Expected %q to match", tc.r, v)
+			}
+		}
+		for _, v := range strings.Fields(tc.miss) {
+			if r(MustParse(v)) {
+				t.Errorf("Invalid for case %q: Expected %q not to match", tc.r, v)
+			}
+		}
+	}
+}
+
+// nodeSemverTildeFixtures are the tilde ranges of node-semver's
+// test/fixtures/range-parse.js and their expansion.
+var nodeSemverTildeFixtures = []struct {
+	r        string
+	expanded string
+}{
+	{"~1.2.3", ">=1.2.3 <1.3.0-0"},
+	{"~1.2", ">=1.2.0 <1.3.0-0"},
+	{"~1", ">=1.0.0 <2.0.0-0"},
+	{"~>1", ">=1.0.0 <2.0.0-0"},
+	{"~> 1", ">=1.0.0 <2.0.0-0"},
+	{"~1.0", ">=1.0.0 <1.1.0-0"},
+	{"~ 1.0", ">=1.0.0 <1.1.0-0"},
+	{"~ 1.0.3", ">=1.0.3 <1.1.0-0"},
+	{"~> 1.0.3", ">=1.0.3 <1.1.0-0"},
+	{"~1.x", ">=1.0.0 <2.0.0-0"},
+	{"~1.2.x", ">=1.2.0 <1.3.0-0"},
+	{"~2.4", ">=2.4.0 <2.5.0-0"},
+	{"~>3.2.1", ">=3.2.1 <3.3.0-0"},
+	{"~>1.2", ">=1.2.0 <1.3.0-0"},
+	{"~>1.2.x", ">=1.2.0 <1.3.0-0"},
+	{"~>0.0.1", ">=0.0.1 <0.1.0-0"},
+	{"~0.0.1", ">=0.0.1 <0.1.0-0"},
+	{"~0", ">=0.0.0 <1.0.0-0"},
+	{"~0.x", ">=0.0.0 <1.0.0-0"},
+	{"~1.2.3-beta.2", ">=1.2.3-beta.2 <1.3.0-0"},
+	{"~*", ">=0.0.0"},
+	{"~x", ">=0.0.0"},
+}
+
+func TestTildeNodeSemverParity(t *testing.T) {
+	for _, tc := range nodeSemverTildeFixtures {
+		c, err := ParseConstraintsWithOptions(tc.r, RangeOptions{NPMCompat: true})
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+			continue
+		}
+		if s := c.String(); s != tc.expanded {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.expanded, s)
+		}
+
+		// without NPMCompat the upper bounds are plain comparators
+		expanded := strings.ReplaceAll(tc.expanded, "-0", "")
+		c, err = ParseConstraints(tc.r)
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.r, err)
+		} else if s := c.String(); s != expanded {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, expanded, s)
+		}
+	}
+}
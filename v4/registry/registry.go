@@ -0,0 +1,61 @@
+// Package registry implements semver.VersionSource for package registries
+// reached over HTTP.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+// HTTPSource is a semver.VersionSource backed by an npm compatible registry,
+// which serves a JSON document with a "versions" object keyed by version
+// string at BaseURL/name.
+type HTTPSource struct {
+	// BaseURL is the registry URL, e.g. "https://registry.npmjs.org".
+	BaseURL string
+	// Client is used for requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Versions implements the semver.VersionSource interface. Published versions
+// which are not valid semver are skipped.
+func (s *HTTPSource) Versions(ctx context.Context, name string) ([]semver.Version, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(s.BaseURL, "/")+"/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for package %q", resp.Status, name)
+	}
+
+	var doc struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("could not decode registry document for package %q: %s", name, err)
+	}
+	versions := make([]semver.Version, 0, len(doc.Versions))
+	for s := range doc.Versions {
+		if v, err := semver.Parse(s); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	semver.Sort(versions)
+	return versions, nil
+}
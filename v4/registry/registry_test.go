@@ -0,0 +1,56 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jarred-Sumner/semver/v4"
+)
+
+func TestHTTPSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/foo":
+			w.Write([]byte(`{"name": "foo", "versions": {"1.0.0": {}, "2.0.0-beta.1": {}, "1.1.0": {}, "not-semver": {}}}`))
+		case "/@scope%2Fbar":
+			w.Write([]byte(`{"versions": {"0.1.0": {}}}`))
+		case "/broken":
+			w.Write([]byte(`{`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	src := &HTTPSource{BaseURL: srv.URL + "/"}
+	versions, err := src.Versions(context.Background(), "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"1.0.0", "1.1.0", "2.0.0-beta.1"}
+	if len(versions) != len(expected) {
+		t.Fatalf("Expected %d versions, got %q", len(expected), versions)
+	}
+	for i, v := range versions {
+		if v.String() != expected[i] {
+			t.Errorf("Expected %q at %d, got %q", expected[i], i, v)
+		}
+	}
+
+	if versions, err := src.Versions(context.Background(), "@scope/bar"); err != nil || len(versions) != 1 {
+		t.Errorf("Expected scoped package to resolve, got %q, %v", versions, err)
+	}
+	if _, err := src.Versions(context.Background(), "missing"); err == nil {
+		t.Error("Expected error for missing package")
+	}
+	if _, err := src.Versions(context.Background(), "broken"); err == nil {
+		t.Error("Expected error for malformed document")
+	}
+
+	v, err := semver.ResolveFrom(context.Background(), src, "foo", semver.MustParseRange("<1.2.0"))
+	if err != nil || !v.EQ(semver.MustParse("1.1.0")) {
+		t.Errorf("Expected 1.1.0, got %q, %v", v, err)
+	}
+}
@@ -0,0 +1,65 @@
+package semver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Fetch queries src for the versions of each of the given packages. It is
+// the only stage of a resolution performing I/O, its result is the input of
+// the pure Resolve.
+func Fetch(ctx context.Context, src VersionSource, names []string) (map[string][]Version, error) {
+	available := make(map[string][]Version, len(names))
+	for _, name := range names {
+		if _, ok := available[name]; ok {
+			continue
+		}
+		versions, err := src.Versions(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		available[name] = versions
+	}
+	return available, nil
+}
+
+// Resolve picks the highest available version satisfying the constraint of
+// each package. Resolve is pure: it depends only on its inputs, which makes
+// resolvers built on it deterministic and easy to unit test. Packages are
+// resolved in name order, so the returned error is deterministic as well.
+func Resolve(available map[string][]Version, constraints map[string]Range) (map[string]Version, error) {
+	names := sortedNames(constraints)
+	decisions := make(map[string]Version, len(names))
+	for _, name := range names {
+		v, err := resolvePackage(available, constraints, name)
+		if err != nil {
+			return nil, err
+		}
+		decisions[name] = v
+	}
+	return decisions, nil
+}
+
+func sortedNames(constraints map[string]Range) []string {
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolvePackage picks the highest available version of the package name
+// satisfying its constraint.
+func resolvePackage(available map[string][]Version, constraints map[string]Range, name string) (Version, error) {
+	versions, ok := available[name]
+	if !ok {
+		return Version{}, fmt.Errorf("no versions available for package %q", name)
+	}
+	v, ok := MaxSatisfying(versions, constraints[name])
+	if !ok {
+		return Version{}, fmt.Errorf("package %q: %w", name, ErrNoSatisfyingVersion)
+	}
+	return v, nil
+}
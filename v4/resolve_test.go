@@ -0,0 +1,73 @@
+package semver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	available := map[string][]Version{
+		"foo": {MustParse("1.0.0"), MustParse("1.5.0"), MustParse("2.0.0")},
+		"bar": {MustParse("0.1.0"), MustParse("0.2.0")},
+	}
+	decisions, err := Resolve(available, map[string]Range{
+		"foo": MustParseRange("<2.0.0"),
+		"bar": MustParseRange(">=0.1.0"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decisions["foo"].EQ(MustParse("1.5.0")) || !decisions["bar"].EQ(MustParse("0.2.0")) {
+		t.Errorf("Unexpected decisions: %v", decisions)
+	}
+
+	_, err = Resolve(available, map[string]Range{"foo": MustParseRange(">3.0.0")})
+	if !errors.Is(err, ErrNoSatisfyingVersion) {
+		t.Errorf("Expected ErrNoSatisfyingVersion, got %v", err)
+	}
+	if _, err := Resolve(available, map[string]Range{"baz": MustParseRange(">1.0.0")}); err == nil {
+		t.Error("Expected error for unavailable package")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	src := MemorySource{"foo": {MustParse("1.0.0")}}
+	available, err := Fetch(context.Background(), src, []string{"foo", "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(available) != 1 || len(available["foo"]) != 1 {
+		t.Errorf("Unexpected fetch result: %v", available)
+	}
+	if _, err := Fetch(context.Background(), src, []string{"bar"}); err == nil {
+		t.Error("Expected error for unknown package")
+	}
+}
+
+// resolveGraph returns the versions and constraints of a dependency graph
+// with the given number of packages and versions per package.
+func resolveGraph(packages, versions int) (map[string][]Version, map[string]Range) {
+	available := make(map[string][]Version, packages)
+	constraints := make(map[string]Range, packages)
+	for p := 0; p < packages; p++ {
+		name := "pkg" + strconv.Itoa(p)
+		list := make([]Version, versions)
+		for i := range list {
+			list[i] = Version{Major: uint64(i / 100), Minor: uint64(i % 100 / 10), Patch: uint64(i % 10)}
+		}
+		available[name] = list
+		major := strconv.Itoa(p % (versions / 100))
+		constraints[name] = MustParseRange("^" + major + ".0.0 !=" + major + ".9.9")
+	}
+	return available, constraints
+}
+
+func BenchmarkResolve(b *testing.B) {
+	available, constraints := resolveGraph(1000, 500)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = Resolve(available, constraints)
+	}
+}
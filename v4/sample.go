@@ -0,0 +1,113 @@
+package semver
+
+import (
+	"math"
+	"math/rand"
+)
+
+// sampleAttempts limits the random draws for each requested version in
+// SampleVersions, draws outside of the range are discarded.
+const sampleAttempts = 16
+
+// SampleVersions returns up to n distinct versions satisfying r in ascending
+// order, e.g. to fuzz systems consuming versions which match a constraint.
+// Representative versions are chosen first: the bounds of r, versions next
+// to the bounds, midpoints and prereleases next to the bounds. Remaining
+// versions are drawn randomly within r. The result is deterministic for a
+// given seed. It is nil if r matches no version or can not be inspected.
+func SampleVersions(r Range, n int, seed int64) []Version {
+	c, ok := constraintsOf(r)
+	if !ok || n <= 0 {
+		return nil
+	}
+	intervals := c.intervals()
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	var samples []Version
+	add := func(v Version) {
+		if len(samples) >= n || !c.check(v) {
+			return
+		}
+		for _, s := range samples {
+			if s.Equals(v) {
+				return
+			}
+		}
+		samples = append(samples, v)
+	}
+
+	for _, i := range intervals {
+		if !i.Lower.Unbounded {
+			add(withoutBuild(i.Lower.Version))
+			if len(i.Lower.Version.Pre) > 0 {
+				next := withoutBuild(i.Lower.Version)
+				next.Pre = append(next.Pre[:len(next.Pre):len(next.Pre)], PRVersion{VersionNum: 0, IsNum: true})
+				add(next)
+			}
+		}
+		if !i.Upper.Unbounded {
+			add(withoutBuild(i.Upper.Version))
+		}
+		lo, hi := sampleSpan(i)
+		add(lo)
+		add(hi)
+		add(Version{Major: lo.Major, Minor: lo.Minor, Patch: lo.Patch + 1})
+		if hi.Patch > 0 {
+			add(Version{Major: hi.Major, Minor: hi.Minor, Patch: hi.Patch - 1})
+		}
+		add(Version{
+			Major: lo.Major + (hi.Major-lo.Major)/2,
+			Minor: lo.Minor + (hi.Minor-lo.Minor)/2,
+			Patch: lo.Patch + (hi.Patch-lo.Patch)/2,
+		})
+		add(Version{Major: hi.Major, Minor: hi.Minor, Patch: hi.Patch, Pre: []PRVersion{{VersionNum: 0, IsNum: true}}})
+		add(Version{Major: lo.Major, Minor: lo.Minor, Patch: lo.Patch + 1, Pre: []PRVersion{{VersionStr: "alpha"}, {VersionNum: 1, IsNum: true}}})
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	for attempts := n * sampleAttempts; len(samples) < n && attempts > 0; attempts-- {
+		lo, hi := sampleSpan(intervals[rnd.Intn(len(intervals))])
+		v := Version{Major: lo.Major}
+		if span := hi.Major - lo.Major; span == math.MaxUint64 {
+			v.Major = rnd.Uint64()
+		} else if span > 0 {
+			v.Major += rnd.Uint64() % (span + 1)
+		}
+		v.Minor = uint64(rnd.Intn(20))
+		v.Patch = uint64(rnd.Intn(20))
+		if v.Major == lo.Major && v.Minor < lo.Minor {
+			v.Minor, v.Patch = lo.Minor, lo.Patch+v.Patch
+		}
+		if rnd.Intn(4) == 0 {
+			v.Pre = []PRVersion{{VersionStr: "rc"}, {VersionNum: uint64(rnd.Intn(5)), IsNum: true}}
+		}
+		add(v)
+	}
+	Sort(samples)
+	return samples
+}
+
+func withoutBuild(v Version) Version {
+	v.Build = nil
+	return v
+}
+
+// sampleSpan returns the versions spanning the interval i without
+// prereleases and build meta data. Unbounded ends are replaced by 0.0.0 and
+// ten major versions above the lower end.
+func sampleSpan(i Interval) (lo, hi Version) {
+	if !i.Lower.Unbounded {
+		lo = Version{Major: i.Lower.Version.Major, Minor: i.Lower.Version.Minor, Patch: i.Lower.Version.Patch}
+	}
+	if i.Upper.Unbounded {
+		hi = Version{Major: lo.Major + 10}
+	} else {
+		hi = Version{Major: i.Upper.Version.Major, Minor: i.Upper.Version.Minor, Patch: i.Upper.Version.Patch}
+	}
+	if hi.LT(lo) {
+		hi = lo
+	}
+	return lo, hi
+}
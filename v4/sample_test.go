@@ -0,0 +1,84 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSampleVersions(t *testing.T) {
+	tests := []string{
+		"^1.2.3",
+		">=1.0.0 <1.0.5",
+		"<0.2.0",
+		">=3.0.0",
+		"~1.2.0 || 2.x || 5.0.0",
+		">=1.0.0-beta.1 <1.0.0",
+	}
+	for _, s := range tests {
+		r := MustParseRange(s)
+		samples := SampleVersions(r, 20, 42)
+		if len(samples) == 0 {
+			t.Errorf("Invalid for case %q: no samples", s)
+		}
+		if len(samples) > 20 {
+			t.Errorf("Invalid for case %q: Expected at most 20 samples, got: %d", s, len(samples))
+		}
+		for i, v := range samples {
+			if !r(v) {
+				t.Errorf("Invalid for case %q: sample %q does not satisfy the range", s, v)
+			}
+			if i > 0 && !samples[i-1].LT(v) {
+				t.Errorf("Invalid for case %q: samples not sorted and distinct: %q", s, samples)
+			}
+		}
+		if again := SampleVersions(r, 20, 42); !reflect.DeepEqual(samples, again) {
+			t.Errorf("Invalid for case %q: samples are not deterministic", s)
+		}
+	}
+}
+
+func TestSampleVersionsBounds(t *testing.T) {
+	samples := SampleVersions(MustParseRange(">=1.2.3 <=1.4.0"), 3, 1)
+	if s := fmtVersions(samples); s != "[1.2.3 1.2.4 1.4.0]" {
+		t.Errorf("Expected %q, got: %q", "[1.2.3 1.2.4 1.4.0]", s)
+	}
+	if samples := SampleVersions(MustParseRange("1.2.3"), 10, 1); fmtVersions(samples) != "[1.2.3]" {
+		t.Errorf("Expected [1.2.3], got: %q", samples)
+	}
+}
+
+func TestSampleVersionsHugeSpan(t *testing.T) {
+	// The random major versions span more than the range of an int64
+	tests := []string{
+		"<18446744073709551615.0.0",
+		">=1.0.0 <9223372036854775810.0.0",
+		">=18446744073709551610.0.0",
+	}
+	for _, s := range tests {
+		r := MustParseRange(s)
+		samples := SampleVersions(r, 100, 1)
+		if len(samples) == 0 {
+			t.Errorf("Invalid for case %q: no samples", s)
+		}
+		for _, v := range samples {
+			if !r(v) {
+				t.Errorf("Invalid for case %q: sample %q does not satisfy the range", s, v)
+			}
+		}
+	}
+}
+
+func TestSampleVersionsNone(t *testing.T) {
+	tests := []Range{
+		MustParseRange(">2.0.0 <1.0.0"),
+		func(Version) bool { return true },
+	}
+	for _, r := range tests {
+		if samples := SampleVersions(r, 5, 1); samples != nil {
+			t.Errorf("Expected no samples, got: %q", samples)
+		}
+	}
+	if samples := SampleVersions(MustParseRange("1.x"), 0, 1); samples != nil {
+		t.Errorf("Expected no samples, got: %q", samples)
+	}
+}
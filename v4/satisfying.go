@@ -0,0 +1,135 @@
+package semver
+
+import "errors"
+
+// ErrUnbounded is returned by Range.MaxSatisfying if a range has no upper
+// bound.
+var ErrUnbounded = errors.New("range has no upper bound")
+
+// MinSatisfying returns the least version satisfying the range, like
+// minVersion of node-semver: ">=1.2.3" yields 1.2.3, ">1.2.3" yields 1.2.4
+// and ">1.2.3-beta" yields 1.2.3-beta.0. Like npm it prefers releases, a
+// prerelease is only returned if the range names one or if no release
+// satisfies the range. It returns ErrNoSatisfyingVersion if no version
+// satisfies the range and ErrRangeNotInspectable if the range was not
+// created by this package.
+func (rf Range) MinSatisfying() (Version, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return Version{}, ErrRangeNotInspectable
+	}
+	intervals := c.intervals()
+	for _, fallback := range []bool{false, true} {
+		for _, i := range intervals {
+			preferred, prerelease := lowestCandidates(i.Lower)
+			candidates := preferred
+			if fallback {
+				candidates = prerelease
+			}
+			for _, v := range candidates {
+				if i.Contains(v) && c.check(v) {
+					return v, nil
+				}
+			}
+		}
+	}
+	return Version{}, ErrNoSatisfyingVersion
+}
+
+// MaxSatisfying returns the greatest version satisfying the range:
+// "<=1.2.3" yields 1.2.3. An exclusive upper bound has no greatest
+// prerelease below it, so the greatest release below the bound is used,
+// "<2.0.0" yields 1.18446744073709551615.18446744073709551615. It returns
+// ErrUnbounded if the range has no upper bound, ErrNoSatisfyingVersion if no
+// version satisfies the range and ErrRangeNotInspectable if the range was
+// not created by this package.
+func (rf Range) MaxSatisfying() (Version, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return Version{}, ErrRangeNotInspectable
+	}
+	intervals := c.intervals()
+	if n := len(intervals); n > 0 && intervals[n-1].Upper.Unbounded {
+		return Version{}, ErrUnbounded
+	}
+	for n := len(intervals) - 1; n >= 0; n-- {
+		i := intervals[n]
+		for _, v := range highestCandidates(i.Upper) {
+			if i.Contains(v) && c.check(v) {
+				return v, nil
+			}
+		}
+	}
+	return Version{}, ErrNoSatisfyingVersion
+}
+
+// MaxSatisfying returns the highest version in versions satisfying r, like
+// maxSatisfying of node-semver. ok is false if no version satisfies r. Of
+// versions of equal precedence the first one is returned.
+func MaxSatisfying(versions []Version, r Range) (max Version, ok bool) {
+	for _, v := range versions {
+		if r(v) && (!ok || v.GT(max)) {
+			max = v
+			ok = true
+		}
+	}
+	return max, ok
+}
+
+// MinSatisfying returns the lowest version in versions satisfying r, like
+// minSatisfying of node-semver. ok is false if no version satisfies r. Of
+// versions of equal precedence the first one is returned.
+func MinSatisfying(versions []Version, r Range) (min Version, ok bool) {
+	for _, v := range versions {
+		if r(v) && (!ok || v.LT(min)) {
+			min = v
+			ok = true
+		}
+	}
+	return min, ok
+}
+
+// lowestCandidates returns the candidates for the least version admitted by
+// the lower bound b: the preferred ones, which are releases unless b is a
+// prerelease, and the prereleases to fall back to if no preferred candidate
+// of any interval satisfies the range.
+func lowestCandidates(b Endpoint) (preferred, prerelease []Version) {
+	floor := []PRVersion{{IsNum: true}}
+	if b.Unbounded {
+		return []Version{{}}, []Version{{Pre: floor}}
+	}
+	v := withoutBuild(b.Version)
+	if len(v.Pre) > 0 {
+		if b.Inclusive {
+			preferred = append(preferred, v)
+		} else {
+			next := v
+			next.Pre = append(v.Pre[:len(v.Pre):len(v.Pre)], floor...)
+			preferred = append(preferred, next)
+		}
+		return append(preferred, truncateVersion(v, ReleasePatch)), nil
+	}
+	if b.Inclusive {
+		preferred = append(preferred, v)
+	}
+	if next, ok := nextVersion(v, ReleasePatch); ok {
+		preferred = append(preferred, next)
+		next.Pre = floor
+		prerelease = append(prerelease, next)
+	}
+	return preferred, prerelease
+}
+
+// highestCandidates returns the candidates for the greatest version admitted
+// by the bounded upper bound b, in the order of preference.
+func highestCandidates(b Endpoint) []Version {
+	v := withoutBuild(b.Version)
+	var candidates []Version
+	if b.Inclusive {
+		candidates = append(candidates, v)
+	}
+	if prev, ok := PrevVersion(v, ReleasePatch); ok {
+		candidates = append(candidates, prev)
+	}
+	return candidates
+}
@@ -0,0 +1,115 @@
+package semver
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRangeMinSatisfying(t *testing.T) {
+	tests := []struct {
+		r   string
+		min string
+	}{
+		{">=1.2.3", "1.2.3"},
+		{">1.2.3", "1.2.4"},
+		{"^1.2", "1.2.0"},
+		{"*", "0.0.0"},
+		{"<1.0.0", "0.0.0"},
+		{">1.2.3-beta", "1.2.3-beta.0"},
+		{">=1.2.3-beta+build", "1.2.3-beta"},
+		{">1.2.3 !=1.2.4", "1.2.5"},
+		{">1.2.3 <1.2.4", "1.2.4-0"},
+		{"<0.0.0", "0.0.0-0"},
+		{"<0.0.0-0", ""},
+		{"2.x || 1.5.0", "1.5.0"},
+		{">2.0.0 <1.0.0", ""},
+	}
+	for _, tc := range tests {
+		v, err := MustParseRange(tc.r).MinSatisfying()
+		if tc.min == "" {
+			if err != ErrNoSatisfyingVersion {
+				t.Errorf("Invalid for case %q: Expected ErrNoSatisfyingVersion, got: %s (%v)", tc.r, v, err)
+			}
+		} else if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.r, err)
+		} else if v.String() != tc.min {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.min, v)
+		}
+	}
+
+	r, err := ParseRangeWithOptions(">1.2.3 <1.2.4", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.MinSatisfying(); err != ErrNoSatisfyingVersion {
+		t.Errorf("Expected ErrNoSatisfyingVersion with NPMCompat, got: %s (%v)", v, err)
+	}
+	if _, err := Range(func(Version) bool { return true }).MinSatisfying(); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+}
+
+func TestRangeMaxSatisfying(t *testing.T) {
+	max := Version{Major: 1, Minor: math.MaxUint64, Patch: math.MaxUint64}
+	tests := []struct {
+		r   string
+		max string
+	}{
+		{"<=1.2.3", "1.2.3"},
+		{"<1.2.3", "1.2.2"},
+		{"^1.2", max.String()},
+		{"1.2.x", "1.2.18446744073709551615"},
+		{"<=1.2.3-beta", "1.2.3-beta"},
+		{">=1.2.3-alpha <1.2.3-beta", ""},
+		{"<=1.2.3 !=1.2.3", "1.2.2"},
+		{"1.x || 3.0.0", "3.0.0"},
+		{"<0.0.0-0", ""},
+	}
+	for _, tc := range tests {
+		v, err := MustParseRange(tc.r).MaxSatisfying()
+		if tc.max == "" {
+			if err != ErrNoSatisfyingVersion {
+				t.Errorf("Invalid for case %q: Expected ErrNoSatisfyingVersion, got: %s (%v)", tc.r, v, err)
+			}
+		} else if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.r, err)
+		} else if v.String() != tc.max {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.max, v)
+		}
+	}
+
+	if _, err := MustParseRange(">=1.0.0").MaxSatisfying(); err != ErrUnbounded {
+		t.Errorf("Expected ErrUnbounded, got: %v", err)
+	}
+	if _, err := Range(func(Version) bool { return true }).MaxSatisfying(); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+}
+
+func TestMaxMinSatisfying(t *testing.T) {
+	versions := parseVersions("1.2.3", "2.0.0-rc.1", "1.5.0+b", "1.5.0+a", "0.9.0", "2.1.0")
+	tests := []struct {
+		r        string
+		max, min string
+	}{
+		{">=1.0.0 <2.0.0-0", "1.5.0+b", "1.2.3"},
+		{"*", "2.1.0", "0.9.0"},
+		{"<1.0.0 || >=2.0.0-0", "2.1.0", "0.9.0"},
+		{">=2.0.0-0 <2.0.0", "2.0.0-rc.1", "2.0.0-rc.1"},
+		{"^3", "", ""},
+	}
+	for _, tc := range tests {
+		r := MustParseRange(tc.r)
+		max, ok := MaxSatisfying(versions, r)
+		if ok != (tc.max != "") || (ok && max.String() != tc.max) {
+			t.Errorf("Invalid for case %q: Expected max %q, got: %q (%t)", tc.r, tc.max, max, ok)
+		}
+		min, ok := MinSatisfying(versions, r)
+		if ok != (tc.min != "") || (ok && min.String() != tc.min) {
+			t.Errorf("Invalid for case %q: Expected min %q, got: %q (%t)", tc.r, tc.min, min, ok)
+		}
+	}
+	if _, ok := MaxSatisfying(nil, MustParseRange("*")); ok {
+		t.Errorf("Expected no version of an empty list")
+	}
+}
@@ -0,0 +1,457 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The kinds of syntax errors, see RangeSyntaxError.
+var (
+	// ErrInvalidCharacter is a character which neither starts a token nor
+	// continues a version, e.g. the "e" of "1.2.3 extra" or a single '|'.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrInvalidVersion is a malformed version, e.g. "1.", "1.2.3-", "1e3"
+	// or "01.2.3", or one whose expansion overflows, e.g. the upper bound
+	// of "^18446744073709551615.0.0".
+	ErrInvalidVersion = errors.New("invalid version")
+	// ErrInvalidWildcard is a number following a wildcard, e.g. "1.x.3".
+	ErrInvalidWildcard = errors.New("invalid wildcard")
+	// ErrInvalidOperator is an operator where it is not allowed, e.g. on a
+	// bound of a hyphen range, or "~" in SemVerOnly mode.
+	ErrInvalidOperator = errors.New("invalid operator")
+	// ErrDanglingOperator is an operator or the '-' of a hyphen range
+	// without its version, e.g. ">=" or "1.2.3 -".
+	ErrDanglingOperator = errors.New("dangling operator")
+	// ErrEmptyRange is a range, a set of it or a group without any
+	// comparator, e.g. "", "1.2.3 ||" or "()".
+	ErrEmptyRange = errors.New("empty range")
+	// ErrUnexpectedToken is a valid token in the wrong place, e.g. an
+	// unbalanced parenthesis.
+	ErrUnexpectedToken = errors.New("unexpected token")
+	// ErrAmbiguousPrecedence is a mix of "||" and AND without parentheses,
+	// see RangeOptions.Precedence.
+	ErrAmbiguousPrecedence = errors.New("ambiguous precedence")
+	// ErrRangeTooComplex is a range whose groups expand to too many sets.
+	ErrRangeTooComplex = errors.New("range too complex")
+)
+
+// RangeSyntaxError describes a syntax error in a range string. Use errors.Is
+// with its kind, e.g. ErrInvalidVersion or ErrDanglingOperator, to tell the
+// errors apart, and Caret to point users at the error.
+type RangeSyntaxError struct {
+	Range  string // the range string being parsed
+	Offset int    // byte offset of the error in Range
+	Token  string // the offending token, "" at the end of Range
+	Msg    string // description of the error
+	Err    error  // the kind of the error, e.g. ErrInvalidCharacter
+
+	cause error // the error a version can not be built with, if any
+}
+
+func (e *RangeSyntaxError) Error() string {
+	return fmt.Sprintf("invalid range %s: %s at offset %d", quote(e.Range), e.Msg, e.Offset)
+}
+
+// Is reports whether target is the kind of the error.
+func (e *RangeSyntaxError) Is(target error) bool {
+	return target == e.Err
+}
+
+// Unwrap returns the error a version of the range can not be built with,
+// e.g. an *OverflowError or a *NumberError, or else the kind of the error.
+func (e *RangeSyntaxError) Unwrap() error {
+	if e.cause != nil {
+		return e.cause
+	}
+	return e.Err
+}
+
+// Caret returns Range and a line below it pointing at the error with a
+// '^', e.g. for command line tools:
+//
+//	>=1.2.3 <2.x.1
+//	             ^
+func (e *RangeSyntaxError) Caret() string {
+	offset := e.Offset
+	if offset > len(e.Range) {
+		offset = len(e.Range)
+	}
+	return e.Range + "\n" + strings.Repeat(" ", utf8.RuneCountInString(e.Range[:offset])) + "^"
+}
+
+// TokenKind is the kind of a Token.
+type TokenKind int
+
+const (
+	TokenOperator   TokenKind = iota // <, <=, >, >=, =, ==, !, !=, ~, ~>, ^
+	TokenVersion                     // a possibly partial version, e.g. 1.x or 1.2.3-beta
+	TokenHyphen                      // the '-' of a hyphen range
+	TokenOr                          // ||
+	TokenInvalid                     // input the parser rejects, up to the next space or '||'
+	TokenOpenParen                   // the '(' of a group
+	TokenCloseParen                  // the ')' of a group
+)
+
+// Token is a lexical element of a range string.
+type Token struct {
+	Kind   TokenKind
+	Offset int    // byte offset in the range string
+	Text   string // the source text of the token
+}
+
+// rangeScanner splits a range string into tokens. Versions are checked
+// strictly: anything following a version up to the next space or '||' is an
+// error instead of being silently dropped.
+type rangeScanner struct {
+	s   string
+	pos int
+}
+
+// errorf returns a RangeSyntaxError of the kind err at offset, token is the
+// offending token.
+func (sc *rangeScanner) errorf(err error, offset int, token string, format string, a ...interface{}) error {
+	return &RangeSyntaxError{Range: sc.s, Offset: offset, Token: token, Msg: fmt.Sprintf(format, a...), Err: err}
+}
+
+// word returns the text of the range string from start up to the next space,
+// '|' or ')', e.g. the whole malformed version.
+func (sc *rangeScanner) word(start int) string {
+	end := start
+	for end < len(sc.s) && !isSpace(sc.s[end]) && sc.s[end] != '|' && sc.s[end] != ')' {
+		end++
+	}
+	return sc.s[start:end]
+}
+
+// charAt returns the character at offset i of the range string.
+func (sc *rangeScanner) charAt(i int) string {
+	_, n := utf8.DecodeRuneInString(sc.s[i:])
+	return sc.s[i : i+n]
+}
+
+// scanRange returns the tokens of the range string s.
+func scanRange(s string) ([]Token, error) {
+	sc := &rangeScanner{s: s}
+	var tokens []Token
+	for {
+		t, ok, err := sc.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return tokens, nil
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// Tokenize splits the range string s into tokens like the parser, e.g. for
+// syntax highlighting in editors. Unlike the parser it does not stop at
+// errors: input the parser rejects becomes a TokenInvalid reaching to the
+// next space or '||', and scanning continues after it. Tokenize only checks
+// the tokens, not their order, e.g. ">= ||" has no TokenInvalid.
+func Tokenize(s string) []Token {
+	sc := &rangeScanner{s: s}
+	var tokens []Token
+	for {
+		start := sc.pos
+		t, ok, err := sc.next()
+		if err != nil {
+			// Skip the leading space the failed scan consumed
+			sc.pos = start
+			sc.skipSpace()
+			start = sc.pos
+			for sc.pos < len(s) && !isSpace(s[sc.pos]) && ((s[sc.pos] != '|' && s[sc.pos] != ')') || sc.pos == start) {
+				sc.pos++
+			}
+			t, ok = Token{Kind: TokenInvalid, Offset: start, Text: s[start:sc.pos]}, true
+		}
+		if !ok {
+			return tokens
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// next scans the next token, ok is false at the end of the string.
+func (sc *rangeScanner) next() (t Token, ok bool, err error) {
+	s := sc.s
+	sc.skipSpace()
+	if sc.pos == len(s) {
+		return Token{}, false, nil
+	}
+	start := sc.pos
+	switch c := s[sc.pos]; {
+	case c == '|':
+		if sc.pos+1 == len(s) || s[sc.pos+1] != '|' {
+			return Token{}, false, sc.errorf(ErrInvalidCharacter, start, "|", "expected '||'")
+		}
+		sc.pos += 2
+		return Token{Kind: TokenOr, Offset: start, Text: "||"}, true, nil
+	case c == '-':
+		sc.pos++
+		return Token{Kind: TokenHyphen, Offset: start, Text: "-"}, true, nil
+	case c == '(':
+		sc.pos++
+		return Token{Kind: TokenOpenParen, Offset: start, Text: "("}, true, nil
+	case c == ')':
+		sc.pos++
+		return Token{Kind: TokenCloseParen, Offset: start, Text: ")"}, true, nil
+	case isDigit(c) || c == 'x' || c == '*':
+		if err := sc.scanVersion(); err != nil {
+			return Token{}, false, err
+		}
+		return Token{Kind: TokenVersion, Offset: start, Text: s[start:sc.pos]}, true, nil
+	default:
+		n := scanOperator(s[start:])
+		if n == 0 {
+			return Token{}, false, sc.errorf(ErrInvalidCharacter, start, sc.charAt(start), "unexpected character %q", c)
+		}
+		sc.pos += n
+		return Token{Kind: TokenOperator, Offset: start, Text: s[start:sc.pos]}, true, nil
+	}
+}
+
+func (sc *rangeScanner) skipSpace() {
+	for sc.pos < len(sc.s) && isSpace(sc.s[sc.pos]) {
+		sc.pos++
+	}
+}
+
+// scanVersion scans a version of up to three dot separated numbers or
+// wildcards. A prerelease and build suffix is only allowed after a full
+// numeric version.
+func (sc *rangeScanner) scanVersion() error {
+	s := sc.s
+	start := sc.pos
+	wildcard := false
+	for part := 0; ; part++ {
+		switch {
+		case sc.pos < len(s) && (s[sc.pos] == 'x' || s[sc.pos] == '*'):
+			wildcard = true
+			sc.pos++
+		case sc.pos < len(s) && isDigit(s[sc.pos]):
+			if wildcard {
+				return sc.errorf(ErrInvalidWildcard, sc.pos, sc.word(start), "version number after wildcard")
+			}
+			for sc.pos < len(s) && isDigit(s[sc.pos]) {
+				sc.pos++
+			}
+			if n := exponentLength(s[sc.pos:]); n > 0 {
+				return sc.errorf(ErrInvalidVersion, sc.pos, sc.word(start), "scientific notation %s in version number", quote(s[sc.pos:sc.pos+n]))
+			}
+		default:
+			return sc.errorf(ErrInvalidVersion, sc.pos, sc.word(start), "expected version number")
+		}
+		if part < 2 && sc.pos < len(s) && s[sc.pos] == '.' {
+			sc.pos++
+			continue
+		}
+		if part == 2 && !wildcard {
+			if err := sc.scanIdentifiers(start, '-', "prerelease"); err != nil {
+				return err
+			}
+			if err := sc.scanIdentifiers(start, '+', "build"); err != nil {
+				return err
+			}
+		}
+		break
+	}
+	if sc.pos < len(s) && !isSpace(s[sc.pos]) && s[sc.pos] != '|' && s[sc.pos] != ')' {
+		return sc.errorf(ErrInvalidCharacter, sc.pos, sc.charAt(sc.pos), "unexpected character %q after version", s[sc.pos])
+	}
+	return nil
+}
+
+// scanIdentifiers scans dot separated identifiers introduced by prefix of the
+// version starting at versionStart.
+func (sc *rangeScanner) scanIdentifiers(versionStart int, prefix byte, name string) error {
+	s := sc.s
+	if sc.pos == len(s) || s[sc.pos] != prefix {
+		return nil
+	}
+	sc.pos++
+	for {
+		start := sc.pos
+		for sc.pos < len(s) && (isDigit(s[sc.pos]) || isLetter(s[sc.pos]) || s[sc.pos] == '-') {
+			sc.pos++
+		}
+		if sc.pos == start {
+			return sc.errorf(ErrInvalidVersion, start, sc.word(versionStart), "empty %s identifier", name)
+		}
+		if sc.pos == len(s) || s[sc.pos] != '.' {
+			return nil
+		}
+		sc.pos++
+	}
+}
+
+// scanORParts scans the range string s and returns its comparators grouped by
+// '||', in the form expected by expandWildcardVersion.
+func scanORParts(s string) ([][]string, error) {
+	tokens, err := scanRange(s)
+	if err != nil {
+		return nil, err
+	}
+	sc := &rangeScanner{s: s}
+	if len(tokens) == 0 {
+		return nil, sc.errorf(ErrEmptyRange, 0, "", "empty range")
+	}
+
+	var orParts [][]string
+	var set []string
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.Kind {
+		case TokenOr:
+			if len(set) == 0 {
+				return nil, sc.errorf(ErrEmptyRange, t.Offset, t.Text, "'||' without range before it")
+			}
+			if i == len(tokens)-1 {
+				return nil, sc.errorf(ErrEmptyRange, t.Offset, t.Text, "'||' without range after it")
+			}
+			orParts = append(orParts, set)
+			set = nil
+		default:
+			clause, next, err := sc.scanClause(tokens, i)
+			if err != nil {
+				return nil, err
+			}
+			set = append(set, clause)
+			i = next - 1
+		}
+	}
+	return append(orParts, set), nil
+}
+
+// scanClause returns the comparator starting at tokens[i], in the form
+// expected by expandWildcardVersion, and the index of the token following
+// it: an operator and a version, a bare version or a hyphen range.
+func (sc *rangeScanner) scanClause(tokens []Token, i int) (clause string, next int, err error) {
+	t := tokens[i]
+	switch t.Kind {
+	case TokenHyphen:
+		return "", 0, sc.errorf(ErrDanglingOperator, t.Offset, t.Text, "'-' without version before it")
+	case TokenOperator:
+		if i+1 == len(tokens) || tokens[i+1].Kind != TokenVersion {
+			return "", 0, sc.errorf(ErrDanglingOperator, sc.nextOffset(tokens, i), t.Text, "expected version after %s", quote(t.Text))
+		}
+		if i+2 < len(tokens) && tokens[i+2].Kind == TokenHyphen {
+			return "", 0, sc.errorf(ErrInvalidOperator, tokens[i+2].Offset, t.Text, "hyphen range bound must not have an operator")
+		}
+		return comparatorString(t.Text, tokens[i+1].Text), i + 2, nil
+	case TokenVersion:
+		if i+1 < len(tokens) && tokens[i+1].Kind == TokenHyphen {
+			if i+2 == len(tokens) || tokens[i+2].Kind != TokenVersion {
+				return "", 0, sc.errorf(ErrDanglingOperator, sc.nextOffset(tokens, i+1), "-", "expected version after '-'")
+			}
+			return t.Text + " - " + tokens[i+2].Text, i + 3, nil
+		}
+		return comparatorString("", t.Text), i + 1, nil
+	}
+	return "", 0, sc.errorf(ErrUnexpectedToken, t.Offset, t.Text, "unexpected %s", quote(t.Text))
+}
+
+// comparatorError returns err, the error of building the comparators of the
+// range string s, as *RangeSyntaxError at the first comparator which can not
+// be built, see versionError.
+func comparatorError(s string, npm bool, err error) error {
+	tokens, scanErr := scanRange(s)
+	if scanErr != nil {
+		return err
+	}
+	sc := &rangeScanner{s: s}
+	for i := 0; i < len(tokens); {
+		if tokens[i].Kind == TokenOr {
+			i++
+			continue
+		}
+		clause, next, scanErr := sc.scanClause(tokens, i)
+		if scanErr != nil {
+			return err
+		}
+		if _, buildErr := buildComparator(clause, npm); buildErr != nil {
+			return sc.versionError(tokens[i:next], npm)
+		}
+		i = next
+	}
+	return err
+}
+
+// versionError returns the error of building the comparator of the tokens
+// clause as *RangeSyntaxError of the kind ErrInvalidVersion at its version,
+// e.g. for "01.2.3" or "^18446744073709551615.0.0" whose upper bound
+// overflows. The error wraps the cause. Of a hyphen range, the first bound
+// which can not be built on its own is blamed, or else the second.
+func (sc *rangeScanner) versionError(clause []Token, npm bool) error {
+	text, _, _ := sc.scanClause(clause, 0)
+	_, err := buildComparator(text, npm)
+	if err == nil {
+		return nil
+	}
+	var version Token
+	for _, t := range clause {
+		if t.Kind != TokenVersion {
+			continue
+		}
+		version = t
+		if _, boundErr := buildComparator(comparatorString("", t.Text), npm); boundErr != nil {
+			break
+		}
+	}
+	return &RangeSyntaxError{Range: sc.s, Offset: version.Offset, Token: version.Text, Msg: err.Error(), Err: ErrInvalidVersion, cause: err}
+}
+
+// nextOffset returns the offset of the token following tokens[i], or the end
+// of the range string.
+func (sc *rangeScanner) nextOffset(tokens []Token, i int) int {
+	if i+1 < len(tokens) {
+		return tokens[i+1].Offset
+	}
+	return len(sc.s)
+}
+
+// comparatorString joins an operator and a scanned version so that
+// splitComparatorVersion recovers both: a version starting with a wildcard is
+// written as "*" and a bare prerelease version gets an explicit "=", so that
+// it is not mistaken for a hyphen range.
+func comparatorString(op, version string) string {
+	if version[0] == 'x' || version[0] == '*' {
+		return op + "*"
+	}
+	if op == "" && hasPrerelease(version) {
+		return "=" + version
+	}
+	return op + version
+}
+
+// hasPrerelease checks if the scanned version has a prerelease suffix.
+func hasPrerelease(version string) bool {
+	for i := 0; i < len(version); i++ {
+		switch version[i] {
+		case '-':
+			return true
+		case '+':
+			return false
+		}
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		return true
+	}
+	return false
+}
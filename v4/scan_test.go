@@ -0,0 +1,184 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func formatTokens(tokens []Token) string {
+	var b strings.Builder
+	for i, t := range tokens {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s@%d", t.Text, t.Offset)
+	}
+	return b.String()
+}
+
+func TestScanRange(t *testing.T) {
+	tests := []struct {
+		i string
+		t string
+	}{
+		{">=1.2.3", ">=@0 1.2.3@2"},
+		{"~> 1.x || *", "~>@0 1.x@3 ||@7 *@10"},
+		{"1.2.3 - 2", "1.2.3@0 -@6 2@8"},
+		{"1.2.3-alpha.1 -2.0.0-rc", "1.2.3-alpha.1@0 -@14 2.0.0-rc@15"},
+		{"1.2.3||2", "1.2.3@0 ||@5 2@7"},
+		{"!=1.2.3-beta.1+b", "!=@0 1.2.3-beta.1+b@2"},
+		{"<==1", "<=@0 =@2 1@3"},
+	}
+
+	for _, tc := range tests {
+		tokens, err := scanRange(tc.i)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if s := formatTokens(tokens); s != tc.t {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.t, s)
+		}
+	}
+}
+
+func TestScanORParts(t *testing.T) {
+	tests := []struct {
+		i string
+		p string
+	}{
+		{"1.2.3 - 2 || >= 1.x <2", "[[1.2.3 - 2] [>=1.x <2]]"},
+		{"x.x || 1.2.3-beta", "[[*] [=1.2.3-beta]]"},
+		{"1.2.3-alpha.1 - 2.0.0-rc.1", "[[1.2.3-alpha.1 - 2.0.0-rc.1]]"},
+	}
+
+	for _, tc := range tests {
+		p, err := scanORParts(tc.i)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.i, err)
+		} else if s := fmt.Sprint(p); s != tc.p {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.p, s)
+		}
+	}
+}
+
+func TestRangeSyntaxError(t *testing.T) {
+	_, err := ParseRange("1.2.3 extra")
+	if err == nil || err.Error() != `invalid range "1.2.3 extra": unexpected character 'e' at offset 6` {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	_, err = ParseRange(">=1e3.2.1")
+	if err == nil || err.Error() != `invalid range ">=1e3.2.1": scientific notation "e3" in version number at offset 3` {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRangeSyntaxErrorKinds(t *testing.T) {
+	tests := []struct {
+		r      string
+		opts   RangeOptions
+		err    error
+		offset int
+		token  string
+	}{
+		{"1.2.3 extra", RangeOptions{}, ErrInvalidCharacter, 6, "e"},
+		{"1.2.3 | 2.0.0", RangeOptions{}, ErrInvalidCharacter, 6, "|"},
+		{">=1.2.3€", RangeOptions{}, ErrInvalidCharacter, 7, "€"},
+		{">=1.2.", RangeOptions{}, ErrInvalidVersion, 6, "1.2."},
+		{"1.2.3-", RangeOptions{}, ErrInvalidVersion, 6, "1.2.3-"},
+		{">=1e3.2.1", RangeOptions{}, ErrInvalidVersion, 3, "1e3.2.1"},
+		{">=01.2.3", RangeOptions{}, ErrInvalidVersion, 2, "01.2.3"},
+		{"1.2.3-01", RangeOptions{}, ErrInvalidVersion, 0, "1.2.3-01"},
+		{"^99999999999999999999.0.0", RangeOptions{}, ErrInvalidVersion, 1, "99999999999999999999.0.0"},
+		{"~18446744073709551615.18446744073709551615.0", RangeOptions{}, ErrInvalidVersion, 1, "18446744073709551615.18446744073709551615.0"},
+		{"~18446744073709551615.18446744073709551615.0", RangeOptions{NPMCompat: true}, ErrInvalidVersion, 1, "18446744073709551615.18446744073709551615.0"},
+		{"1.2.3 - 01.0.0", RangeOptions{}, ErrInvalidVersion, 8, "01.0.0"},
+		{"1.x || (>=1.2.3 <01.0.0)", RangeOptions{}, ErrInvalidVersion, 17, "01.0.0"},
+		{"1.x.3 || 2", RangeOptions{}, ErrInvalidWildcard, 4, "1.x.3"},
+		{">=1.2.3 - 2.0.0", RangeOptions{}, ErrInvalidOperator, 8, ">="},
+		{"~1.2.3", RangeOptions{SemVerOnly: true}, ErrInvalidOperator, 0, "~"},
+		{">= <2.0.0", RangeOptions{}, ErrDanglingOperator, 3, ">="},
+		{"1.2.3 <", RangeOptions{}, ErrDanglingOperator, 7, "<"},
+		{"1.2.3 - ", RangeOptions{}, ErrDanglingOperator, 8, "-"},
+		{"", RangeOptions{}, ErrEmptyRange, 0, ""},
+		{"1.2.3 ||", RangeOptions{}, ErrEmptyRange, 6, "||"},
+		{"() || 1.2.3", RangeOptions{}, ErrEmptyRange, 1, ")"},
+		{"(1.2.3", RangeOptions{}, ErrUnexpectedToken, 6, ""},
+		{"1.2.3)", RangeOptions{}, ErrUnexpectedToken, 5, ")"},
+		{"1.x || 2.x <2.5.0", RangeOptions{Precedence: PrecedenceExplicit}, ErrAmbiguousPrecedence, 11, "<"},
+	}
+	for _, tc := range tests {
+		_, err := ParseRangeWithOptions(tc.r, tc.opts)
+		var se *RangeSyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Invalid for case %q: Expected a RangeSyntaxError, got: %v", tc.r, err)
+			continue
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%s)", tc.r, tc.err, se.Err, err)
+		}
+		if se.Offset != tc.offset || se.Token != tc.token {
+			t.Errorf("Invalid for case %q: Expected token %q at offset %d, got: %q at offset %d", tc.r, tc.token, tc.offset, se.Token, se.Offset)
+		}
+	}
+}
+
+func TestRangeSyntaxErrorCause(t *testing.T) {
+	_, err := ParseRange("^18446744073709551615.0.0")
+	var oe *OverflowError
+	if !errors.As(err, &oe) || oe.Component != "major" {
+		t.Errorf("Expected an OverflowError of the major version, got: %v", err)
+	}
+	_, err = ParseRange(">=1.2.3 <01.0.0")
+	if !errors.Is(err, ErrInvalidVersion) || !errors.Is(err, ErrLeadingZeroes) {
+		t.Errorf("Expected ErrInvalidVersion caused by ErrLeadingZeroes, got: %v", err)
+	}
+}
+
+func TestRangeSyntaxErrorCaret(t *testing.T) {
+	tests := []struct {
+		r     string
+		caret string
+	}{
+		{">=1.2.3 <2.x.1", ">=1.2.3 <2.x.1\n             ^"},
+		{"€ 1.2.3", "€ 1.2.3\n^"},
+		{"^1.2.3 €", "^1.2.3 €\n       ^"},
+		{">=", ">=\n  ^"},
+	}
+	for _, tc := range tests {
+		_, err := ParseRange(tc.r)
+		se, ok := err.(*RangeSyntaxError)
+		if !ok {
+			t.Errorf("Invalid for case %q: Expected a RangeSyntaxError, got: %v", tc.r, err)
+		} else if c := se.Caret(); c != tc.caret {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.caret, c)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		i string
+		t string
+	}{
+		{">=1.2.3 <2", ">=@0 1.2.3@2 <@8 2@9"},
+		{">=1.2.3garbage <2", ">=@0 1.2.3garbage@2 <@15 2@16"},
+		{"1.2.3abc||2 | 3", "1.2.3abc@0 ||@8 2@10 |@12 3@14"},
+		{"v1.2.3 - @", "v1.2.3@0 -@7 @@9"},
+		{"!(1.2.3x) ||(2)", "!@0 (@1 1.2.3x@2 )@8 ||@10 (@12 2@13 )@14"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		if s := formatTokens(Tokenize(tc.i)); s != tc.t {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.i, tc.t, s)
+		}
+	}
+
+	kinds := []TokenKind{TokenOperator, TokenInvalid, TokenOr, TokenHyphen, TokenVersion}
+	for i, tok := range Tokenize(">= 1.x.3 || - 1") {
+		if tok.Kind != kinds[i] {
+			t.Errorf("Invalid kind of token %q: Expected %d, got: %d", tok.Text, kinds[i], tok.Kind)
+		}
+	}
+}
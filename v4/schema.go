@@ -0,0 +1,52 @@
+package semver
+
+// JSONSchema is a JSON Schema fragment describing a string property.
+// It marshals to e.g. {"type":"string","pattern":"...","description":"..."}.
+type JSONSchema struct {
+	Type        string `json:"type"`
+	Pattern     string `json:"pattern,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+const (
+	schemaNum        = `(?:0|[1-9][0-9]*)`
+	schemaPrerelease = `(?:-(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*)`
+	schemaBuild      = `(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)`
+
+	// A partial version as accepted by ParseRange: "1", "1.x", "1.2.*", "1.2.3-beta+build".
+	// Wildcards can only be followed by wildcards, prerelease and build
+	// meta data need a full version.
+	schemaWildcard   = `[x*]`
+	schemaPartial    = `(?:` + schemaWildcard + `(?:\.` + schemaWildcard + `){0,2}|` + schemaNum + `(?:\.` + schemaWildcard + `(?:\.` + schemaWildcard + `)?|\.` + schemaNum + `(?:\.` + schemaWildcard + `|\.` + schemaNum + schemaPrerelease + `?` + schemaBuild + `?)?)?)`
+	schemaOperator   = `(?:<=|>=|<|>|==|=|!=|!|~>|~|\^)`
+	schemaComparator = `(?:` + schemaPartial + `\s+-\s+` + schemaPartial + `|` + schemaOperator + `?\s*` + schemaPartial + `)`
+	// A comparator opening and closing groups, like "!(>=2.0.0" or "3.x))".
+	schemaClause = `(?:(?:!\s*)?\(\s*)*` + schemaComparator + `(?:\s*\))*`
+)
+
+// VersionSchema returns a JSON Schema fragment matching exactly the version
+// strings accepted by Parse.
+func VersionSchema() JSONSchema {
+	return JSONSchema{
+		Type:        "string",
+		Pattern:     `^` + schemaNum + `\.` + schemaNum + `\.` + schemaNum + schemaPrerelease + `?` + schemaBuild + `?$`,
+		Description: "A semantic version (https://semver.org/spec/v2.0.0.html), e.g. 1.2.3-beta.1+build.5",
+	}
+}
+
+// RangeSchema returns a JSON Schema fragment matching the range strings
+// accepted by ParseRange, including wildcard, tilde, caret and hyphen ranges
+// and groups like "!(>=2.0.0 <3.0.0) || >=4.0.0". A pattern can not count,
+// so it does not check that the parentheses are balanced.
+//
+// The pattern covers only the default dialect of ParseRange. It does not
+// match the extra syntax of RangeOptions.Loose and RangeOptions.Tolerant,
+// e.g. "=v1.2.3" or `>= "1.2.3"`, and matches ranges which
+// RangeOptions.SemVerOnly rejects, e.g. "^1.2.3".
+func RangeSchema() JSONSchema {
+	return JSONSchema{
+		Type:        "string",
+		Pattern:     `^\s*` + schemaClause + `(?:(?:\s+|\s*\|\|\s*)` + schemaClause + `)*\s*$`,
+		Description: "A semantic version range, e.g. >=1.2.3 <2.0.0 || ^3.1.0",
+	}
+}
@@ -0,0 +1,93 @@
+package semver
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestVersionSchema(t *testing.T) {
+	re := regexp.MustCompile(VersionSchema().Pattern)
+	for _, test := range formatTests {
+		if !re.MatchString(test.result) {
+			t.Errorf("Version schema does not match valid version %q", test.result)
+		}
+	}
+	for _, s := range []string{"", "1", "1.2", "v1.2.3", "01.1.1", "1.1.1-01", "1.1.1+", "0.0.0-!", "1.2.3 "} {
+		if re.MatchString(s) {
+			t.Errorf("Version schema matches invalid version %q", s)
+		}
+	}
+}
+
+func TestRangeSchema(t *testing.T) {
+	re := regexp.MustCompile(RangeSchema().Pattern)
+	valid := []string{
+		">1.2.3",
+		"<=1.2.3",
+		"!=1.2.3",
+		">= 1.2.3",
+		"1.x",
+		"1.2.*",
+		"~1.2.3",
+		"~>1.2",
+		"^1.2.3",
+		">1.2.2 <1.2.4 || >=2.0.0 <3.0.0",
+		"  1.2.3   ||   >=2.0.0  ",
+		"*",
+		"x.x",
+		"1.2.3 - 2.0.0",
+		"1.2.3-beta.1+build",
+		"(1.x || 3.x)",
+		"!(>=2.0.0 <3.0.0) || >=4.0.0",
+		"!( >=2.0.0 <3.0.0 ) || >=4.0.0",
+		"((^1.2.3 || 2.x) !=2.1.0) || 4.0.0",
+	}
+	for _, s := range valid {
+		if _, err := ParseRange(s); err != nil {
+			t.Fatalf("Test case %q must be a valid range: %s", s, err)
+		}
+		if !re.MatchString(s) {
+			t.Errorf("Range schema does not match valid range %q", s)
+		}
+	}
+	for _, s := range []string{"", "||", ">1.2.3 ||", ">>1.2.3", "string", "v1.2.3", "1.2.3garbage", "1.x.3", "1.2-beta", "1.2.3 extra", "1.", "()", "!()", "(1.x ||)", "(1.x)!"} {
+		if re.MatchString(s) {
+			t.Errorf("Range schema matches invalid range %q", s)
+		}
+		if _, err := ParseRange(s); err == nil {
+			t.Errorf("Test case %q must be an invalid range", s)
+		}
+	}
+}
+
+func TestRangeSchemaDefaultDialect(t *testing.T) {
+	re := regexp.MustCompile(RangeSchema().Pattern)
+	for _, tc := range []struct {
+		r    string
+		opts RangeOptions
+	}{
+		{"=v1.2.3", RangeOptions{Loose: true}},
+		{`>= "1.2.3"`, RangeOptions{Tolerant: true}},
+	} {
+		if _, err := ParseRangeWithOptions(tc.r, tc.opts); err != nil {
+			t.Fatalf("Test case %q must be a valid range with %+v: %s", tc.r, tc.opts, err)
+		}
+		if re.MatchString(tc.r) {
+			t.Errorf("Range schema matches %q outside of the default dialect", tc.r)
+		}
+	}
+	if _, err := ParseRangeWithOptions("^1.2.3", RangeOptions{SemVerOnly: true}); err == nil || !re.MatchString("^1.2.3") {
+		t.Errorf("Range schema must match %q of the default dialect only", "^1.2.3")
+	}
+}
+
+func TestSchemaJSON(t *testing.T) {
+	b, err := json.Marshal(JSONSchema{Type: "string", Pattern: "^x$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"type":"string","pattern":"^x$"}` {
+		t.Errorf("Unexpected JSON schema encoding: %s", b)
+	}
+}
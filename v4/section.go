@@ -0,0 +1,34 @@
+package semver
+
+import "sort"
+
+// SectionKey returns the release note headings expected between from and to
+// for the released versions s, e.g. to stitch the notes of all releases
+// skipped by an upgrade: every version of s above from up to and including
+// to, in ascending order and formatted like the tags. Prereleases are only
+// included if to is a prerelease, the notes of a release cover its
+// prereleases. Of versions of equal precedence, the first one of s is
+// used. It returns nil if to is not above from. s is not modified.
+func (s Versions) SectionKey(from, to Version) []string {
+	if !to.GT(from) {
+		return nil
+	}
+	var between []Version
+	for _, v := range s {
+		if v.GT(from) && v.LTE(to) && (len(v.Pre) == 0 || len(to.Pre) > 0) {
+			between = append(between, v)
+		}
+	}
+	sort.SliceStable(between, func(i, j int) bool {
+		return between[i].LT(between[j])
+	})
+
+	var keys []string
+	for i, v := range between {
+		if i > 0 && v.EQ(between[i-1]) {
+			continue
+		}
+		keys = append(keys, v.String())
+	}
+	return keys
+}
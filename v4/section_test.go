@@ -0,0 +1,28 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersionsSectionKey(t *testing.T) {
+	released := Versions(parseVersions("1.0.0", "1.3.0", "1.1.0", "1.2.0-rc.1", "1.2.0", "1.2.0+build.2", "2.0.0-beta.1", "2.0.0"))
+	tests := []struct {
+		from, to string
+		keys     string
+	}{
+		{"1.0.0", "1.3.0", "1.1.0 1.2.0 1.3.0"},
+		{"1.0.0", "2.0.0", "1.1.0 1.2.0 1.3.0 2.0.0"},
+		{"1.1.0", "2.0.0-beta.1", "1.2.0-rc.1 1.2.0 1.3.0 2.0.0-beta.1"},
+		{"1.1.5", "1.2.5", "1.2.0"},
+		{"0.9.0", "1.0.0", "1.0.0"},
+		{"1.3.0", "1.3.0", ""},
+		{"2.0.0", "1.0.0", ""},
+	}
+	for _, tc := range tests {
+		keys := released.SectionKey(MustParse(tc.from), MustParse(tc.to))
+		if s := strings.Join(keys, " "); s != tc.keys {
+			t.Errorf("Invalid for case %q %q: Expected %q, got: %q", tc.from, tc.to, tc.keys, s)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package semver
+
+// Segment is a contiguous part of a range positioned on an axis reaching
+// from 0.0.0 to a maximum version, for drawing range coverage bars.
+// Start and End are normalized positions in [0, 1]. Every major version
+// takes the same width on the axis, minor and patch versions are placed
+// monotonically within it. Prereleases are positioned like their release.
+type Segment struct {
+	Start float64
+	End   float64
+
+	From        Version
+	IncludeFrom bool
+	To          Version
+	IncludeTo   bool
+
+	// OpenEnd is set if the segment has no upper bound or extends beyond
+	// the axis maximum, To is clamped to the axis maximum then.
+	OpenEnd bool
+}
+
+// Segments returns the segments of c on an axis reaching from 0.0.0 to
+// axisMax, ordered by position. Parts of c above axisMax are omitted.
+func (c *Constraints) Segments(axisMax Version) []Segment {
+	axisMin := Version{}
+	var segments []Segment
+	for _, i := range c.intervals() {
+		if !i.Lower.Unbounded && (i.Lower.Version.Compare(axisMax) > 0 || (i.Lower.Version.Compare(axisMax) == 0 && !i.Lower.Inclusive)) {
+			break
+		}
+		if !i.Upper.Unbounded && i.Upper.Version.Compare(axisMin) < 0 {
+			continue
+		}
+		s := Segment{
+			From:        i.Lower.Version,
+			IncludeFrom: i.Lower.Inclusive,
+			To:          i.Upper.Version,
+			IncludeTo:   i.Upper.Inclusive,
+		}
+		if i.Lower.Unbounded || i.Lower.Version.Compare(axisMin) < 0 {
+			s.From = axisMin
+			s.IncludeFrom = true
+		}
+		if i.Upper.Unbounded || i.Upper.Version.Compare(axisMax) > 0 {
+			s.To = axisMax
+			s.IncludeTo = true
+			s.OpenEnd = true
+		}
+		s.Start = axisPosition(s.From, axisMax)
+		s.End = axisPosition(s.To, axisMax)
+		if s.OpenEnd {
+			s.End = 1
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
+// Segments returns the segments of the range like Constraints.Segments. It
+// returns ErrRangeNotInspectable if the range was not created by this
+// package.
+func (rf Range) Segments(axisMax Version) ([]Segment, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return nil, ErrRangeNotInspectable
+	}
+	return c.Segments(axisMax), nil
+}
+
+// axisPosition maps v to its normalized position on an axis from 0.0.0 to
+// axisMax.
+func axisPosition(v Version, axisMax Version) float64 {
+	max := axisOffset(axisMax)
+	if max == 0 {
+		return 1
+	}
+	pos := axisOffset(v) / max
+	if pos > 1 {
+		return 1
+	}
+	return pos
+}
+
+// axisOffset maps v monotonically to a number, with every major version
+// taking a width of one.
+func axisOffset(v Version) float64 {
+	patch := float64(v.Patch)
+	minor := float64(v.Minor) + patch/(patch+1)
+	return float64(v.Major) + minor/(minor+1)
+}
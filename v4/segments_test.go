@@ -0,0 +1,72 @@
+package semver
+
+import "testing"
+
+func TestSegments(t *testing.T) {
+	axis := MustParse("4.0.0")
+	tests := []struct {
+		r        string
+		segments []Segment
+	}{
+		{">=1.0.0 <2.0.0", []Segment{
+			{Start: 0.25, End: 0.5, From: MustParse("1.0.0"), IncludeFrom: true, To: MustParse("2.0.0")},
+		}},
+		{"<1.0.0 || >=3.0.0", []Segment{
+			{Start: 0, End: 0.25, From: MustParse("0.0.0"), IncludeFrom: true, To: MustParse("1.0.0")},
+			{Start: 0.75, End: 1, From: MustParse("3.0.0"), IncludeFrom: true, To: axis, IncludeTo: true, OpenEnd: true},
+		}},
+		{">2.0.0 <=6.0.0", []Segment{
+			{Start: 0.5, End: 1, From: MustParse("2.0.0"), To: axis, IncludeTo: true, OpenEnd: true},
+		}},
+		{">=5.0.0", nil},
+		{">4.0.0 || 4.0.0", []Segment{
+			{Start: 1, End: 1, From: axis, IncludeFrom: true, To: axis, IncludeTo: true, OpenEnd: true},
+		}},
+	}
+	for _, tc := range tests {
+		segments := MustParseConstraints(tc.r).Segments(axis)
+		if len(segments) != len(tc.segments) {
+			t.Errorf("Invalid for case %q: Expected %v, got %v", tc.r, tc.segments, segments)
+			continue
+		}
+		for i, s := range segments {
+			e := tc.segments[i]
+			if s.Start != e.Start || s.End != e.End || !s.From.EQ(e.From) || s.IncludeFrom != e.IncludeFrom ||
+				!s.To.EQ(e.To) || s.IncludeTo != e.IncludeTo || s.OpenEnd != e.OpenEnd {
+				t.Errorf("Invalid segment %d for case %q: Expected %+v, got %+v", i, tc.r, e, s)
+			}
+		}
+	}
+}
+
+func TestRangeSegments(t *testing.T) {
+	axis := MustParse("4.0.0")
+	r := MustParseRange(">=1.0.0 <2.0.0").OR(MustParseRange(">=3.0.0"))
+	segments, err := r.Segments(axis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 || segments[0].Start != 0.25 || segments[0].End != 0.5 || segments[1].Start != 0.75 || !segments[1].OpenEnd {
+		t.Errorf("Expected the segments [0.25,0.5) and [0.75,1], got: %+v", segments)
+	}
+
+	if _, err := Range(func(Version) bool { return true }).Segments(axis); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+}
+
+func TestAxisPositionMonotonic(t *testing.T) {
+	axis := MustParse("10.0.0")
+	versions := []string{"0.0.0", "0.0.1", "0.0.99", "0.1.0", "0.99.99", "1.0.0", "1.0.1", "1.1.0", "2.0.0", "9.99.99", "10.0.0"}
+	last := -1.0
+	for _, s := range versions {
+		pos := axisPosition(MustParse(s), axis)
+		if pos <= last {
+			t.Errorf("Position of %q (%f) must be greater than %f", s, pos, last)
+		}
+		last = pos
+	}
+	if axisPosition(MustParse("11.0.0"), axis) != 1 {
+		t.Error("Positions beyond the axis must be clamped")
+	}
+}
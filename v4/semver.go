@@ -3,8 +3,10 @@ package semver
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -73,12 +75,14 @@ func (v Version) FinalizeVersion() string {
 	return string(b)
 }
 
-// Equals checks if v is equal to o.
+// Equals checks if v is equal to o. Like all comparison methods it compares
+// by precedence, so build meta data is ignored: 1.2.3+a equals 1.2.3+b.
+// Compare the String forms to also tell build meta data apart.
 func (v Version) Equals(o Version) bool {
 	return (v.Compare(o) == 0)
 }
 
-// EQ checks if v is equal to o.
+// EQ checks if v is equal to o, see Equals.
 func (v Version) EQ(o Version) bool {
 	return (v.Compare(o) == 0)
 }
@@ -173,21 +177,102 @@ func (v Version) Compare(o Version) int {
 
 }
 
-// IncrementPatch increments the patch version
+// OverflowError is returned when incrementing a version component which
+// already is math.MaxUint64.
+type OverflowError struct {
+	Component string
+}
+
+func (e *OverflowError) Error() string {
+	return e.Component + " version overflows uint64"
+}
+
+// ErrLeadingZeroes and ErrExponent are the causes of a NumberError.
+var (
+	ErrLeadingZeroes = errors.New("must not contain leading zeroes")
+	ErrExponent      = errors.New("must not use scientific notation")
+)
+
+// NumberError is returned when the major, minor or patch number of a
+// version string is malformed, e.g. "01" or "1e3". Use errors.Is with its
+// cause to triage rejected versions. ParseTolerant removes leading zeroes,
+// but rejects scientific notation like Parse, as "1e3" might as well be a
+// typo as 1000.
+type NumberError struct {
+	Component string // "major", "minor" or "patch"
+	Number    string // the number as given
+	Err       error  // ErrLeadingZeroes or ErrExponent
+}
+
+func (e *NumberError) Error() string {
+	return e.Component + " number " + e.Err.Error() + " " + quote(e.Number)
+}
+
+func (e *NumberError) Unwrap() error {
+	return e.Err
+}
+
+// exponentError returns a NumberError if a number of the version string s
+// is written in scientific notation.
+func exponentError(s string) error {
+	for i, part := range strings.SplitN(s, ".", 3) {
+		d := len(part) - len(strings.TrimLeft(part, numbers))
+		if n := exponentLength(part[d:]); d > 0 && n > 0 && d+n == len(part) {
+			component := [...]string{"major", "minor", "patch"}[i]
+			return &NumberError{Component: component, Number: part, Err: ErrExponent}
+		}
+	}
+	return nil
+}
+
+// exponentLength returns the length of the exponent, like "e3" or "E-2", at
+// the start of s, or 0.
+func exponentLength(s string) int {
+	if len(s) < 2 || (s[0] != 'e' && s[0] != 'E') {
+		return 0
+	}
+	n := 1
+	if s[n] == '+' || s[n] == '-' {
+		n++
+	}
+	d := len(s[n:]) - len(strings.TrimLeft(s[n:], numbers))
+	if d == 0 {
+		return 0
+	}
+	return n + d
+}
+
+// IncrementPatch increments the patch version.
+// If the patch version is math.MaxUint64 an *OverflowError is returned and
+// v is left unchanged.
 func (v *Version) IncrementPatch() error {
+	if v.Patch == math.MaxUint64 {
+		return &OverflowError{Component: "patch"}
+	}
 	v.Patch++
 	return nil
 }
 
-// IncrementMinor increments the minor version
+// IncrementMinor increments the minor version and resets the patch version.
+// If the minor version is math.MaxUint64 an *OverflowError is returned and
+// v is left unchanged.
 func (v *Version) IncrementMinor() error {
+	if v.Minor == math.MaxUint64 {
+		return &OverflowError{Component: "minor"}
+	}
 	v.Minor++
 	v.Patch = 0
 	return nil
 }
 
-// IncrementMajor increments the major version
+// IncrementMajor increments the major version and resets the minor and
+// patch versions.
+// If the major version is math.MaxUint64 an *OverflowError is returned and
+// v is left unchanged.
 func (v *Version) IncrementMajor() error {
+	if v.Major == math.MaxUint64 {
+		return &OverflowError{Component: "major"}
+	}
 	v.Major++
 	v.Minor = 0
 	v.Patch = 0
@@ -201,20 +286,20 @@ func (v Version) Validate() error {
 	for _, pre := range v.Pre {
 		if !pre.IsNum { //Numeric prerelease versions already uint64
 			if len(pre.VersionStr) == 0 {
-				return fmt.Errorf("Prerelease can not be empty %q", pre.VersionStr)
+				return fmt.Errorf("Prerelease can not be empty %s", quote(pre.VersionStr))
 			}
 			if !containsOnly(pre.VersionStr, alphanum) {
-				return fmt.Errorf("Invalid character(s) found in prerelease %q", pre.VersionStr)
+				return fmt.Errorf("Invalid character(s) found in prerelease %s", quote(pre.VersionStr))
 			}
 		}
 	}
 
 	for _, build := range v.Build {
 		if len(build) == 0 {
-			return fmt.Errorf("Build meta data can not be empty %q", build)
+			return fmt.Errorf("Build meta data can not be empty %s", quote(build))
 		}
 		if !containsOnly(build, alphanum) {
-			return fmt.Errorf("Invalid character(s) found in build meta data %q", build)
+			return fmt.Errorf("Invalid character(s) found in build meta data %s", quote(build))
 		}
 	}
 
@@ -236,56 +321,48 @@ func Make(s string) (Version, error) {
 // ParseTolerant allows for certain version specifications that do not strictly adhere to semver
 // specs to be parsed by this library. It does so by normalizing versions before passing them to
 // Parse(). It currently trims spaces, removes a "v" prefix, adds a 0 patch number to versions
-// with only major and minor components specified, and removes leading 0s.
+// with only major and minor components specified, and removes leading 0s, see
+// TolerantParseOptions. ParseStrict is its counterpart for spec compliance.
 func ParseTolerant(s string) (Version, error) {
-	s = strings.TrimSpace(s)
-	s = strings.TrimPrefix(s, "v")
-
-	// Split into major.minor.(patch+pr+meta)
-	parts := strings.SplitN(s, ".", 3)
-	// Remove leading zeros.
-	for i, p := range parts {
-		if len(p) > 1 {
-			p = strings.TrimLeft(p, "0")
-			if len(p) == 0 || !strings.ContainsAny(p[0:1], "0123456789") {
-				p = "0" + p
-			}
-			parts[i] = p
-		}
-	}
-	// Fill up shortened versions.
-	if len(parts) < 3 {
-		if strings.ContainsAny(parts[len(parts)-1], "+-") {
-			return Version{}, errors.New("Short version cannot contain PreRelease/Build meta data")
-		}
-		for len(parts) < 3 {
-			parts = append(parts, "0")
-		}
-	}
-	s = strings.Join(parts, ".")
-
-	return Parse(s)
+	return ParseWithOptions(s, TolerantParseOptions())
 }
 
 // Parse parses version string and returns a validated Version or error
 func Parse(s string) (Version, error) {
+	if m := loadMetricsSink(); m != nil {
+		start := time.Now()
+		v, err := parse(s)
+		m.ObserveParse(time.Since(start), err == nil)
+		return v, err
+	}
+	return parse(s)
+}
+
+func parse(s string) (Version, error) {
 	if len(s) == 0 {
 		return Version{}, errors.New("Version string empty")
 	}
+	limits := loadLimits()
+	if limits.MaxLength > 0 && len(s) > limits.MaxLength {
+		return Version{}, fmt.Errorf("Version string %s is longer than %d bytes", quote(s), limits.MaxLength)
+	}
 
 	// Split into major.minor.(patch+pr+meta)
 	parts, _, isValid := createVersionFromWildcard(s)
 
 	if !isValid {
+		if err := exponentError(s); err != nil {
+			return Version{}, err
+		}
 		return Version{}, errors.New("no Major.Minor.Patch elements found")
 	}
 
 	// Major
 	if !containsOnly(parts[0], numbers) {
-		return Version{}, fmt.Errorf("invalid character(s) found in major number %q", parts[0])
+		return Version{}, fmt.Errorf("invalid character(s) found in major number %s", quote(parts[0]))
 	}
 	if hasLeadingZeroes(parts[0]) {
-		return Version{}, fmt.Errorf("major number must not contain leading zeroes %q", parts[0])
+		return Version{}, &NumberError{Component: "major", Number: parts[0], Err: ErrLeadingZeroes}
 	}
 	major, err := strconv.ParseUint(parts[0], 10, 64)
 	if err != nil {
@@ -294,10 +371,10 @@ func Parse(s string) (Version, error) {
 
 	// Minor
 	if !containsOnly(parts[1], numbers) {
-		return Version{}, fmt.Errorf("Invalid character(s) found in minor number %q", parts[1])
+		return Version{}, fmt.Errorf("Invalid character(s) found in minor number %s", quote(parts[1]))
 	}
 	if hasLeadingZeroes(parts[1]) {
-		return Version{}, fmt.Errorf("Minor number must not contain leading zeroes %q", parts[1])
+		return Version{}, &NumberError{Component: "minor", Number: parts[1], Err: ErrLeadingZeroes}
 	}
 	minor, err := strconv.ParseUint(parts[1], 10, 64)
 	if err != nil {
@@ -323,10 +400,10 @@ func Parse(s string) (Version, error) {
 	}
 
 	if !containsOnly(patchStr, numbers) {
-		return Version{}, fmt.Errorf("Invalid character(s) found in patch number %q", patchStr)
+		return Version{}, fmt.Errorf("Invalid character(s) found in patch number %s", quote(patchStr))
 	}
 	if hasLeadingZeroes(patchStr) {
-		return Version{}, fmt.Errorf("Patch number must not contain leading zeroes %q", patchStr)
+		return Version{}, &NumberError{Component: "patch", Number: patchStr, Err: ErrLeadingZeroes}
 	}
 	patch, err := strconv.ParseUint(patchStr, 10, 64)
 	if err != nil {
@@ -337,6 +414,9 @@ func Parse(s string) (Version, error) {
 
 	// Prerelease
 	for _, prstr := range prerelease {
+		if limits.MaxIdentifierLength > 0 && len(prstr) > limits.MaxIdentifierLength {
+			return Version{}, fmt.Errorf("Prerelease %s is longer than %d bytes", quote(prstr), limits.MaxIdentifierLength)
+		}
 		parsedPR, err := NewPRVersion(prstr)
 		if err != nil {
 			return Version{}, err
@@ -349,8 +429,11 @@ func Parse(s string) (Version, error) {
 		if len(str) == 0 {
 			return Version{}, errors.New("Build meta data is empty")
 		}
+		if limits.MaxIdentifierLength > 0 && len(str) > limits.MaxIdentifierLength {
+			return Version{}, fmt.Errorf("Build meta data %s is longer than %d bytes", quote(str), limits.MaxIdentifierLength)
+		}
 		if !containsOnly(str, alphanum) {
-			return Version{}, fmt.Errorf("Invalid character(s) found in build meta data %q", str)
+			return Version{}, fmt.Errorf("Invalid character(s) found in build meta data %s", quote(str))
 		}
 		v.Build = append(v.Build, str)
 	}
@@ -358,11 +441,36 @@ func Parse(s string) (Version, error) {
 	return v, nil
 }
 
+// specifiedComponents counts the leading numeric components of the version
+// string s, wildcards and missing components are not counted.
+func specifiedComponents(s string) int {
+	n, digits := 0, false
+	for i := 0; i < len(s) && n < 3; i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = true
+		case c == ' ':
+		case c == '.' && digits:
+			n++
+			digits = false
+		default:
+			if digits {
+				n++
+			}
+			return n
+		}
+	}
+	if digits && n < 3 {
+		n++
+	}
+	return n
+}
+
 // MustParse is like Parse but panics if the version cannot be parsed.
 func MustParse(s string) Version {
 	v, err := Parse(s)
 	if err != nil {
-		panic(`semver: Parse(` + s + `): ` + err.Error())
+		panic(`semver: Parse(` + truncate(s, maxErrorInputLength) + `): ` + err.Error())
 	}
 	return v
 }
@@ -382,7 +490,7 @@ func NewPRVersion(s string) (PRVersion, error) {
 	v := PRVersion{}
 	if containsOnly(s, numbers) {
 		if hasLeadingZeroes(s) {
-			return PRVersion{}, fmt.Errorf("Numeric PreRelease version must not contain leading zeroes %q", s)
+			return PRVersion{}, fmt.Errorf("Numeric PreRelease version must not contain leading zeroes %s", quote(s))
 		}
 		num, err := strconv.ParseUint(s, 10, 64)
 
@@ -396,7 +504,7 @@ func NewPRVersion(s string) (PRVersion, error) {
 		v.VersionStr = s
 		v.IsNum = false
 	} else {
-		return PRVersion{}, fmt.Errorf("Invalid character(s) found in prerelease %q", s)
+		return PRVersion{}, fmt.Errorf("Invalid character(s) found in prerelease %s", quote(s))
 	}
 	return v, nil
 }
@@ -458,7 +566,7 @@ func NewBuildVersion(s string) (string, error) {
 		return "", errors.New("Buildversion is empty")
 	}
 	if !containsOnly(s, alphanum) {
-		return "", fmt.Errorf("Invalid character(s) found in build meta data %q", s)
+		return "", fmt.Errorf("Invalid character(s) found in build meta data %s", quote(s))
 	}
 	return s, nil
 }
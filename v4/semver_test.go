@@ -1,6 +1,8 @@
 package semver
 
 import (
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -235,6 +237,56 @@ var wrongTolerantFormatTests = []wrongformatTest{
 	{nil, "1.0-rc.1"},
 }
 
+func TestNumberError(t *testing.T) {
+	tests := []struct {
+		s         string
+		tolerant  bool
+		component string
+		cause     error
+	}{
+		{"01.2.3", false, "major", ErrLeadingZeroes},
+		{"1.02.3", false, "minor", ErrLeadingZeroes},
+		{"1.2.03", false, "patch", ErrLeadingZeroes},
+		{"1e3.2.1", false, "major", ErrExponent},
+		{"1.2E-1.0", false, "minor", ErrExponent},
+		{"1.2.3e+5", false, "patch", ErrExponent},
+		{"1e3.2.1", true, "major", ErrExponent},
+		{"v1.2e3", true, "minor", ErrExponent},
+	}
+	for _, tc := range tests {
+		var err error
+		if tc.tolerant {
+			_, err = ParseTolerant(tc.s)
+		} else {
+			_, err = Parse(tc.s)
+		}
+		var nerr *NumberError
+		if !errors.As(err, &nerr) {
+			t.Errorf("Invalid for case %q: Expected *NumberError, got: %v", tc.s, err)
+			continue
+		}
+		if nerr.Component != tc.component || !errors.Is(err, tc.cause) {
+			t.Errorf("Invalid for case %q: Expected %s number error %q, got: %q", tc.s, tc.component, tc.cause, err)
+		}
+	}
+
+	// Ranges wrap the error of the version
+	_, err := ParseRangeWithOptions(">=1.02.3", RangeOptions{Tolerant: true})
+	if !errors.Is(err, ErrLeadingZeroes) {
+		t.Errorf("Expected ErrLeadingZeroes, got: %v", err)
+	}
+
+	// Leading zeroes are removed in tolerant mode, but not an exponent
+	if v, err := ParseTolerant("01.02.03"); err != nil || v.String() != "1.2.3" {
+		t.Errorf("Expected 1.2.3, got: %s (%v)", v, err)
+	}
+
+	err = &NumberError{Component: "major", Number: "1e3", Err: ErrExponent}
+	if s := err.Error(); s != `major number must not use scientific notation "1e3"` {
+		t.Errorf("Unexpected error message: %s", s)
+	}
+}
+
 func TestWrongTolerantFormat(t *testing.T) {
 	for _, test := range wrongTolerantFormatTests {
 		if res, err := ParseTolerant(test.str); err == nil {
@@ -279,6 +331,10 @@ func TestCompareHelper(t *testing.T) {
 	if !v1.GE(v) {
 		t.Errorf("%q should be greater than or equal %q", v1, v)
 	}
+	b1, b2 := MustParse("1.0.0+build.1"), MustParse("1.0.0+build.2")
+	if !b1.EQ(b2) || b1.NE(b2) || b1.GT(b2) || b1.LT(b2) {
+		t.Errorf("%q and %q should compare equal", b1, b2)
+	}
 }
 
 const (
@@ -339,6 +395,28 @@ func TestIncrements(t *testing.T) {
 	}
 }
 
+func TestIncrementOverflow(t *testing.T) {
+	tests := []struct {
+		v         Version
+		increment func(*Version) error
+		component string
+	}{
+		{Version{1, 2, math.MaxUint64, nil, nil}, (*Version).IncrementPatch, "patch"},
+		{Version{1, math.MaxUint64, 3, nil, nil}, (*Version).IncrementMinor, "minor"},
+		{Version{math.MaxUint64, 2, 3, nil, nil}, (*Version).IncrementMajor, "major"},
+	}
+	for _, test := range tests {
+		original := test.v
+		err := test.increment(&test.v)
+		if oe, ok := err.(*OverflowError); !ok || oe.Component != test.component {
+			t.Errorf("Increment %q: expected %s overflow error, got %v", original, test.component, err)
+		}
+		if test.v.NE(original) {
+			t.Errorf("Increment %q: version must be unchanged on overflow, got %q", original, test.v)
+		}
+	}
+}
+
 func TestPreReleaseVersions(t *testing.T) {
 	p1, err := NewPRVersion("123")
 	if !p1.IsNumeric() {
@@ -0,0 +1,15 @@
+// Command semvercheck reports misuse of package semver, see package
+// semvercheck:
+//
+//	go install github.com/Jarred-Sumner/semver/v4/semvercheck/cmd/semvercheck@latest
+//	semvercheck ./...
+package main
+
+import (
+	"github.com/Jarred-Sumner/semver/v4/semvercheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(semvercheck.Analyzer)
+}
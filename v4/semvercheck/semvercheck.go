@@ -0,0 +1,203 @@
+// Package semvercheck provides an analyzer reporting common misuse of
+// package semver:
+//
+//   - discarding the error of a parse function, e.g. r, _ := semver.ParseRange(s),
+//     which leaves a nil Range that panics when called,
+//   - comparing versions with == or !=, either as *Version pointers, which
+//     compares identities, or by their String results, which differ for
+//     versions of the same precedence with different build meta data,
+//   - parsing a constant range inside a loop, which repeats the work of
+//     parsing on every iteration.
+//
+// Run it with the semvercheck command or add Analyzer to a vet tool built
+// with golang.org/x/tools/go/analysis/multichecker.
+package semvercheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const semverPath = "github.com/Jarred-Sumner/semver/v4"
+
+// Analyzer reports misuse of package semver.
+var Analyzer = &analysis.Analyzer{
+	Name:     "semvercheck",
+	Doc:      "report misuse of github.com/Jarred-Sumner/semver/v4",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// parseFuncs are the functions of package semver returning a parse error.
+var parseFuncs = map[string]bool{
+	"Parse":                       true,
+	"ParseTolerant":               true,
+	"ParseRange":                  true,
+	"ParseRangeWithOptions":       true,
+	"ParseConstraints":            true,
+	"ParseConstraintsWithOptions": true,
+	"NewPRVersion":                true,
+	"NewBuildVersion":             true,
+}
+
+// rangeFuncs are the functions of package semver parsing a range.
+var rangeFuncs = map[string]bool{
+	"ParseRange":                  true,
+	"ParseRangeWithOptions":       true,
+	"MustParseRange":              true,
+	"ParseConstraints":            true,
+	"ParseConstraintsWithOptions": true,
+	"MustParseConstraints":        true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	insp.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 && len(n.Lhs) == 2 {
+				checkDiscardedError(pass, n.Rhs[0], n.Lhs[1])
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 && len(n.Names) == 2 {
+				checkDiscardedError(pass, n.Values[0], n.Names[1])
+			}
+		case *ast.ExprStmt:
+			if name := semverFunc(pass, n.X); parseFuncs[name] {
+				pass.Reportf(n.Pos(), "result and error of semver.%s are discarded", name)
+			}
+		case *ast.BinaryExpr:
+			checkComparison(pass, n)
+		case *ast.CallExpr:
+			checkParseInLoop(pass, n, stack)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkDiscardedError reports the assignment of the error of a parse
+// function to the blank identifier.
+func checkDiscardedError(pass *analysis.Pass, call ast.Expr, errExpr ast.Expr) {
+	name := semverFunc(pass, call)
+	if !parseFuncs[name] {
+		return
+	}
+	id, ok := errExpr.(*ast.Ident)
+	if !ok || id.Name != "_" {
+		return
+	}
+	switch name {
+	case "Parse", "ParseRange", "ParseConstraints":
+		pass.Reportf(errExpr.Pos(), "error of semver.%s is discarded, use semver.Must%s for constant input", name, name)
+	default:
+		pass.Reportf(errExpr.Pos(), "error of semver.%s is discarded", name)
+	}
+}
+
+// checkComparison reports comparisons of versions with == and !=.
+func checkComparison(pass *analysis.Pass, n *ast.BinaryExpr) {
+	if n.Op != token.EQL && n.Op != token.NEQ {
+		return
+	}
+	if isVersionPointer(pass.TypesInfo.TypeOf(n.X)) && isVersionPointer(pass.TypesInfo.TypeOf(n.Y)) {
+		pass.Reportf(n.OpPos, "comparison of *semver.Version pointers with %s compares identities, use Version.Equals", n.Op)
+		return
+	}
+	if isVersionString(pass, n.X) && isVersionString(pass, n.Y) {
+		pass.Reportf(n.OpPos, "comparison of semver.Version strings with %s takes build meta data into account, use Version.Equals", n.Op)
+	}
+}
+
+func isVersionPointer(t types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	return ok && isSemverType(p.Elem(), "Version")
+}
+
+// isVersionString checks if e is a call of the String method of a Version.
+func isVersionString(pass *analysis.Pass, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "String" {
+		return false
+	}
+	t := pass.TypesInfo.TypeOf(sel.X)
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	return isSemverType(t, "Version")
+}
+
+func isSemverType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == semverPath
+}
+
+// checkParseInLoop reports parsing a constant range inside a loop, the
+// enclosing function literal ends the search for a loop.
+func checkParseInLoop(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	name := semverFunc(pass, call)
+	if !rangeFuncs[name] || len(call.Args) == 0 {
+		return
+	}
+	if tv, ok := pass.TypesInfo.Types[call.Args[0]]; !ok || tv.Value == nil {
+		return
+	}
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			pass.Reportf(call.Pos(), "semver.%s parses a constant range in every iteration, parse it once outside of the loop", name)
+			return
+		case *ast.FuncLit, *ast.FuncDecl:
+			return
+		}
+	}
+}
+
+// semverFunc returns the name of the package semver function called by e,
+// or "".
+func semverFunc(pass *analysis.Pass, e ast.Expr) string {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return ""
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != semverPath {
+		return ""
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return ""
+	}
+	return fn.Name()
+}
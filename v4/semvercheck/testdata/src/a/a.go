@@ -0,0 +1,42 @@
+package a
+
+import "github.com/Jarred-Sumner/semver/v4"
+
+func discarded(s string) {
+	r, _ := semver.ParseRange(s) // want `error of semver.ParseRange is discarded, use semver.MustParseRange for constant input`
+	_ = r
+	var v, _ = semver.Parse(s) // want `error of semver.Parse is discarded`
+	_ = v
+	semver.ParseConstraints(s) // want `result and error of semver.ParseConstraints are discarded`
+
+	r, err := semver.ParseRange(s)
+	_, _ = r, err
+}
+
+func compared(a, b semver.Version, p, q *semver.Version) bool {
+	_ = p == q                   // want `comparison of \*semver.Version pointers with == compares identities`
+	_ = a.String() != b.String() // want `comparison of semver.Version strings with != takes build meta data into account`
+	_ = p.String() == "1.0.0"
+	_ = p == nil
+	return a.Equals(b)
+}
+
+func loops(versions []semver.Version, ranges []string) {
+	for _, v := range versions {
+		_ = semver.MustParseRange(">=1.0.0")(v) // want `semver.MustParseRange parses a constant range in every iteration`
+	}
+	for i := 0; i < 3; i++ {
+		if r, err := semver.ParseRange("^1.2.3"); err == nil { // want `semver.ParseRange parses a constant range in every iteration`
+			_ = r
+		}
+	}
+	for _, s := range ranges {
+		_ = semver.MustParseRange(s)
+	}
+	r := semver.MustParseRange(">=1.0.0")
+	for _, v := range versions {
+		_ = r(v)
+		f := func() { _ = semver.MustParseRange("<2.0.0") }
+		f()
+	}
+}
@@ -0,0 +1,73 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+)
+
+// semVerOnlyOperators are the operators admitted by RangeOptions.SemVerOnly.
+var semVerOnlyOperators = map[string]bool{
+	"=":  true,
+	"!=": true,
+	">":  true,
+	">=": true,
+	"<":  true,
+	"<=": true,
+}
+
+// checkSemVerOnly checks that the range s uses only pure SemVer 2.0.0
+// constructs, see RangeOptions.SemVerOnly. The structure of the range is
+// checked by scanORParts afterwards.
+func checkSemVerOnly(s string) error {
+	tokens, err := scanRange(s)
+	if err != nil {
+		return err
+	}
+	sc := &rangeScanner{s: s}
+	for _, t := range tokens {
+		switch t.Kind {
+		case TokenOperator:
+			if !semVerOnlyOperators[t.Text] {
+				return sc.errorf(ErrInvalidOperator, t.Offset, t.Text, "operator %s is not allowed in SemVer-only mode", quote(t.Text))
+			}
+		case TokenHyphen:
+			return sc.errorf(ErrInvalidOperator, t.Offset, t.Text, "hyphen range is not allowed in SemVer-only mode")
+		case TokenOpenParen, TokenCloseParen:
+			return sc.errorf(ErrUnexpectedToken, t.Offset, t.Text, "grouping is not allowed in SemVer-only mode")
+		case TokenVersion:
+			if _, err := Parse(t.Text); err != nil || !hasFullCore(t.Text) {
+				return sc.errorf(ErrInvalidVersion, t.Offset, t.Text, "%s is not a SemVer 2.0.0 version", quote(t.Text))
+			}
+		}
+	}
+	return nil
+}
+
+// hasFullCore checks if the version s consists of three numbers before its
+// prerelease and build meta data, Parse also accepts partial versions and
+// wildcards.
+func hasFullCore(s string) bool {
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	for _, p := range parts {
+		if p == "" || !containsOnly(p, numbers) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseFull parses a version like Parse, but requires all of major, minor
+// and patch number.
+func parseFull(s string) (Version, error) {
+	v, err := Parse(s)
+	if err == nil && !hasFullCore(s) {
+		err = errors.New("major, minor and patch number required")
+	}
+	return v, err
+}
@@ -0,0 +1,96 @@
+package semver
+
+import "testing"
+
+func TestSemVerOnly(t *testing.T) {
+	valid := []string{
+		"1.2.3",
+		"=1.2.3",
+		"!=1.2.3",
+		">1.2.3",
+		">=1.2.3-alpha.1",
+		"<1.2.3+build.5",
+		"<=1.2.3-rc.1+build",
+		">=1.0.0 <2.0.0",
+		"1.0.0 || >=2.0.0 <3.0.0-0",
+	}
+	for _, s := range valid {
+		if _, err := ParseRangeWithOptions(s, RangeOptions{SemVerOnly: true}); err != nil {
+			t.Errorf("Invalid for case %q: unexpected error: %s", s, err)
+		}
+	}
+
+	invalid := []string{
+		// v-prefix
+		"v1.2.3",
+		"=v1.2.3",
+		"V1.2.3",
+		// partial versions
+		"1",
+		"1.2",
+		">=1.2",
+		"<2",
+		// wildcards
+		"*",
+		"x",
+		"1.x",
+		"1.2.x",
+		"1.2.*",
+		">=1.x",
+		// operators outside of the spec
+		"~1.2.3",
+		"~>1.2.3",
+		"^1.2.3",
+		"==1.2.3",
+		"!1.2.3",
+		// hyphen ranges
+		"1.2.3 - 2.3.4",
+		"1.0.0 - 2",
+		// versions violating the spec
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.3-01",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-alpha..1",
+		"1.2.3.4",
+		// structure
+		"",
+		"||",
+		"1.2.3 ||",
+		">=",
+		// extensions mixed with valid comparators
+		">=1.0.0 <2",
+		"1.0.0 || ^2.0.0",
+		">=1.0.0 1.x",
+	}
+	for _, s := range invalid {
+		if _, err := ParseRangeWithOptions(s, RangeOptions{SemVerOnly: true}); err == nil {
+			t.Errorf("Invalid for case %q: Expected error", s)
+		}
+		if _, err := ParseRangeWithOptions(s, RangeOptions{SemVerOnly: true, Tolerant: true}); err == nil {
+			t.Errorf("Invalid for case %q: Expected error in tolerant mode", s)
+		}
+	}
+}
+
+func TestSemVerOnlyErrorOffset(t *testing.T) {
+	tests := []struct {
+		r      string
+		offset int
+	}{
+		{">=1.0.0 ^2.0.0", 8},
+		{">=1.0.0 <2", 9},
+		{"1.0.0 - 2.0.0", 6},
+	}
+	for _, tc := range tests {
+		_, err := ParseRangeWithOptions(tc.r, RangeOptions{SemVerOnly: true})
+		serr, ok := err.(*RangeSyntaxError)
+		if !ok {
+			t.Errorf("Invalid for case %q: Expected RangeSyntaxError, got: %v", tc.r, err)
+		} else if serr.Offset != tc.offset {
+			t.Errorf("Invalid for case %q: Expected offset %d, got: %d", tc.r, tc.offset, serr.Offset)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Query is a recorded VersionSource query and its result.
+type Query struct {
+	Name     string
+	Versions []Version
+	Err      error
+}
+
+// Session is a VersionSource recording every query it forwards to the
+// underlying source. Each package is queried once per Session if the query
+// succeeds, concurrent queries for the same package wait for the first one.
+// Failed queries, e.g. of a cancelled context, are recorded but not kept, the
+// next query for the package asks the source again. The recorded queries can
+// be replayed with Replay, which makes resolvers using a Session
+// reproducible in tests without access to the registry.
+type Session struct {
+	src      VersionSource
+	mu       sync.Mutex
+	queries  []Query
+	versions map[string][]Version
+	inflight map[string]*sessionCall
+}
+
+// sessionCall is a query of a Session in progress, done is closed once
+// versions and err are set.
+type sessionCall struct {
+	done     chan struct{}
+	versions []Version
+	err      error
+}
+
+// NewSession creates a Session querying src.
+func NewSession(src VersionSource) *Session {
+	return &Session{
+		src:      src,
+		versions: make(map[string][]Version),
+		inflight: make(map[string]*sessionCall),
+	}
+}
+
+// Versions implements the VersionSource interface. The returned slice is a
+// copy of the recorded one.
+func (s *Session) Versions(ctx context.Context, name string) ([]Version, error) {
+	for {
+		s.mu.Lock()
+		if versions, ok := s.versions[name]; ok {
+			s.mu.Unlock()
+			return copyVersions(versions), nil
+		}
+		call, ok := s.inflight[name]
+		if !ok {
+			break
+		}
+		s.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if call.err == nil {
+			return copyVersions(call.versions), nil
+		}
+		// The query failed, maybe only for the context of its caller
+	}
+	call := &sessionCall{done: make(chan struct{})}
+	s.inflight[name] = call
+	s.mu.Unlock()
+
+	versions, err := s.src.Versions(ctx, name)
+	if err == nil {
+		versions = copyVersions(versions)
+	} else {
+		versions = nil
+	}
+
+	s.mu.Lock()
+	delete(s.inflight, name)
+	s.queries = append(s.queries, Query{Name: name, Versions: versions, Err: err})
+	if err == nil {
+		s.versions[name] = versions
+	}
+	s.mu.Unlock()
+
+	call.versions, call.err = versions, err
+	close(call.done)
+	if err != nil {
+		return nil, err
+	}
+	return copyVersions(versions), nil
+}
+
+func copyVersions(versions []Version) []Version {
+	if versions == nil {
+		return nil
+	}
+	return append([]Version(nil), versions...)
+}
+
+// Queries returns the recorded queries in the order they were made.
+func (s *Session) Queries() []Query {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queries := make([]Query, len(s.queries))
+	for i, q := range s.queries {
+		q.Versions = copyVersions(q.Versions)
+		queries[i] = q
+	}
+	return queries
+}
+
+// Replay returns a VersionSource answering the recorded queries. Queries
+// for packages which were not recorded fail.
+func (s *Session) Replay() VersionSource {
+	return ReplaySource(s.Queries())
+}
+
+// ReplaySource is a VersionSource answering from recorded queries. A package
+// queried more than once, because earlier queries failed, is answered by the
+// last query.
+type ReplaySource []Query
+
+// Versions implements the VersionSource interface.
+func (s ReplaySource) Versions(ctx context.Context, name string) ([]Version, error) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if q := s[i]; q.Name == name {
+			return copyVersions(q.Versions), q.Err
+		}
+	}
+	return nil, fmt.Errorf("query for package %q was not recorded", name)
+}
@@ -0,0 +1,157 @@
+package semver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type countingSource struct {
+	MemorySource
+	calls int
+}
+
+func (s *countingSource) Versions(ctx context.Context, name string) ([]Version, error) {
+	s.calls++
+	return s.MemorySource.Versions(ctx, name)
+}
+
+func TestSession(t *testing.T) {
+	src := &countingSource{MemorySource: MemorySource{
+		"foo": {MustParse("1.0.0"), MustParse("1.1.0")},
+	}}
+	session := NewSession(src)
+	ctx := context.Background()
+
+	v, err := ResolveFrom(ctx, session, "foo", MustParseRange("^1.0.0"))
+	if err != nil || !v.EQ(MustParse("1.1.0")) {
+		t.Fatalf("Expected 1.1.0, got %q, %v", v, err)
+	}
+	if _, err := ResolveFrom(ctx, session, "foo", MustParseRange("1.0.0")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.Versions(ctx, "missing"); err == nil {
+		t.Error("Expected error for unknown package")
+	}
+	if src.calls != 2 {
+		t.Errorf("Expected 2 queries to the source, got %d", src.calls)
+	}
+
+	queries := session.Queries()
+	if len(queries) != 2 || queries[0].Name != "foo" || queries[1].Name != "missing" || queries[1].Err == nil {
+		t.Errorf("Unexpected recorded queries: %v", queries)
+	}
+
+	replay := session.Replay()
+	v, err = ResolveFrom(ctx, replay, "foo", MustParseRange("^1.0.0"))
+	if err != nil || !v.EQ(MustParse("1.1.0")) {
+		t.Errorf("Replay: expected 1.1.0, got %q, %v", v, err)
+	}
+	if _, err := replay.Versions(ctx, "missing"); err == nil {
+		t.Error("Replay: expected recorded error")
+	}
+	if _, err := replay.Versions(ctx, "other"); err == nil {
+		t.Error("Replay: expected error for unrecorded query")
+	}
+	if src.calls != 2 {
+		t.Errorf("Replay must not query the source, got %d calls", src.calls)
+	}
+}
+
+// flakySource fails the first query of every package.
+type flakySource struct {
+	MemorySource
+	failed map[string]bool
+}
+
+func (s *flakySource) Versions(ctx context.Context, name string) ([]Version, error) {
+	if !s.failed[name] {
+		s.failed[name] = true
+		return nil, errors.New("registry unavailable")
+	}
+	return s.MemorySource.Versions(ctx, name)
+}
+
+func TestSessionFailedQuery(t *testing.T) {
+	src := &flakySource{MemorySource: MemorySource{"foo": {MustParse("1.0.0")}}, failed: map[string]bool{}}
+	session := NewSession(src)
+	ctx := context.Background()
+	if _, err := session.Versions(ctx, "foo"); err == nil {
+		t.Fatal("Expected the first query to fail")
+	}
+	versions, err := session.Versions(ctx, "foo")
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("Expected the failed query to be retried, got %v, %v", versions, err)
+	}
+	versions[0] = MustParse("9.9.9")
+	if versions, _ := session.Versions(ctx, "foo"); !versions[0].EQ(MustParse("1.0.0")) {
+		t.Errorf("Callers must not modify the recorded versions, got %q", versions[0])
+	}
+
+	queries := session.Queries()
+	if len(queries) != 2 || queries[0].Err == nil || queries[1].Err != nil {
+		t.Errorf("Unexpected recorded queries: %v", queries)
+	}
+	if versions, err := session.Replay().Versions(ctx, "foo"); err != nil || len(versions) != 1 {
+		t.Errorf("Replay: expected the successful query, got %v, %v", versions, err)
+	}
+}
+
+// blockingSource answers queries for "slow" once release is closed.
+type blockingSource struct {
+	MemorySource
+	release chan struct{}
+	mu      sync.Mutex
+	calls   map[string]int
+}
+
+func (s *blockingSource) Versions(ctx context.Context, name string) ([]Version, error) {
+	s.mu.Lock()
+	s.calls[name]++
+	s.mu.Unlock()
+	if name == "slow" {
+		<-s.release
+	}
+	return s.MemorySource.Versions(ctx, name)
+}
+
+func TestSessionConcurrent(t *testing.T) {
+	src := &blockingSource{
+		MemorySource: MemorySource{"slow": {MustParse("1.0.0")}, "fast": {MustParse("2.0.0")}},
+		release:      make(chan struct{}),
+		calls:        map[string]int{},
+	}
+	session := NewSession(src)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if versions, err := session.Versions(ctx, "slow"); err != nil || len(versions) != 1 {
+				t.Errorf("Expected 1.0.0, got %v, %v", versions, err)
+			}
+		}()
+	}
+	for inflight := false; !inflight; {
+		session.mu.Lock()
+		_, inflight = session.inflight["slow"]
+		session.mu.Unlock()
+	}
+	// A query in progress must not block queries for other packages
+	if _, err := session.Versions(ctx, "fast"); err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := session.Versions(cancelled, "slow"); err != context.Canceled {
+		t.Errorf("Expected a waiting query to end with its context, got %v", err)
+	}
+	close(src.release)
+	wg.Wait()
+	if src.calls["slow"] != 1 || src.calls["fast"] != 1 {
+		t.Errorf("Expected one query per package, got %v", src.calls)
+	}
+}
@@ -0,0 +1,155 @@
+package semver
+
+// Simplify returns an equivalent range with the fewest comparators, see
+// Constraints.Simplify, e.g. ">=1.0.0 <2.0.0" for
+// ">=1.0.0 <2.0.0 || >=1.5.0 <1.8.0". It is useful to deduplicate
+// constraints collected from many manifests. A Range which can not be
+// inspected is returned unchanged.
+func (rf Range) Simplify() Range {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return rf
+	}
+	return c.Simplify().Range()
+}
+
+// Simplify returns equivalent constraints with the fewest comparators: sets
+// which overlap or adjoin are merged, comparators implied by others are
+// dropped and sets no version satisfies are removed, e.g.
+// ">=1.0.0 <2.0.0 || >=1.5.0 <1.8.0" becomes ">=1.0.0 <2.0.0" and
+// ">=1.2.0 >=1.0.0 <2.0.0" becomes ">=1.2.0 <2.0.0". Equivalent constraints
+// simplify to the same String, so it can be used as the canonical form.
+//
+// Constraints using the prerelease rule of RangeOptions.NPMCompat are only
+// simplified if the rule admits the same prereleases afterwards, as merged
+// sets lose the prerelease versions of the dropped comparators.
+func (c *Constraints) Simplify() *Constraints {
+	s := &Constraints{npm: c.npm}
+	intervals := c.intervals()
+	for i := 0; i < len(intervals); {
+		// Intervals separated by a single version form one set excluding it
+		j := i + 1
+		for j < len(intervals) && excludesOnly(intervals[j-1], intervals[j]) {
+			j++
+		}
+		s.sets = append(s.sets, intervalSet(intervals[i:j]))
+		i = j
+	}
+	if len(s.sets) == 0 {
+		// No version is below the lowest version
+		s.sets = [][]versionRange{{newVersionRange(OpLT, lowestPrerelease(Version{}))}}
+	}
+	if c.npm && !samePrereleaseRule(c, s) {
+		return c
+	}
+	return s
+}
+
+// excludesOnly checks if a and b, a starting first, are separated by a
+// single version.
+func excludesOnly(a, b Interval) bool {
+	return !a.Upper.Unbounded && !a.Upper.Inclusive && !b.Lower.Unbounded && !b.Lower.Inclusive &&
+		a.Upper.Version.Equals(b.Lower.Version)
+}
+
+// intervalSet returns the comparators of the versions within the given
+// intervals, each separated from the next by a single version.
+func intervalSet(intervals []Interval) []versionRange {
+	first, last := intervals[0], intervals[len(intervals)-1]
+	if len(intervals) == 1 && !first.Lower.Unbounded && !first.Upper.Unbounded &&
+		first.Lower.Inclusive && first.Upper.Inclusive && first.Lower.Version.Equals(first.Upper.Version) {
+		return []versionRange{newVersionRange(OpEQ, first.Lower.Version)}
+	}
+
+	var set []versionRange
+	switch {
+	case !first.Lower.Unbounded && first.Lower.Inclusive:
+		set = append(set, newVersionRange(OpGTE, first.Lower.Version))
+	case !first.Lower.Unbounded:
+		set = append(set, newVersionRange(OpGT, first.Lower.Version))
+	case first.Upper.Unbounded && len(intervals) == 1:
+		// Every version is at least the lowest version
+		set = append(set, newVersionRange(OpGTE, lowestPrerelease(Version{})))
+	}
+	switch {
+	case !last.Upper.Unbounded && last.Upper.Inclusive:
+		set = append(set, newVersionRange(OpLTE, last.Upper.Version))
+	case !last.Upper.Unbounded:
+		set = append(set, newVersionRange(OpLT, last.Upper.Version))
+	}
+	for _, i := range intervals[1:] {
+		set = append(set, newVersionRange(OpNE, i.Lower.Version))
+	}
+	return set
+}
+
+// newVersionRange returns the comparator op v, build meta data has no
+// precedence and is dropped.
+func newVersionRange(op Operator, v Version) versionRange {
+	v.Build = nil
+	return versionRange{v: v, c: op.comparator(), op: op}
+}
+
+// lowestPrerelease returns the lowest prerelease version of v's major, minor
+// and patch version, e.g. 1.2.3-0 for 1.2.3.
+func lowestPrerelease(v Version) Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: []PRVersion{{IsNum: true}}}
+}
+
+// samePrereleaseRule checks if the prerelease rule of RangeOptions.NPMCompat
+// admits the same prerelease versions for a and b, which satisfy the same
+// versions by precedence.
+func samePrereleaseRule(a, b *Constraints) bool {
+	anchors := map[VersionKey]bool{}
+	for _, c := range []*Constraints{a, b} {
+		for _, set := range c.sets {
+			for _, vr := range set {
+				if len(vr.v.Pre) > 0 {
+					anchors[Version{Major: vr.v.Major, Minor: vr.v.Minor, Patch: vr.v.Patch}.Key()] = true
+				}
+			}
+		}
+	}
+	for key := range anchors {
+		release := key.Version()
+		// The prerelease versions of release, from the lowest one up to the
+		// release itself
+		prereleases := Interval{
+			Lower: Endpoint{Version: lowestPrerelease(release), Inclusive: true},
+			Upper: Endpoint{Version: release},
+		}
+		if !sameIntervals(anchoredIntervals(a, release, prereleases), anchoredIntervals(b, release, prereleases)) {
+			return false
+		}
+	}
+	return true
+}
+
+// anchoredIntervals returns the intervals within prereleases satisfying the
+// sets of c which admit the prerelease versions of release.
+func anchoredIntervals(c *Constraints, release Version, prereleases Interval) []Interval {
+	var all []Interval
+	for _, set := range c.sets {
+		if !hasPrereleaseAnchor(set, release) {
+			continue
+		}
+		for _, i := range setIntervals(set) {
+			all = append(all, i.Intersect(prereleases))
+		}
+	}
+	return mergeIntervals(all)
+}
+
+// sameIntervals checks if a and b, both normalized, contain the same
+// versions.
+func sameIntervals(a, b []Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if compareLower(a[i].Lower, b[i].Lower) != 0 || compareUpper(a[i].Upper, b[i].Upper) != 0 {
+			return false
+		}
+	}
+	return true
+}
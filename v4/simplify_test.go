@@ -0,0 +1,96 @@
+package semver
+
+import "testing"
+
+func TestConstraintsSimplify(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{">=1.0.0 <2.0.0 || >=1.5.0 <1.8.0", ">=1.0.0 <2.0.0"},
+		{">=1.0.0 <2.0.0 || >=1.5.0", ">=1.0.0"},
+		{">=1.2.0 >=1.0.0 <2.0.0", ">=1.2.0 <2.0.0"},
+		{"^1.2.3 || ^1.5.0 || ~1.9.0", ">=1.2.3 <2.0.0"},
+		{"^1.2.3 || ^2.0.0", ">=1.2.3 <3.0.0"},
+		{"<1.0.0 || >=2.0.0 || <0.5.0", "<1.0.0 || >=2.0.0"},
+		{">=1.0.0 <=1.0.0", "1.0.0"},
+		{"1.0.0 || 1.0.0", "1.0.0"},
+		{">1.0.0 <3.0.0 !=2.0.0 || 2.0.0", ">1.0.0 <3.0.0"},
+		{">=1.0.0 <3.0.0 !=2.0.0 !=2.5.0", ">=1.0.0 <3.0.0 !=2.0.0 !=2.5.0"},
+		{"<1.0.0 || >1.0.0", "!=1.0.0"},
+		{"<1.0.0 || >=1.0.0", ">=0.0.0-0"},
+		{">2.0.0 <1.0.0", "<0.0.0-0"},
+		{">2.0.0 <1.0.0 || ^3.0.0", ">=3.0.0 <4.0.0"},
+		{">=1.0.0+build <2.0.0", ">=1.0.0 <2.0.0"},
+	}
+	for _, tc := range tests {
+		s := MustParseConstraints(tc.r).Simplify()
+		if got := s.String(); got != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, got)
+		}
+		// The simplified form is stable and reads back as the same constraints
+		if got := MustParseConstraints(tc.s).Simplify().String(); got != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q to be canonical, got: %q", tc.r, tc.s, got)
+		}
+	}
+}
+
+func TestConstraintsSimplifyEquivalent(t *testing.T) {
+	ranges := []string{
+		">=1.0.0 <2.0.0 || >=1.5.0",
+		">1.0.0 <3.0.0 !=2.0.0 || 2.0.0",
+		"<1.0.0 || >1.0.0",
+		">=1.2.3-beta.2 <1.3.0 || ~1.2.5",
+		"^0.2.3 || 1.x || >=3.0.0-rc.1",
+	}
+	versions := parseVersions("0.0.0-0", "0.2.3", "0.2.9", "0.3.0", "1.0.0", "1.2.3-beta.1", "1.2.3-beta.2", "1.2.3",
+		"1.2.5", "1.3.0", "1.5.0", "2.0.0", "2.5.0", "3.0.0-rc.0", "3.0.0-rc.1", "3.0.0", "9.0.0")
+	for _, r := range ranges {
+		for _, npm := range []bool{false, true} {
+			c, err := ParseConstraintsWithOptions(r, RangeOptions{NPMCompat: npm})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := c.Simplify()
+			for _, v := range versions {
+				if c.Check(v) != s.Check(v) {
+					t.Errorf("Invalid for case %q (npm %v): Expected %q for %s, got: %v", r, npm, s, v, s.Check(v))
+				}
+			}
+		}
+	}
+}
+
+func TestConstraintsSimplifyNPMCompat(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		// The merged set keeps the prerelease versions of ^1.2.3
+		{"^1.2.3 || ^1.5.0", ">=1.2.3 <2.0.0-0"},
+		{"^1.2.3-beta || ^1.2.3", ">=1.2.3-beta <2.0.0-0"},
+		// Merging would admit 1.2.3-alpha.2 in the upper set
+		{">=1.0.0 <=1.2.3-beta || >=1.2.3-alpha <2.0.0", ">=1.0.0 <=1.2.3-beta || >=1.2.3-alpha <2.0.0"},
+	}
+	for _, tc := range tests {
+		c, err := ParseConstraintsWithOptions(tc.r, RangeOptions{NPMCompat: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Simplify().String(); got != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, got)
+		}
+	}
+}
+
+func TestRangeSimplify(t *testing.T) {
+	r := MustParseRange(">=1.0.0 <2.0.0 || >=1.5.0 <1.8.0").AND(MustParseRange(">=1.2.0"))
+	if got := r.Simplify().String(); got != ">=1.2.0 <2.0.0" {
+		t.Errorf("Expected %q, got: %q", ">=1.2.0 <2.0.0", got)
+	}
+
+	plain := Range(func(Version) bool { return true })
+	if got := plain.Simplify(); got == nil || !got(MustParse("1.0.0")) {
+		t.Errorf("Expected a Range which can not be inspected to be returned unchanged")
+	}
+}
@@ -0,0 +1,41 @@
+package semver
+
+import "strings"
+
+// slugOperators names the operators in a slug.
+var slugOperators = map[Operator]string{
+	OpEQ:  "eq",
+	OpNE:  "ne",
+	OpGT:  "gt",
+	OpGTE: "gte",
+	OpLT:  "lt",
+	OpLTE: "lte",
+}
+
+// Slug returns a deterministic identifier of the range which is safe to use
+// as a file name, e.g. to name cache directories per constraint: the
+// comparators of the normal form, see String, are written with a named
+// operator and joined by "_", sets are joined by "_or_". "^1.2 || 3.0.0"
+// becomes "gte1.2.0_lt2.0.0_or_eq3.0.0". Build meta data does not affect
+// which versions satisfy a range and is dropped. Slug returns "" if the
+// range can not be inspected.
+func (rf Range) Slug() string {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	for i, set := range c.sets {
+		if i > 0 {
+			b.WriteString("_or_")
+		}
+		for j, vr := range normalOrder(set) {
+			if j > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteString(slugOperators[vr.op])
+			b.WriteString(withoutBuild(vr.v).String())
+		}
+	}
+	return b.String()
+}
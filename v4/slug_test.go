@@ -0,0 +1,24 @@
+package semver
+
+import "testing"
+
+func TestRangeSlug(t *testing.T) {
+	tests := []struct {
+		r    Range
+		slug string
+	}{
+		{MustParseRange(">=1.2.0 <2.0.0"), "gte1.2.0_lt2.0.0"},
+		{MustParseRange("<2.0.0 >=1.2.0"), "gte1.2.0_lt2.0.0"},
+		{MustParseRange("^1.2 || 3.0.0"), "gte1.2.0_lt2.0.0_or_eq3.0.0"},
+		{MustParseRange(">1.0.0-beta.1 <=1.5.0 !=1.2.3"), "gt1.0.0-beta.1_lte1.5.0_ne1.2.3"},
+		{MustParseRange("1.2.3+build.5"), "eq1.2.3"},
+		{MustParseRange("*"), "gte0.0.0"},
+		{MustParseRange("1.x").AND(MustParseRange("!=1.5.0")), "gte1.0.0_lt2.0.0_ne1.5.0"},
+		{Range(func(Version) bool { return true }), ""},
+	}
+	for _, tc := range tests {
+		if s := tc.r.Slug(); s != tc.slug {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.slug, s)
+		}
+	}
+}
@@ -26,3 +26,27 @@ func (s Versions) Less(i, j int) bool {
 func Sort(versions []Version) {
 	sort.Sort(Versions(versions))
 }
+
+// FloorToAvailable returns the greatest version of available which is less
+// than or equal to v. available must be sorted in ascending order.
+func FloorToAvailable(v Version, available []Version) (Version, bool) {
+	i := sort.Search(len(available), func(i int) bool {
+		return available[i].GT(v)
+	})
+	if i == 0 {
+		return Version{}, false
+	}
+	return available[i-1], true
+}
+
+// CeilToAvailable returns the least version of available which is greater
+// than or equal to v. available must be sorted in ascending order.
+func CeilToAvailable(v Version, available []Version) (Version, bool) {
+	i := sort.Search(len(available), func(i int) bool {
+		return available[i].GTE(v)
+	})
+	if i == len(available) {
+		return Version{}, false
+	}
+	return available[i], true
+}
@@ -28,3 +28,37 @@ func BenchmarkSort(b *testing.B) {
 		Sort([]Version{v010, v100, v001})
 	}
 }
+
+func TestFloorCeilToAvailable(t *testing.T) {
+	available := []Version{
+		MustParse("1.0.0"),
+		MustParse("1.2.0-beta.1"),
+		MustParse("1.2.0"),
+		MustParse("2.0.0"),
+	}
+	tests := []struct {
+		v     string
+		floor string
+		ceil  string
+	}{
+		{"0.9.0", "", "1.0.0"},
+		{"1.0.0", "1.0.0", "1.0.0"},
+		{"1.1.0", "1.0.0", "1.2.0-beta.1"},
+		{"1.2.0-beta.2", "1.2.0-beta.1", "1.2.0"},
+		{"1.2.0", "1.2.0", "1.2.0"},
+		{"2.0.0", "2.0.0", "2.0.0"},
+		{"3.0.0", "2.0.0", ""},
+	}
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		if floor, ok := FloorToAvailable(v, available); ok != (tc.floor != "") || (ok && floor.String() != tc.floor) {
+			t.Errorf("Floor of %q: Expected %q, got %q (%t)", tc.v, tc.floor, floor, ok)
+		}
+		if ceil, ok := CeilToAvailable(v, available); ok != (tc.ceil != "") || (ok && ceil.String() != tc.ceil) {
+			t.Errorf("Ceil of %q: Expected %q, got %q (%t)", tc.v, tc.ceil, ceil, ok)
+		}
+	}
+	if _, ok := FloorToAvailable(MustParse("1.0.0"), nil); ok {
+		t.Error("Expected no floor in empty list")
+	}
+}
@@ -0,0 +1,46 @@
+package semver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoSatisfyingVersion is returned if none of the available versions
+// satisfies a range.
+var ErrNoSatisfyingVersion = errors.New("no version satisfies the range")
+
+// VersionSource lists the published versions of a package, e.g. from a
+// package registry. Selection helpers like ResolveFrom consume a
+// VersionSource so the same logic can be used with any registry. Package
+// registry implements it for npm compatible registries.
+type VersionSource interface {
+	Versions(ctx context.Context, name string) ([]Version, error)
+}
+
+// MemorySource is an in-memory VersionSource mapping package names to their
+// versions.
+type MemorySource map[string][]Version
+
+// Versions implements the VersionSource interface.
+func (s MemorySource) Versions(ctx context.Context, name string) ([]Version, error) {
+	versions, ok := s[name]
+	if !ok {
+		return nil, fmt.Errorf("package %q not found", name)
+	}
+	return versions, nil
+}
+
+// ResolveFrom returns the highest version of package name listed by src
+// which satisfies r.
+func ResolveFrom(ctx context.Context, src VersionSource, name string, r Range) (Version, error) {
+	available, err := Fetch(ctx, src, []string{name})
+	if err != nil {
+		return Version{}, err
+	}
+	decisions, err := Resolve(available, map[string]Range{name: r})
+	if err != nil {
+		return Version{}, err
+	}
+	return decisions[name], nil
+}
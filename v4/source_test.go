@@ -0,0 +1,27 @@
+package semver
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemorySource(t *testing.T) {
+	src := MemorySource{
+		"foo": {MustParse("1.0.0"), MustParse("1.2.0"), MustParse("2.0.0")},
+	}
+	v, err := ResolveFrom(context.Background(), src, "foo", MustParseRange("<2.0.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.EQ(MustParse("1.2.0")) {
+		t.Errorf("Expected 1.2.0, got %q", v)
+	}
+
+	if _, err := ResolveFrom(context.Background(), src, "foo", MustParseRange(">3.0.0")); !errors.Is(err, ErrNoSatisfyingVersion) {
+		t.Errorf("Expected ErrNoSatisfyingVersion, got %v", err)
+	}
+	if _, err := ResolveFrom(context.Background(), src, "bar", MustParseRange(">1.0.0")); err == nil {
+		t.Error("Expected error for unknown package")
+	}
+}
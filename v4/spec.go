@@ -0,0 +1,210 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SpecKind is the kind of a dependency specifier, see ParseSpec.
+type SpecKind int
+
+const (
+	// SpecRange is a semver range, e.g. "^1.2.3".
+	SpecRange SpecKind = iota
+	// SpecTag is a dist-tag, e.g. "latest".
+	SpecTag
+	// SpecAlias is an npm alias, e.g. "npm:other@^1.2.3".
+	SpecAlias
+	// SpecWorkspace is a workspace reference, e.g. "workspace:^1.2.3".
+	SpecWorkspace
+	// SpecCatalog is a pnpm catalog reference, e.g. "catalog:" or
+	// "catalog:react18".
+	SpecCatalog
+	// SpecPatch is a yarn patch, e.g. "patch:pkg@^1.2.3#./pkg.patch".
+	SpecPatch
+	// SpecFile is a local directory or tarball, e.g. "file:../pkg".
+	SpecFile
+	// SpecLink is a symlinked local directory, e.g. "link:../pkg".
+	SpecLink
+	// SpecGit is a git repository, e.g. "git+ssh://git@host/repo.git#semver:^1.2".
+	SpecGit
+	// SpecURL is a tarball URL, e.g. "https://host/pkg.tgz".
+	SpecURL
+	// SpecUnknown is any other specifier, e.g. an unsupported protocol.
+	SpecUnknown
+)
+
+var specKindNames = [...]string{"range", "tag", "alias", "workspace", "catalog", "patch", "file", "link", "git", "url", "unknown"}
+
+func (k SpecKind) String() string {
+	if k < 0 || int(k) >= len(specKindNames) {
+		return "unknown"
+	}
+	return specKindNames[k]
+}
+
+// Spec is a classified dependency specifier as found in package.json files.
+type Spec struct {
+	Kind SpecKind
+	Raw  string
+	// Protocol is the protocol prefix without colon, e.g. "catalog" or
+	// "git+ssh", or "" for ranges and tags.
+	Protocol string
+	// Target is what the specifier refers to: the package of an alias or a
+	// patch, the catalog name, the path, the repository or the URL.
+	Target string
+	// Range is the embedded semver range, e.g. from "#semver:^1.2" or
+	// "workspace:^1.2.3", or "" if there is none.
+	Range string
+}
+
+var gitProtocols = []string{"git", "git+ssh", "git+https", "git+http", "git+file", "github", "gitlab", "bitbucket", "gist"}
+
+// ParseSpec classifies the dependency specifier s and extracts the embedded
+// semver range if there is one. Unknown protocols are passed through as
+// SpecUnknown instead of failing, so that tools can handle specifiers of
+// newer package managers gracefully.
+func ParseSpec(s string) Spec {
+	spec := Spec{Raw: s}
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		spec.Range = "*"
+		return spec
+	}
+
+	i := strings.Index(trimmed, ":")
+	if i == -1 || !isProtocol(trimmed[:i]) {
+		if _, err := ParseConstraints(trimmed); err == nil {
+			spec.Range = trimmed
+		} else if strings.HasPrefix(trimmed, "./") || strings.HasPrefix(trimmed, "../") || strings.HasPrefix(trimmed, "/") || strings.HasPrefix(trimmed, "~/") {
+			spec.Kind = SpecFile
+			spec.Target = trimmed
+		} else if j := strings.Index(trimmed, "/"); j > 0 && !strings.HasPrefix(trimmed, "@") {
+			// GitHub shorthand "owner/repo#semver:^1.2"
+			spec.Kind = SpecGit
+			spec.Target, spec.Range = splitGitFragment(trimmed)
+		} else if isTagName(trimmed) {
+			spec.Kind = SpecTag
+			spec.Target = trimmed
+		} else {
+			spec.Kind = SpecUnknown
+		}
+		return spec
+	}
+
+	spec.Protocol, spec.Target = trimmed[:i], trimmed[i+1:]
+	switch spec.Protocol {
+	case "npm":
+		spec.Kind = SpecAlias
+		spec.Target, spec.Range = splitPackageRange(spec.Target)
+	case "workspace":
+		spec.Kind = SpecWorkspace
+		// "workspace:^" and "workspace:~" are shorthands for ranges based
+		// on the current workspace version, they are no ranges themselves.
+		if spec.Target != "^" && spec.Target != "~" {
+			spec.Range = spec.Target
+		}
+		spec.Target = ""
+	case "catalog":
+		spec.Kind = SpecCatalog
+	case "patch":
+		spec.Kind = SpecPatch
+		target := spec.Target
+		if j := strings.LastIndex(target, "#"); j != -1 {
+			target = target[:j]
+		}
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		spec.Target, spec.Range = splitPackageRange(target)
+		spec.Range = strings.TrimPrefix(spec.Range, "npm:")
+	case "file":
+		spec.Kind = SpecFile
+	case "link":
+		spec.Kind = SpecLink
+	case "http", "https":
+		spec.Kind = SpecURL
+		spec.Target = trimmed
+	default:
+		spec.Kind = SpecUnknown
+		for _, p := range gitProtocols {
+			if spec.Protocol == p {
+				spec.Kind = SpecGit
+				spec.Target, spec.Range = splitGitFragment(trimmed)
+			}
+		}
+	}
+	return spec
+}
+
+// ErrNoRange is returned by Spec.Constraint if a specifier has no semver
+// range.
+var ErrNoRange = errors.New("specifier has no semver range")
+
+// Constraint parses the embedded range of the specifier.
+func (s Spec) Constraint() (Range, error) {
+	if s.Range == "" {
+		return nil, ErrNoRange
+	}
+	return ParseRange(s.Range)
+}
+
+// splitPackageRange splits "name@range" into name and range, the name can
+// be scoped like "@scope/name".
+func splitPackageRange(s string) (name, r string) {
+	if i := strings.LastIndex(s, "@"); i > 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// splitGitFragment splits a git specifier into the repository and the range
+// of a "#semver:" fragment. Fragments can have several "::" separated parts,
+// e.g. "#semver:^1.2::path:packages/a".
+func splitGitFragment(s string) (repo, r string) {
+	i := strings.Index(s, "#")
+	if i == -1 {
+		return s, ""
+	}
+	repo = s[:i]
+	for _, part := range strings.Split(s[i+1:], "::") {
+		if strings.HasPrefix(part, "semver:") {
+			r = strings.TrimPrefix(part, "semver:")
+			if unescaped, err := url.PathUnescape(r); err == nil {
+				r = unescaped
+			}
+		}
+	}
+	return repo, r
+}
+
+// isProtocol checks if s is a protocol scheme like "git+ssh".
+func isProtocol(s string) bool {
+	return s != "" && containsOnly(s, "abcdefghijklmnopqrstuvwxyz0123456789+-.")
+}
+
+// isTagName checks if s can be a dist-tag: tags must not be valid ranges and
+// are URL safe.
+func isTagName(s string) bool {
+	return url.PathEscape(s) == s && !strings.ContainsAny(s, "/@")
+}
+
+// ParseGitSemverFragment parses a git dependency with a semver fragment like
+// "git+https://host/repo.git#semver:^2.1.0" and returns the repository and
+// the range to resolve against the tags of the repository. A "git+" prefix
+// is removed from the repository, so that it can be passed to git directly,
+// shorthands like "github:owner/repo" are returned unchanged. It returns
+// ErrNoRange if the fragment has no semver part.
+func ParseGitSemverFragment(gitURL string) (repo string, r Range, err error) {
+	spec := ParseSpec(gitURL)
+	if spec.Kind != SpecGit {
+		return "", nil, fmt.Errorf("not a git dependency %s", quote(gitURL))
+	}
+	r, err = spec.Constraint()
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimPrefix(spec.Target, "git+"), r, nil
+}
@@ -0,0 +1,83 @@
+package semver
+
+import "testing"
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		s        string
+		kind     SpecKind
+		protocol string
+		target   string
+		r        string
+	}{
+		{"^1.2.3", SpecRange, "", "", "^1.2.3"},
+		{">=1.0.0 <2.0.0 || 3.x", SpecRange, "", "", ">=1.0.0 <2.0.0 || 3.x"},
+		{"", SpecRange, "", "", "*"},
+		{"latest", SpecTag, "", "latest", ""},
+		{"next-11", SpecTag, "", "next-11", ""},
+		{"npm:@scope/other@^1.2.3", SpecAlias, "npm", "@scope/other", "^1.2.3"},
+		{"workspace:^1.2.3", SpecWorkspace, "workspace", "", "^1.2.3"},
+		{"workspace:*", SpecWorkspace, "workspace", "", "*"},
+		{"workspace:^", SpecWorkspace, "workspace", "", ""},
+		{"catalog:", SpecCatalog, "catalog", "", ""},
+		{"catalog:react18", SpecCatalog, "catalog", "react18", ""},
+		{"patch:pkg@^1#hash", SpecPatch, "patch", "pkg", "^1"},
+		{"patch:@scope/pkg@npm%3A^1.0.0#./.yarn/patches/pkg.patch", SpecPatch, "patch", "@scope/pkg", "^1.0.0"},
+		{"file:../pkg", SpecFile, "file", "../pkg", ""},
+		{"../pkg", SpecFile, "", "../pkg", ""},
+		{"link:../pkg", SpecLink, "link", "../pkg", ""},
+		{"git+ssh://git@github.com/owner/repo.git#semver:^1.2", SpecGit, "git+ssh", "git+ssh://git@github.com/owner/repo.git", "^1.2"},
+		{"git+https://host/repo.git#v1.0.0", SpecGit, "git+https", "git+https://host/repo.git", ""},
+		{"github:owner/repo#semver:~2.1::path:packages/a", SpecGit, "github", "github:owner/repo", "~2.1"},
+		{"owner/repo#semver:>=1.0.0", SpecGit, "", "owner/repo", ">=1.0.0"},
+		{"https://host/pkg.tgz", SpecURL, "https", "https://host/pkg.tgz", ""},
+		{"jsr:@std/path@^1.0.0", SpecUnknown, "jsr", "@std/path@^1.0.0", ""},
+		{"^1.2.3 garbage!", SpecUnknown, "", "", ""},
+	}
+	for _, tc := range tests {
+		spec := ParseSpec(tc.s)
+		if spec.Kind != tc.kind || spec.Protocol != tc.protocol || spec.Target != tc.target || spec.Range != tc.r || spec.Raw != tc.s {
+			t.Errorf("Invalid for case %q: Expected %s %q %q %q, got: %s %q %q %q", tc.s, tc.kind, tc.protocol, tc.target, tc.r, spec.Kind, spec.Protocol, spec.Target, spec.Range)
+		}
+	}
+}
+
+func TestSpecConstraint(t *testing.T) {
+	r, err := ParseSpec("git+ssh://git@github.com/owner/repo.git#semver:^1.2").Constraint()
+	if err != nil || !r(MustParse("1.9.0")) || r(MustParse("2.0.0")) {
+		t.Errorf("Unexpected constraint behavior: %v", err)
+	}
+	if _, err := ParseSpec("catalog:").Constraint(); err != ErrNoRange {
+		t.Errorf("Expected ErrNoRange, got: %v", err)
+	}
+}
+
+func TestParseGitSemverFragment(t *testing.T) {
+	tests := []struct {
+		url  string
+		repo string
+		v    string
+	}{
+		{"git+https://github.com/owner/repo.git#semver:^2.1.0", "https://github.com/owner/repo.git", "2.3.0"},
+		{"git+ssh://git@github.com/owner/repo.git#semver:~1.2", "ssh://git@github.com/owner/repo.git", "1.2.9"},
+		{"github:owner/repo#semver:%3E%3D1.0.0", "github:owner/repo", "1.0.0"},
+		{"git://host/repo.git#main::semver:1.x", "git://host/repo.git", "1.5.0"},
+	}
+	for _, tc := range tests {
+		repo, r, err := ParseGitSemverFragment(tc.url)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %s", tc.url, err)
+		} else if repo != tc.repo || !r(MustParse(tc.v)) {
+			t.Errorf("Invalid for case %q: Expected %q matching %q, got: %q", tc.url, tc.repo, tc.v, repo)
+		}
+	}
+
+	for _, url := range []string{"^1.2.3", "https://host/pkg.tgz", "git+https://host/repo.git#v1.0.0", "git+https://host/repo.git#semver:^1.2.3junk"} {
+		if _, _, err := ParseGitSemverFragment(url); err == nil {
+			t.Errorf("Expected error for case %q", url)
+		}
+	}
+	if _, _, err := ParseGitSemverFragment("git+https://host/repo.git"); err != ErrNoRange {
+		t.Errorf("Expected ErrNoRange, got: %v", err)
+	}
+}
@@ -2,29 +2,138 @@ package semver
 
 import (
 	"database/sql/driver"
+	"errors"
 	"fmt"
 )
 
-// Scan implements the database/sql.Scanner interface.
-func (v *Version) Scan(src interface{}) (err error) {
-	var str string
+// The packed integer encoding of a release version, see PackVersion.
+const (
+	packedMajorBits = 23
+	packedMinorBits = 20
+	packedPatchBits = 20
+)
+
+// ErrNotPackable is returned by PackVersion for versions which have no packed
+// integer encoding.
+var ErrNotPackable = errors.New("version can not be packed")
+
+// PackVersion encodes the release version v as a non-negative integer
+// ordered like the versions, e.g. to store it in an indexed integer column.
+// The major number is limited to 23 bits, the minor and patch numbers to 20
+// bits each. Prereleases, build meta data and larger numbers can not be
+// packed, PackVersion returns ErrNotPackable for them.
+func PackVersion(v Version) (int64, error) {
+	if len(v.Pre) > 0 || len(v.Build) > 0 ||
+		v.Major >= 1<<packedMajorBits || v.Minor >= 1<<packedMinorBits || v.Patch >= 1<<packedPatchBits {
+		return 0, fmt.Errorf("%w: %s", ErrNotPackable, quote(v.String()))
+	}
+	return int64(v.Major<<(packedMinorBits+packedPatchBits) | v.Minor<<packedPatchBits | v.Patch), nil
+}
+
+// UnpackVersion decodes an integer created by PackVersion.
+func UnpackVersion(n int64) (Version, error) {
+	if n < 0 {
+		return Version{}, fmt.Errorf("invalid packed version %d", n)
+	}
+	u := uint64(n)
+	return Version{
+		Major: u >> (packedMinorBits + packedPatchBits),
+		Minor: u >> packedPatchBits & (1<<packedMinorBits - 1),
+		Patch: u & (1<<packedPatchBits - 1),
+	}, nil
+}
+
+// Scan implements the database/sql.Scanner interface. It accepts the text
+// of a version and integers created by PackVersion. Text which is not a
+// valid version leaves v unchanged without an error, as in earlier
+// releases. NullVersion and PackedVersion report it.
+func (v *Version) Scan(src interface{}) error {
+	t, err := scanVersion(src)
+	switch src.(type) {
+	case string, []byte:
+		if err != nil {
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+	*v = t
+	return nil
+}
+
+// scanVersion converts a value read from a database to a Version. Unlike
+// Version.Scan it is an error if text is not a valid version.
+func scanVersion(src interface{}) (Version, error) {
 	switch src := src.(type) {
 	case string:
-		str = src
+		return Parse(src)
 	case []byte:
-		str = string(src)
-	default:
-		return fmt.Errorf("version.Scan: cannot convert %T to string", src)
+		return Parse(string(src))
+	case int64:
+		return UnpackVersion(src)
 	}
+	return Version{}, fmt.Errorf("version.Scan: cannot convert %T to string", src)
+}
+
+// Value implements the database/sql/driver.Valuer interface.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
 
-	if t, err := Parse(str); err == nil {
-		*v = t
+// PackedVersion is a Version stored in a database by its packed integer
+// encoding, see PackVersion. Convert a Version to store it and scan into a
+// converted pointer to read it:
+//
+//	db.Exec("INSERT INTO releases (version) VALUES (?)", semver.PackedVersion(v))
+//	row.Scan((*semver.PackedVersion)(&v))
+type PackedVersion Version
+
+// Scan implements the database/sql.Scanner interface. It accepts the
+// values of Version.Scan, but text which is not a valid version is an error.
+func (p *PackedVersion) Scan(src interface{}) error {
+	v, err := scanVersion(src)
+	if err != nil {
+		return err
 	}
+	*p = PackedVersion(v)
+	return nil
+}
+
+// Value implements the database/sql/driver.Valuer interface. It is an error
+// if the version can not be packed.
+func (p PackedVersion) Value() (driver.Value, error) {
+	return PackVersion(Version(p))
+}
 
-	return
+// NullVersion is a Version which may be NULL in a database, like
+// sql.NullString. Versions are stored as text.
+type NullVersion struct {
+	Version Version
+	Valid   bool // Valid is true if Version is not NULL
+}
+
+// Scan implements the database/sql.Scanner interface. It accepts NULL and
+// the values of Version.Scan, but text which is not a valid version is an
+// error.
+func (n *NullVersion) Scan(src interface{}) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	v, err := scanVersion(src)
+	if err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Version, n.Valid = v, true
+	return nil
 }
 
 // Value implements the database/sql/driver.Valuer interface.
-func (v Version) Value() (driver.Value, error) {
-	return v.String(), nil
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
 }
@@ -1,6 +1,7 @@
 package semver
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -36,3 +37,101 @@ func TestScanString(t *testing.T) {
 		}
 	}
 }
+
+func TestScanInvalid(t *testing.T) {
+	v := MustParse("1.0.0")
+	for _, src := range []interface{}{int64(-1), nil} {
+		if err := v.Scan(src); err == nil {
+			t.Errorf("Scan did not return an error on %v (%T)", src, src)
+		}
+	}
+	// Invalid text is ignored like in earlier releases
+	for _, src := range []interface{}{"a.b.c", []byte("01.2.3")} {
+		if err := v.Scan(src); err != nil {
+			t.Errorf("Scan returned an unexpected error on %v (%T): %s", src, src, err)
+		}
+		var p PackedVersion
+		if err := p.Scan(src); err == nil {
+			t.Errorf("PackedVersion.Scan did not return an error on %v (%T)", src, src)
+		}
+	}
+	if v.String() != "1.0.0" {
+		t.Errorf("Invalid Scan modified the version: %s", v)
+	}
+}
+
+func TestPackVersion(t *testing.T) {
+	tests := []struct {
+		v       string
+		packErr bool
+	}{
+		{"0.0.0", false},
+		{"1.2.3", false},
+		{"8388607.1048575.1048575", false},
+		{"8388608.0.0", true},
+		{"1.1048576.0", true},
+		{"1.0.1048576", true},
+		{"1.2.3-beta", true},
+		{"1.2.3+build", true},
+	}
+	var last int64 = -1
+	for _, tc := range tests {
+		v := MustParse(tc.v)
+		n, err := PackVersion(v)
+		if tc.packErr {
+			if !errors.Is(err, ErrNotPackable) {
+				t.Errorf("Invalid for case %q: Expected ErrNotPackable, got: %v", tc.v, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.v, err)
+			continue
+		}
+		if n <= last {
+			t.Errorf("Invalid for case %q: Packed %d does not sort after %d", tc.v, n, last)
+		}
+		last = n
+		var s Version
+		if err := s.Scan(n); err != nil || !s.Equals(v) {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.v, v, s, err)
+		}
+	}
+}
+
+func TestPackedVersion(t *testing.T) {
+	v := MustParse("1.2.3")
+	val, err := PackedVersion(v).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != int64(1<<40|2<<20|3) {
+		t.Errorf("Unexpected packed value: %v", val)
+	}
+	var p PackedVersion
+	if err := p.Scan(val); err != nil || !Version(p).Equals(v) {
+		t.Errorf("Expected %q, got: %q (%v)", v, Version(p), err)
+	}
+	if _, err := PackedVersion(MustParse("1.2.3-rc.1")).Value(); err == nil {
+		t.Errorf("Expected an error for a prerelease")
+	}
+}
+
+func TestNullVersion(t *testing.T) {
+	var n NullVersion
+	if err := n.Scan("1.2.3"); err != nil || !n.Valid || n.Version.String() != "1.2.3" {
+		t.Errorf("Expected valid 1.2.3, got: %+v (%v)", n, err)
+	}
+	if val, err := n.Value(); val != "1.2.3" || err != nil {
+		t.Errorf("Expected %q, got: %v (%v)", "1.2.3", val, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Expected NULL, got: %+v (%v)", n, err)
+	}
+	if val, err := n.Value(); val != nil || err != nil {
+		t.Errorf("Expected nil, got: %v (%v)", val, err)
+	}
+	if err := n.Scan("a.b.c"); err == nil || n.Valid {
+		t.Errorf("Expected an error, got: %+v", n)
+	}
+}
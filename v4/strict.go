@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseOptions selects how strictly ParseWithOptions reads a version string.
+// The zero value parses like Parse, which follows the specification but
+// accepts partial versions like "1.2" and wildcards. The normalizations are
+// applied first, so Strict can be combined with them, e.g. to accept
+// "v1.2.3" but not "v1.2".
+type ParseOptions struct {
+	// Strict admits only version strings of the SemVer 2.0.0
+	// specification: exactly three numbers without leading zeroes,
+	// optionally followed by prerelease and build meta data, without a "v"
+	// prefix, white space or wildcards.
+	Strict bool
+
+	// TrimSpace removes leading and trailing white space.
+	TrimSpace bool
+	// TrimPrefix removes a leading "v", e.g. of a git tag like "v1.2.3".
+	TrimPrefix bool
+	// TrimLeadingZeroes removes the leading zeroes of the numbers, "01.02.03"
+	// becomes 1.2.3.
+	TrimLeadingZeroes bool
+	// FillMissing adds the minor and patch number of short versions, "1.2"
+	// becomes 1.2.0. Short versions can not have prerelease or build meta
+	// data.
+	FillMissing bool
+}
+
+// StrictParseOptions returns the options of ParseStrict.
+func StrictParseOptions() ParseOptions {
+	return ParseOptions{Strict: true}
+}
+
+// TolerantParseOptions returns the options of ParseTolerant.
+func TolerantParseOptions() ParseOptions {
+	return ParseOptions{TrimSpace: true, TrimPrefix: true, TrimLeadingZeroes: true, FillMissing: true}
+}
+
+// ParseStrict parses a version string of the SemVer 2.0.0 specification and
+// rejects everything else, e.g. "v1.2.3", "01.2.3", "1.2" or " 1.2.3", see
+// ParseOptions.Strict. Use it to validate versions published by others, and
+// ParseTolerant to read versions written by people.
+func ParseStrict(s string) (Version, error) {
+	return ParseWithOptions(s, StrictParseOptions())
+}
+
+// ParseWithOptions parses a version string like Parse, normalized and
+// checked as selected by opts.
+func ParseWithOptions(s string, opts ParseOptions) (Version, error) {
+	v, _, err := parseWithOptions(s, opts)
+	return v, err
+}
+
+// parseWithOptions is ParseWithOptions which also returns the precision of
+// s, see PreciseVersion.
+func parseWithOptions(s string, opts ParseOptions) (Version, int, error) {
+	if opts.TrimSpace {
+		s = strings.TrimSpace(s)
+	}
+	if opts.TrimPrefix {
+		s = strings.TrimPrefix(s, "v")
+	}
+
+	// Split into major.minor.(patch+pr+meta)
+	parts := strings.SplitN(s, ".", 3)
+	if opts.TrimLeadingZeroes {
+		for i, p := range parts {
+			if len(p) > 1 {
+				p = strings.TrimLeft(p, "0")
+				if len(p) == 0 || !strings.ContainsAny(p[0:1], "0123456789") {
+					p = "0" + p
+				}
+				parts[i] = p
+			}
+		}
+	}
+	specified := len(parts)
+	if opts.FillMissing && len(parts) < 3 {
+		if strings.ContainsAny(parts[len(parts)-1], "+-") {
+			return Version{}, 0, errors.New("Short version cannot contain PreRelease/Build meta data")
+		}
+		for len(parts) < 3 {
+			parts = append(parts, "0")
+		}
+	}
+	s = strings.Join(parts, ".")
+
+	if opts.Strict {
+		if err := checkStrict(s); err != nil {
+			return Version{}, 0, err
+		}
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return Version{}, 0, err
+	}
+	if p := specifiedComponents(s); p < specified {
+		specified = p
+	}
+	return v, specified, nil
+}
+
+// checkStrict checks that s has the structure of a SemVer 2.0.0 version
+// string, see ParseOptions.Strict. Parse checks the prerelease and build
+// identifiers afterwards.
+func checkStrict(s string) error {
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		return fmt.Errorf("version %s must not have a %q prefix", quote(s), s[:1])
+	}
+	if !containsOnly(s, alphanum+".+") {
+		return fmt.Errorf("Invalid character(s) found in version %s", quote(s))
+	}
+	core := s
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("version %s must have exactly three numbers", quote(s))
+	}
+	for i, p := range parts {
+		component := [...]string{"major", "minor", "patch"}[i]
+		if p == "" || !containsOnly(p, numbers) {
+			return fmt.Errorf("Invalid character(s) found in %s number %s", component, quote(p))
+		}
+		if hasLeadingZeroes(p) {
+			return &NumberError{Component: component, Number: p, Err: ErrLeadingZeroes}
+		}
+	}
+	return nil
+}
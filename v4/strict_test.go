@@ -0,0 +1,98 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStrict(t *testing.T) {
+	valid := []string{
+		"0.0.0",
+		"1.2.3",
+		"1.2.3-beta.1",
+		"1.2.3-0.3.7",
+		"1.2.3-x-y-z.--",
+		"1.2.3+build.001",
+		"1.2.3-rc.1+exp.sha.5114f85",
+		"18446744073709551615.0.0",
+	}
+	for _, s := range valid {
+		v, err := ParseStrict(s)
+		if err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", s, err)
+		} else if v.String() != s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", s, s, v)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"v1.2.3",
+		"V1.2.3",
+		"1.2",
+		"1",
+		"1.2.3.4",
+		"1.2.x",
+		"*",
+		" 1.2.3",
+		"1.2.3 ",
+		"1.2 .3",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.3-01",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-beta..1",
+		"1.2.3-beta_1",
+		"1..3",
+		"1.2.3e2",
+		"18446744073709551616.0.0",
+	}
+	for _, s := range invalid {
+		if v, err := ParseStrict(s); err == nil {
+			t.Errorf("Invalid for case %q: Expected an error, got: %q", s, v)
+		}
+	}
+
+	var numErr *NumberError
+	if _, err := ParseStrict("1.02.3"); !errors.As(err, &numErr) || numErr.Component != "minor" || !errors.Is(err, ErrLeadingZeroes) {
+		t.Errorf("Expected a NumberError for the minor number, got: %v", err)
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts ParseOptions
+		v    string
+		err  bool
+	}{
+		{"1.2", ParseOptions{}, "1.2.0", false},
+		{"1.2", ParseOptions{Strict: true}, "", true},
+		{"1.2", ParseOptions{Strict: true, FillMissing: true}, "1.2.0", false},
+		{"v1.2.3", ParseOptions{Strict: true}, "", true},
+		{"v1.2.3", ParseOptions{Strict: true, TrimPrefix: true}, "1.2.3", false},
+		{"v1.2", ParseOptions{Strict: true, TrimPrefix: true}, "", true},
+		{" 1.2.3\n", ParseOptions{Strict: true, TrimSpace: true}, "1.2.3", false},
+		{"01.02.03", ParseOptions{TrimLeadingZeroes: true}, "1.2.3", false},
+		{"01.02.03", ParseOptions{Strict: true}, "", true},
+		{"1.2-beta", ParseOptions{FillMissing: true}, "", true},
+		{" v1.2 ", TolerantParseOptions(), "1.2.0", false},
+		{" v1.2 ", StrictParseOptions(), "", true},
+	}
+	for _, tc := range tests {
+		v, err := ParseWithOptions(tc.s, tc.opts)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Invalid for case %q with %+v: Expected an error, got: %q", tc.s, tc.opts, v)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Invalid for case %q with %+v: Unexpected error: %s", tc.s, tc.opts, err)
+		} else if v.String() != tc.v {
+			t.Errorf("Invalid for case %q with %+v: Expected %q, got: %q", tc.s, tc.opts, tc.v, v)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package semver
+
+import "strings"
+
+// ConstraintStyle selects the kind of constraint proposed by SuggestConstraint.
+type ConstraintStyle int
+
+const (
+	// StyleCaret proposes caret ranges, e.g. "^1.2.3", allowing changes that
+	// do not modify the left-most non-zero number.
+	StyleCaret ConstraintStyle = iota
+	// StyleTilde proposes tilde ranges, e.g. "~1.2.3", allowing patch level
+	// changes.
+	StyleTilde
+	// StyleExact pins the used versions exactly.
+	StyleExact
+)
+
+// SuggestConstraint proposes the tightest constraint of the given style
+// covering all used versions, e.g. the versions observed in deployed
+// environments. The lowest used version is the base of the constraint,
+// versions which one constraint can not cover are joined by "||":
+// 1.2.3 and 1.4.0 become "^1.2.3" with StyleCaret and "~1.2.3 || ~1.4.0"
+// with StyleTilde. If used is empty, the Range matches no version.
+func SuggestConstraint(used []Version, style ConstraintStyle) Range {
+	s := SuggestConstraintString(used, style)
+	if s == "" {
+		return func(Version) bool { return false }
+	}
+	return MustParseRange(s)
+}
+
+// SuggestConstraintString is like SuggestConstraint but returns the range
+// string, e.g. to write it to a manifest. It returns "" if used is empty.
+func SuggestConstraintString(used []Version, style ConstraintStyle) string {
+	sorted := make([]Version, len(used))
+	for i, v := range used {
+		// Build meta data has no precedence and is not part of a constraint
+		sorted[i] = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Pre: v.Pre}
+	}
+	Sort(sorted)
+
+	var prefix string
+	switch style {
+	case StyleCaret:
+		prefix = "^"
+	case StyleTilde:
+		prefix = "~"
+	}
+
+	var parts []string
+	var base Version
+	for i, v := range sorted {
+		if i > 0 && sameConstraintGroup(base, v, style) {
+			continue
+		}
+		base = v
+		parts = append(parts, prefix+v.String())
+	}
+	return strings.Join(parts, " || ")
+}
+
+// sameConstraintGroup checks if the constraint of style based on base covers
+// v, v must not be lower than base.
+func sameConstraintGroup(base, v Version, style ConstraintStyle) bool {
+	switch style {
+	case StyleCaret:
+		upper, err := caretUpperBound(base, 3)
+		return err != nil || Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}.LT(upper)
+	case StyleTilde:
+		return base.Major == v.Major && base.Minor == v.Minor
+	}
+	return base.Equals(v)
+}
@@ -0,0 +1,38 @@
+package semver
+
+import "testing"
+
+func TestSuggestConstraint(t *testing.T) {
+	tests := []struct {
+		used  []string
+		style ConstraintStyle
+		s     string
+	}{
+		{[]string{"1.4.0", "1.2.3"}, StyleCaret, "^1.2.3"},
+		{[]string{"1.4.0", "1.2.3"}, StyleTilde, "~1.2.3 || ~1.4.0"},
+		{[]string{"1.4.0", "1.2.3", "1.4.0"}, StyleExact, "1.2.3 || 1.4.0"},
+		{[]string{"2.0.1", "1.2.3", "1.9.0", "2.3.0"}, StyleCaret, "^1.2.3 || ^2.0.1"},
+		{[]string{"1.2.5", "1.2.3+build.1"}, StyleTilde, "~1.2.3"},
+		{[]string{"1.2.3-beta.1", "1.2.3"}, StyleCaret, "^1.2.3-beta.1"},
+		{[]string{"0.2.3", "0.2.9", "0.3.0", "0.0.1", "0.0.2"}, StyleCaret, "^0.0.1 || ^0.0.2 || ^0.2.3 || ^0.3.0"},
+		{nil, StyleCaret, ""},
+	}
+	for _, tc := range tests {
+		used := make([]Version, len(tc.used))
+		for i, s := range tc.used {
+			used[i] = MustParse(s)
+		}
+		if s := SuggestConstraintString(used, tc.style); s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.used, tc.s, s)
+		}
+		r := SuggestConstraint(used, tc.style)
+		for _, v := range used {
+			if !r(v) {
+				t.Errorf("Invalid for case %q: suggestion does not cover %q", tc.used, v)
+			}
+		}
+	}
+	if SuggestConstraint(nil, StyleExact)(MustParse("1.0.0")) {
+		t.Errorf("Empty suggestion must not match")
+	}
+}
@@ -0,0 +1,66 @@
+package semver
+
+import "errors"
+
+// errNPMCompatText is returned when marshaling constraints whose prerelease
+// policy the text form can not express.
+var errNPMCompatText = errors.New("range with the NPMCompat prerelease policy has no text form, marshal it as JSON")
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, e.g. for
+// versions in configuration files.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. Constraints
+// are written as their expanded normal form. It is an error if the
+// constraints were parsed with RangeOptions.NPMCompat but without
+// IncludePrerelease, their JSON form keeps the prerelease policy.
+func (c *Constraints) MarshalText() ([]byte, error) {
+	if c.npm {
+		return nil, errNPMCompatText
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, see
+// ParseRange for the supported syntax.
+func (c *Constraints) UnmarshalText(text []byte) error {
+	parsed, err := ParseConstraints(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface like
+// Constraints.MarshalText. It is an error if the Range can not be inspected.
+func (rf Range) MarshalText() ([]byte, error) {
+	c, ok := constraintsOf(rf)
+	if !ok {
+		return nil, ErrRangeNotInspectable
+	}
+	return c.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, e.g. for
+// ranges in configuration files, environment variables and flags.
+func (rf *Range) UnmarshalText(text []byte) error {
+	r, err := ParseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*rf = r
+	return nil
+}
@@ -0,0 +1,71 @@
+package semver
+
+import (
+	"encoding"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler   = Version{}
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+	_ encoding.TextMarshaler   = Range(nil)
+	_ encoding.TextUnmarshaler = (*Range)(nil)
+	_ encoding.TextMarshaler   = (*Constraints)(nil)
+	_ encoding.TextUnmarshaler = (*Constraints)(nil)
+)
+
+func TestVersionText(t *testing.T) {
+	var v Version
+	if err := v.UnmarshalText([]byte("1.2.3-rc.1+build")); err != nil {
+		t.Fatal(err)
+	}
+	if text, err := v.MarshalText(); err != nil || string(text) != "1.2.3-rc.1+build" {
+		t.Errorf("Expected %q, got: %q (%v)", "1.2.3-rc.1+build", text, err)
+	}
+	if err := v.UnmarshalText([]byte("a.b.c")); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestRangeText(t *testing.T) {
+	tests := []struct {
+		text       string
+		normalForm string
+	}{
+		{"^1.2", ">=1.2.0 <2.0.0"},
+		{"1.x || 3.1.0", ">=1.0.0 <2.0.0 || 3.1.0"},
+	}
+	for _, tc := range tests {
+		var r Range
+		if err := r.UnmarshalText([]byte(tc.text)); err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.text, err)
+			continue
+		}
+		if text, err := r.MarshalText(); err != nil || string(text) != tc.normalForm {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.text, tc.normalForm, text, err)
+		}
+		var c Constraints
+		if err := c.UnmarshalText([]byte(tc.text)); err != nil {
+			t.Errorf("Invalid for case %q: Unexpected error: %s", tc.text, err)
+			continue
+		}
+		if text, err := c.MarshalText(); err != nil || string(text) != tc.normalForm {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q (%v)", tc.text, tc.normalForm, text, err)
+		}
+	}
+
+	var r Range
+	if err := r.UnmarshalText([]byte(">=")); err == nil {
+		t.Errorf("Expected an error")
+	}
+	if _, err := Range(func(Version) bool { return true }).MarshalText(); err != ErrRangeNotInspectable {
+		t.Errorf("Expected ErrRangeNotInspectable, got: %v", err)
+	}
+	npm, err := ParseRangeWithOptions("~1.2.3", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := npm.MarshalText(); err == nil {
+		t.Errorf("Expected an error for the NPMCompat prerelease policy")
+	}
+}
@@ -0,0 +1,18 @@
+package semver
+
+// Union returns the constraints satisfied by the versions satisfying any of
+// cs, e.g. the versions allowed by the manifests of several packages, in the
+// form of Constraints.Simplify: the sets are disjoint, sorted by version and
+// do not adjoin, so the String of the result reads as the allowed intervals,
+// e.g. ">=1.2.0 <2.0.0 || >=3.0.0" for "^1.2", "~1.5.0" and ">=3".
+// Unlike Range.OR, which keeps the sets of both ranges, equivalent unions
+// have the same String. Versions are compared by precedence, the prerelease
+// rule of RangeOptions.NPMCompat is not taken into account. The union of no
+// constraints matches no version.
+func Union(cs ...*Constraints) *Constraints {
+	all := &Constraints{}
+	for _, c := range cs {
+		all.sets = append(all.sets, c.sets...)
+	}
+	return all.Simplify()
+}
@@ -0,0 +1,44 @@
+package semver
+
+import "testing"
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		ranges []string
+		union  string
+	}{
+		{[]string{"^1.2", "~1.5.0", ">=3"}, ">=1.2.0 <2.0.0 || >=3.0.0"},
+		{[]string{"^1.2", "^2.0.0"}, ">=1.2.0 <3.0.0"},
+		{[]string{"<1.0.0", "1.0.0", ">1.0.0 <2.0.0"}, "<2.0.0"},
+		{[]string{">=1.0.0 <3.0.0 !=2.0.0", "2.0.0"}, ">=1.0.0 <3.0.0"},
+		{[]string{">=1.0.0 <3.0.0 !=2.0.0", "^1.5.0"}, ">=1.0.0 <3.0.0 !=2.0.0"},
+		{[]string{"1.2.3", "1.2.3", "1.2.4"}, "1.2.3 || 1.2.4"},
+		{[]string{"^1.2.3 || ^4.0.0", "^2.0.0 || 5.0.0"}, ">=1.2.3 <3.0.0 || >=4.0.0 <=5.0.0"},
+		{[]string{">2.0.0 <1.0.0"}, "<0.0.0-0"},
+		{nil, "<0.0.0-0"},
+	}
+	for _, tc := range tests {
+		var cs []*Constraints
+		for _, r := range tc.ranges {
+			cs = append(cs, MustParseConstraints(r))
+		}
+		if union := Union(cs...).String(); union != tc.union {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.ranges, tc.union, union)
+		}
+	}
+}
+
+func TestUnionNPMCompat(t *testing.T) {
+	a, err := ParseConstraintsWithOptions("^1.2.3", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	union := Union(a, MustParseConstraints("^1.5.0"))
+	if s := union.String(); s != ">=1.2.3 <2.0.0" {
+		t.Errorf("Expected %q, got: %q", ">=1.2.3 <2.0.0", s)
+	}
+	// Versions are compared by precedence
+	if v := MustParse("1.6.0-beta.1"); !union.Check(v) {
+		t.Errorf("Expected %s to satisfy the union", v)
+	}
+}
@@ -0,0 +1,84 @@
+package semver
+
+import (
+	"bytes"
+	_ "embed" // for the embedded range test vectors
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+//go:embed testvectors/ranges.json
+var rangeVectorsJSON []byte
+
+// RangeVector is a machine-readable conformance test case describing which
+// versions a range is expected to match. Implementations of the same range
+// semantics in other languages can verify parity against these vectors, the
+// JSON source is shipped in testvectors/ranges.json.
+type RangeVector struct {
+	Range       string   `json:"range"`
+	Satisfied   []string `json:"satisfied,omitempty"`
+	Unsatisfied []string `json:"unsatisfied,omitempty"`
+	Invalid     bool     `json:"invalid,omitempty"`
+}
+
+// rangeVectorFile is the top level structure of a test vector file.
+type rangeVectorFile struct {
+	Format int           `json:"format"`
+	Ranges []RangeVector `json:"ranges"`
+}
+
+// rangeVectorFormat is the only supported test vector file format.
+const rangeVectorFormat = 1
+
+// RangeVectors returns the range test vectors shipped with this package.
+func RangeVectors() ([]RangeVector, error) {
+	return LoadRangeVectors(bytes.NewReader(rangeVectorsJSON))
+}
+
+// LoadRangeVectors reads range test vectors in the JSON format of
+// testvectors/ranges.json from r.
+func LoadRangeVectors(r io.Reader) ([]RangeVector, error) {
+	var f rangeVectorFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	if f.Format != rangeVectorFormat {
+		return nil, fmt.Errorf("unsupported range vector format %d", f.Format)
+	}
+	return f.Ranges, nil
+}
+
+// Check verifies the vector against ParseRange and returns an error
+// describing the first mismatch.
+func (rv RangeVector) Check() error {
+	r, err := ParseRange(rv.Range)
+	if rv.Invalid {
+		if err == nil {
+			return fmt.Errorf("range %q: expected parse error", rv.Range)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("range %q: %s", rv.Range, err)
+	}
+	for _, s := range rv.Satisfied {
+		v, err := Parse(s)
+		if err != nil {
+			return fmt.Errorf("range %q: %s", rv.Range, err)
+		}
+		if !r(v) {
+			return fmt.Errorf("range %q: expected %q to be satisfied", rv.Range, s)
+		}
+	}
+	for _, s := range rv.Unsatisfied {
+		v, err := Parse(s)
+		if err != nil {
+			return fmt.Errorf("range %q: %s", rv.Range, err)
+		}
+		if r(v) {
+			return fmt.Errorf("range %q: expected %q to be unsatisfied", rv.Range, s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRangeVectors(t *testing.T) {
+	vectors, err := RangeVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("Expected embedded range vectors")
+	}
+	for _, rv := range vectors {
+		if err := rv.Check(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLoadRangeVectors(t *testing.T) {
+	vectors, err := LoadRangeVectors(strings.NewReader(`{"format": 1, "ranges": [{"range": ">1.0.0", "satisfied": ["1.0.1"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 1 || vectors[0].Range != ">1.0.0" {
+		t.Errorf("Unexpected vectors: %v", vectors)
+	}
+
+	if _, err := LoadRangeVectors(strings.NewReader(`{"format": 2, "ranges": []}`)); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if _, err := LoadRangeVectors(strings.NewReader(`[`)); err == nil {
+		t.Error("Expected error for malformed JSON")
+	}
+
+	failing := RangeVector{Range: ">1.0.0", Satisfied: []string{"1.0.0"}}
+	if err := failing.Check(); err == nil {
+		t.Error("Expected mismatch error")
+	}
+}
@@ -0,0 +1,191 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ParseVers parses a version range in the vers notation of the package-url
+// project, which CycloneDX uses for the versionRange of vulnerabilities and
+// SPDX tooling increasingly adopts, e.g. "vers:npm/>=1.2.3|<2.0.0". The
+// versions must be semantic versions, the versioning scheme is returned but
+// not interpreted otherwise, a leading "v" is accepted for "golang".
+//
+// The constraints of a vers range are "=", "!=", "<", "<=", ">" and ">="
+// followed by a version, a bare version means "=". Equality constraints
+// match their version, "!=" excludes its version, the remaining constraints
+// form intervals in the order of their versions. "*" matches every version.
+func ParseVers(s string) (scheme string, r Range, err error) {
+	c, scheme, err := parseVers(s)
+	if err != nil {
+		return "", nil, err
+	}
+	return scheme, c.Range(), nil
+}
+
+// versConstraint is a single constraint of a vers range.
+type versConstraint struct {
+	op string
+	v  Version
+}
+
+func parseVers(s string) (*Constraints, string, error) {
+	rest := strings.TrimSpace(s)
+	if !strings.HasPrefix(rest, "vers:") {
+		return nil, "", fmt.Errorf("vers range %s must start with \"vers:\"", quote(s))
+	}
+	rest = rest[len("vers:"):]
+	i := strings.IndexByte(rest, '/')
+	if i <= 0 {
+		return nil, "", fmt.Errorf("vers range %s has no versioning scheme", quote(s))
+	}
+	scheme, rest := strings.ToLower(rest[:i]), strings.TrimSpace(rest[i+1:])
+	if rest == "*" {
+		c, err := ParseConstraints("*")
+		return c, scheme, err
+	}
+
+	var constraints []versConstraint
+	for _, part := range strings.Split(rest, "|") {
+		part = strings.TrimSpace(part)
+		n := scanOperator(part)
+		op := part[:n]
+		switch op {
+		case "":
+			op = "="
+		case "=", "!=", "<", "<=", ">", ">=":
+		default:
+			return nil, "", fmt.Errorf("vers range %s: invalid comparator %s", quote(s), quote(op))
+		}
+		raw, err := url.PathUnescape(strings.TrimSpace(part[n:]))
+		if err != nil {
+			return nil, "", fmt.Errorf("vers range %s: %s", quote(s), err)
+		}
+		if scheme == "golang" {
+			raw = strings.TrimPrefix(raw, "v")
+		}
+		v, err := parseFull(raw)
+		if err != nil {
+			return nil, "", fmt.Errorf("vers range %s: invalid version %s: %s", quote(s), quote(raw), err)
+		}
+		constraints = append(constraints, versConstraint{op: op, v: v})
+	}
+	c, err := versConstraints(constraints)
+	if err != nil {
+		return nil, "", fmt.Errorf("vers range %s: %s", quote(s), err)
+	}
+	return c, scheme, nil
+}
+
+// versConstraints builds the Constraints of the vers constraints, following
+// the containment algorithm of the vers specification.
+func versConstraints(constraints []versConstraint) (*Constraints, error) {
+	sort.SliceStable(constraints, func(i, j int) bool {
+		return constraints[i].v.LT(constraints[j].v)
+	})
+	var excluded []string
+	var bounds []versConstraint
+	for i, vc := range constraints {
+		if i > 0 && constraints[i-1].v.Equals(vc.v) {
+			return nil, fmt.Errorf("duplicate version %s", vc.v)
+		}
+		switch vc.op {
+		case "!=":
+			excluded = append(excluded, "!="+vc.v.String())
+		default:
+			bounds = append(bounds, vc)
+		}
+	}
+
+	// Equality constraints are sets of their own, the others are paired to
+	// intervals. The sets stay in the order of their versions.
+	var sets [][]string
+	lower := -1 // index of the set with an open lower bound
+	intervals := false
+	for _, b := range bounds {
+		if b.op == "=" {
+			sets = append(sets, []string{"=" + b.v.String()})
+			continue
+		}
+		set := []string{b.op + b.v.String()}
+		if b.op == ">" || b.op == ">=" {
+			if lower >= 0 {
+				return nil, fmt.Errorf("%s%s follows the lower bound %s", b.op, b.v, sets[lower][0])
+			}
+			lower = len(sets)
+			sets = append(sets, set)
+		} else if lower >= 0 {
+			sets[lower] = append(sets[lower], set[0])
+			lower = -1
+		} else if intervals {
+			return nil, fmt.Errorf("upper bound %s%s has no lower bound", b.op, b.v)
+		} else {
+			sets = append(sets, set)
+		}
+		intervals = true
+	}
+	if len(sets) == 0 {
+		if len(excluded) == 0 {
+			return nil, errors.New("no constraints")
+		}
+		sets = append(sets, nil)
+	}
+	for i := range sets {
+		sets[i] = append(sets[i], excluded...)
+	}
+	return buildConstraints(sets, RangeOptions{})
+}
+
+// FormatVers formats r in the vers notation with the given versioning
+// scheme, e.g. "vers:npm/>=1.2.3|<2.0.0", see ParseVers. The prerelease rule
+// of RangeOptions.NPMCompat can not be expressed and is dropped. It fails if r
+// can not be inspected or matches no version.
+func FormatVers(scheme string, r Range) (string, error) {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return "", ErrRangeNotInspectable
+	}
+	intervals := c.intervals()
+	if len(intervals) == 0 {
+		return "", errors.New("vers can not express a range matching no version")
+	}
+	if len(intervals) == 1 && intervals[0].Lower.Unbounded && intervals[0].Upper.Unbounded {
+		return "vers:" + scheme + "/*", nil
+	}
+
+	var parts []string
+	for i, in := range intervals {
+		switch {
+		case !in.Lower.Unbounded && !in.Upper.Unbounded && in.Lower.Inclusive && in.Upper.Inclusive && in.Lower.Version.Equals(in.Upper.Version):
+			parts = append(parts, versVersion(in.Lower.Version))
+			continue
+		case in.Lower.Unbounded:
+		case i > 0 && !in.Lower.Inclusive && !intervals[i-1].Upper.Unbounded && !intervals[i-1].Upper.Inclusive && intervals[i-1].Upper.Version.Equals(in.Lower.Version):
+			// The previous interval ends right before this one, only the
+			// version between them is excluded.
+			parts[len(parts)-1] = "!=" + versVersion(in.Lower.Version)
+		case in.Lower.Inclusive:
+			parts = append(parts, ">="+versVersion(in.Lower.Version))
+		default:
+			parts = append(parts, ">"+versVersion(in.Lower.Version))
+		}
+		switch {
+		case in.Upper.Unbounded:
+		case in.Upper.Inclusive:
+			parts = append(parts, "<="+versVersion(in.Upper.Version))
+		default:
+			parts = append(parts, "<"+versVersion(in.Upper.Version))
+		}
+	}
+	return "vers:" + scheme + "/" + strings.Join(parts, "|"), nil
+}
+
+// versVersion formats v for a vers range, build meta data has no precedence
+// and is dropped.
+func versVersion(v Version) string {
+	v.Build = nil
+	return v.String()
+}
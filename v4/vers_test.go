@@ -0,0 +1,95 @@
+package semver
+
+import "testing"
+
+func TestParseVers(t *testing.T) {
+	tests := []struct {
+		s        string
+		scheme   string
+		expanded string
+	}{
+		{"vers:npm/>=1.2.3|<2.0.0", "npm", ">=1.2.3 <2.0.0"},
+		{"vers:npm/<2.0.0|>=1.2.3", "npm", ">=1.2.3 <2.0.0"},
+		{"vers:NPM/1.2.3", "npm", "1.2.3"},
+		{"vers:npm/*", "npm", ">=0.0.0"},
+		{"vers:npm/<1.0.0|1.5.0|>=2.0.0", "npm", "<1.0.0 || 1.5.0 || >=2.0.0"},
+		{"vers:npm/>=1.0.0|<=1.9.9|>2.0.0|<3.0.0", "npm", ">=1.0.0 <=1.9.9 || >2.0.0 <3.0.0"},
+		{"vers:npm/>=1.0.0|!=1.5.0|<2.0.0", "npm", ">=1.0.0 <2.0.0 !=1.5.0"},
+		{"vers:npm/!=1.5.0", "npm", "!=1.5.0"},
+		{"vers:golang/>=v1.2.3|<v1.4.0", "golang", ">=1.2.3 <1.4.0"},
+		{"vers:semver/>=1.0.0-beta%2Bb1", "semver", ">=1.0.0-beta+b1"},
+	}
+	for _, tc := range tests {
+		c, scheme, err := parseVers(tc.s)
+		if err != nil {
+			t.Errorf("Invalid for case %q: unexpected error: %s", tc.s, err)
+			continue
+		}
+		if scheme != tc.scheme || c.String() != tc.expanded {
+			t.Errorf("Invalid for case %q: Expected %q %q, got: %q %q", tc.s, tc.scheme, tc.expanded, scheme, c)
+		}
+	}
+
+	_, r, err := ParseVers("vers:npm/>=1.2.3|<2.0.0")
+	if err != nil || !r(MustParse("1.5.0")) || r(MustParse("2.0.0")) {
+		t.Errorf("Unexpected range for vers:npm/>=1.2.3|<2.0.0: %v", err)
+	}
+}
+
+func TestParseVersInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"npm/>=1.2.3",
+		"vers:/>=1.2.3",
+		"vers:npm",
+		"vers:npm/",
+		"vers:npm/~1.2.3",
+		"vers:npm/>=1.x",
+		"vers:npm/>=1.0.0|1.0.0",
+		"vers:npm/>=1.0.0|>=2.0.0",
+		"vers:npm/<1.0.0|<2.0.0",
+		"vers:npm/>=1.0.0|%zz",
+	}
+	for _, s := range tests {
+		if _, _, err := ParseVers(s); err == nil {
+			t.Errorf("Invalid for case %q: Expected error", s)
+		}
+	}
+}
+
+func TestFormatVers(t *testing.T) {
+	tests := []struct {
+		r string
+		s string
+	}{
+		{"^1.2.3", "vers:npm/>=1.2.3|<2.0.0"},
+		{"1.2.3 || 2.x", "vers:npm/1.2.3|>=2.0.0|<3.0.0"},
+		{"<=1.0.0 || >2.0.0", "vers:npm/<=1.0.0|>2.0.0"},
+		{"^1.0.0 !=1.5.0", "vers:npm/>=1.0.0|!=1.5.0|<2.0.0"},
+		{"!=1.5.0", "vers:npm/!=1.5.0"},
+		{"1.2.3-beta+build", "vers:npm/1.2.3-beta"},
+	}
+	for _, tc := range tests {
+		s, err := FormatVers("npm", MustParseRange(tc.r))
+		if err != nil {
+			t.Errorf("Invalid for case %q: unexpected error: %s", tc.r, err)
+			continue
+		}
+		if s != tc.s {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.r, tc.s, s)
+		}
+		c, _, err := parseVers(s)
+		if err != nil {
+			t.Errorf("Invalid for case %q: %q does not parse: %s", tc.r, s, err)
+		} else if back, _ := FormatVers("npm", c.Range()); back != s {
+			t.Errorf("Invalid for case %q: round trip of %q gives %q", tc.r, s, back)
+		}
+	}
+
+	if _, err := FormatVers("npm", MustParseRange(">2.0.0 <1.0.0")); err == nil {
+		t.Errorf("Expected error for an empty range")
+	}
+	if _, err := FormatVers("npm", func(Version) bool { return true }); err != ErrRangeNotInspectable {
+		t.Errorf("Expected %v, got: %v", ErrRangeNotInspectable, err)
+	}
+}
@@ -0,0 +1,171 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// versionListMagic starts the version list format, the last byte is the
+// format version.
+const versionListMagic = "SVL\x01"
+
+// EncodeVersionList serializes versions in a compact binary format, e.g. to
+// exchange registry snapshots between services. The versions are sorted by
+// precedence, versions of equal precedence keep their order, and every
+// version is stored as the difference to its predecessor. Prerelease and
+// build identifiers are stored once in a dictionary and referenced by index.
+//
+// The format is the magic "SVL" followed by the format version 1, the
+// dictionary, and the versions, all counts and numbers as uvarints: the
+// number of identifiers and the identifiers, the number of versions, and per
+// version the major, minor and patch number, the prerelease identifiers and
+// the build identifiers. A number is stored as the difference to the one of
+// the predecessor until a higher component differs. A numeric prerelease
+// identifier is stored as 0 followed by its number, other identifiers as
+// their dictionary index, plus one for prerelease identifiers.
+func EncodeVersionList(versions []Version) []byte {
+	sorted := make([]Version, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LT(sorted[j])
+	})
+
+	index := map[string]uint64{}
+	var dict []string
+	lookup := func(s string) uint64 {
+		i, ok := index[s]
+		if !ok {
+			i = uint64(len(dict))
+			index[s] = i
+			dict = append(dict, s)
+		}
+		return i
+	}
+
+	var body []byte
+	body = appendUvarint(body, uint64(len(sorted)))
+	var prev Version
+	for _, v := range sorted {
+		changed := v.Major != prev.Major
+		body = appendUvarint(body, v.Major-prev.Major)
+		if changed {
+			body = appendUvarint(body, v.Minor)
+		} else {
+			body = appendUvarint(body, v.Minor-prev.Minor)
+		}
+		changed = changed || v.Minor != prev.Minor
+		if changed {
+			body = appendUvarint(body, v.Patch)
+		} else {
+			body = appendUvarint(body, v.Patch-prev.Patch)
+		}
+		body = appendUvarint(body, uint64(len(v.Pre)))
+		for _, pre := range v.Pre {
+			if pre.IsNum {
+				body = append(body, 0)
+				body = appendUvarint(body, pre.VersionNum)
+			} else {
+				body = appendUvarint(body, lookup(pre.VersionStr)+1)
+			}
+		}
+		body = appendUvarint(body, uint64(len(v.Build)))
+		for _, build := range v.Build {
+			body = appendUvarint(body, lookup(build))
+		}
+		prev = v
+	}
+
+	b := append([]byte(versionListMagic), appendUvarint(nil, uint64(len(dict)))...)
+	for _, s := range dict {
+		b = appendString(b, s)
+	}
+	return append(b, body...)
+}
+
+// DecodeVersionList restores the versions serialized by EncodeVersionList,
+// sorted by precedence. The data is validated, it is an error if it is
+// truncated, has trailing bytes, an unknown format version or invalid
+// identifiers. The identifiers of the decoded versions share memory.
+func DecodeVersionList(data []byte) ([]Version, error) {
+	if len(data) < len(versionListMagic) || string(data[:len(versionListMagic)-1]) != versionListMagic[:len(versionListMagic)-1] {
+		return nil, errors.New("not a version list")
+	}
+	if data[len(versionListMagic)-1] != versionListMagic[len(versionListMagic)-1] {
+		return nil, fmt.Errorf("unsupported version list format version %d", data[len(versionListMagic)-1])
+	}
+	d := compiledDecoder{data: data[len(versionListMagic):]}
+
+	ndict := d.count()
+	dict := make([]string, 0, ndict)
+	for i := 0; i < ndict && d.err == nil; i++ {
+		dict = append(dict, d.string())
+	}
+	// valid caches the validation of the dictionary entries as prerelease
+	// and build identifiers.
+	type validity struct{ checked, ok bool }
+	validPre := make([]validity, len(dict))
+	validBuild := make([]validity, len(dict))
+	identifier := func(i uint64, valid []validity, check func(string) bool, kind string) string {
+		if d.err != nil {
+			return ""
+		}
+		if i >= uint64(len(dict)) {
+			d.err = fmt.Errorf("identifier %d is not in the dictionary", i)
+			return ""
+		}
+		if !valid[i].checked {
+			valid[i] = validity{checked: true, ok: check(dict[i])}
+		}
+		if !valid[i].ok {
+			d.err = fmt.Errorf("invalid %s identifier %s", kind, quote(dict[i]))
+			return ""
+		}
+		return dict[i]
+	}
+	isPre := func(s string) bool {
+		pre, err := NewPRVersion(s)
+		return err == nil && !pre.IsNum
+	}
+	isBuild := func(s string) bool {
+		_, err := NewBuildVersion(s)
+		return err == nil
+	}
+
+	n := d.count()
+	versions := make([]Version, 0, n)
+	var prev Version
+	for i := 0; i < n && d.err == nil; i++ {
+		var v Version
+		major, minor, patch := d.uvarint(), d.uvarint(), d.uvarint()
+		v.Major, v.Minor, v.Patch = prev.Major+major, minor, patch
+		if major == 0 {
+			v.Minor += prev.Minor
+			if minor == 0 {
+				v.Patch += prev.Patch
+			}
+		}
+		for k, npre := 0, d.count(); k < npre && d.err == nil; k++ {
+			if x := d.uvarint(); x == 0 {
+				v.Pre = append(v.Pre, PRVersion{VersionNum: d.uvarint(), IsNum: true})
+			} else {
+				v.Pre = append(v.Pre, PRVersion{VersionStr: identifier(x-1, validPre, isPre, "prerelease")})
+			}
+		}
+		for k, nbuild := 0, d.count(); k < nbuild && d.err == nil; k++ {
+			v.Build = append(v.Build, identifier(d.uvarint(), validBuild, isBuild, "build"))
+		}
+		if d.err == nil && i > 0 && v.LT(prev) {
+			d.err = fmt.Errorf("version %d is not sorted", i)
+		}
+		versions = append(versions, v)
+		prev = v
+	}
+	if d.err == nil && len(d.data) > 0 {
+		d.err = fmt.Errorf("version list has %d trailing bytes", len(d.data))
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return versions, nil
+}
@@ -0,0 +1,95 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVersionListRoundTrip(t *testing.T) {
+	tests := [][]Version{
+		nil,
+		parseVersions("1.2.3"),
+		parseVersions("2.0.0", "1.0.0", "1.0.0-beta.2", "1.0.0-beta.10", "1.0.0-alpha", "1.10.0", "1.2.0+build.5", "1.2.0+build.4"),
+		parseVersions("18446744073709551615.18446744073709551615.18446744073709551615-18446744073709551615", "0.0.0-0"),
+		parseVersions("1.0.0-rc.1+sha.5114f85", "1.0.0-rc.1+sha.dirty", "1.0.0-rc.2+sha.5114f85"),
+	}
+	for _, versions := range tests {
+		data := EncodeVersionList(versions)
+		decoded, err := DecodeVersionList(data)
+		if err != nil {
+			t.Errorf("Invalid for case %s: unexpected error: %s", fmtVersions(versions), err)
+			continue
+		}
+		want := fmtVersions(sortedStable(versions))
+		if got := fmtVersions(decoded); got != want {
+			t.Errorf("Invalid for case %s: Expected %s, got: %s", fmtVersions(versions), want, got)
+		}
+		if again := EncodeVersionList(decoded); string(again) != string(data) {
+			t.Errorf("Invalid for case %s: encoding is not stable", fmtVersions(versions))
+		}
+	}
+}
+
+// sortedStable sorts a copy of versions by precedence, keeping the order of
+// versions of equal precedence like EncodeVersionList.
+func sortedStable(versions []Version) []Version {
+	var sorted []Version
+	for _, v := range versions {
+		i := len(sorted)
+		for i > 0 && v.LT(sorted[i-1]) {
+			i--
+		}
+		sorted = append(sorted[:i], append([]Version{v}, sorted[i:]...)...)
+	}
+	return sorted
+}
+
+func TestVersionListSize(t *testing.T) {
+	var versions []Version
+	var text int
+	for major := 0; major < 10; major++ {
+		for minor := 0; minor < 20; minor++ {
+			for patch := 0; patch < 10; patch++ {
+				s := fmt.Sprintf("%d.%d.%d", major, minor, patch)
+				versions = append(versions, MustParse(s), MustParse(s+"-beta.1"))
+				text += 2*len(s) + len("-beta.1") + 2
+			}
+		}
+	}
+	data := EncodeVersionList(versions)
+	if len(data)*3 > text*2 {
+		t.Errorf("Expected the encoding to be two thirds of the %d bytes of text, got: %d bytes", text, len(data))
+	}
+	if decoded, err := DecodeVersionList(data); err != nil || len(decoded) != len(versions) {
+		t.Errorf("Unexpected decoding: %d versions (%v)", len(decoded), err)
+	}
+}
+
+func TestDecodeVersionListInvalid(t *testing.T) {
+	valid := EncodeVersionList(parseVersions("1.0.0-beta+b", "1.2.3"))
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{"", "not a version list"},
+		{"SVR\x01", "not a version list"},
+		{"SVL\x02", "unsupported version list format version 2"},
+		{string(valid[:len(valid)-1]), "truncated"},
+		{string(valid) + "\x00", "trailing bytes"},
+		// dictionary ["b"], one version 1.0.0 with the prerelease identifier 2
+		{"SVL\x01\x01\x01b\x01\x01\x00\x00\x01\x02\x00", "not in the dictionary"},
+		// dictionary ["0"], one version 1.0.0-0 with a numeric string identifier
+		{"SVL\x01\x01\x010\x01\x01\x00\x00\x01\x01\x00", `invalid prerelease identifier "0"`},
+		// dictionary ["a.b"], one version 1.0.0+a.b
+		{"SVL\x01\x01\x03a.b\x01\x01\x00\x00\x00\x01\x00", `invalid build identifier "a.b"`},
+		// 1.0.0 followed by 1.0.0-0
+		{"SVL\x01\x00\x02\x01\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00", "not sorted"},
+	}
+	for _, tc := range tests {
+		_, err := DecodeVersionList([]byte(tc.data))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Invalid for case %q: Expected error containing %q, got: %v", tc.data, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package semver
+
+// WindowPolicy describes which versions are supported relative to the
+// current version, e.g. "the current and the previous two minors" is
+// WindowPolicy{Grain: ReleaseMinor, Previous: 2}.
+type WindowPolicy struct {
+	// Grain is the version line counted by the window: ReleaseMajor counts
+	// X.*, ReleaseMinor X.Y.* and ReleasePatch X.Y.Z lines.
+	Grain ReleaseType
+	// Previous is the number of lines before the current one which are still
+	// supported. Zero supports the current line only.
+	Previous uint64
+}
+
+// SupportWindow returns the Range of versions supported under policy if
+// current is the latest version, e.g. for deprecating old API clients. The
+// window spans from the first version of the oldest supported line up to,
+// but excluding, the next line after current: with current 1.5.2 and the
+// previous two minors supported it is ">=1.3.0 <1.6.0". The window does not
+// cross a higher component, as the number of lines in a previous major is
+// not known: 2.1.0 with the previous two minors supported yields
+// ">=2.0.0 <2.2.0".
+func SupportWindow(current Version, policy WindowPolicy) Range {
+	lower := truncateVersion(current, policy.Grain)
+	switch policy.Grain {
+	case ReleaseMajor:
+		lower.Major -= minUint64(lower.Major, policy.Previous)
+	case ReleaseMinor:
+		lower.Minor -= minUint64(lower.Minor, policy.Previous)
+	default:
+		lower.Patch -= minUint64(lower.Patch, policy.Previous)
+	}
+	s := ">=" + lower.String()
+	if upper, ok := nextVersion(truncateVersion(current, policy.Grain), policy.Grain); ok {
+		s += " <" + upper.String()
+	}
+	return MustParseRange(s)
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,33 @@
+package semver
+
+import "testing"
+
+func TestSupportWindow(t *testing.T) {
+	tests := []struct {
+		current string
+		policy  WindowPolicy
+		window  string
+	}{
+		{"1.5.2", WindowPolicy{Grain: ReleaseMinor, Previous: 2}, ">=1.3.0 <1.6.0"},
+		{"1.5.2", WindowPolicy{Grain: ReleaseMinor}, ">=1.5.0 <1.6.0"},
+		{"2.1.0", WindowPolicy{Grain: ReleaseMinor, Previous: 2}, ">=2.0.0 <2.2.0"},
+		{"5.0.1", WindowPolicy{Grain: ReleaseMajor, Previous: 1}, ">=4.0.0 <6.0.0"},
+		{"1.2.3", WindowPolicy{Grain: ReleaseMajor, Previous: 3}, ">=0.0.0 <2.0.0"},
+		{"1.2.3", WindowPolicy{Grain: ReleasePatch, Previous: 1}, ">=1.2.2 <1.2.4"},
+		{"1.6.0-rc.1", WindowPolicy{Grain: ReleaseMinor, Previous: 1}, ">=1.5.0 <1.7.0"},
+		{"1.2.3+build.5", WindowPolicy{Grain: ReleaseMinor}, ">=1.2.0 <1.3.0"},
+		{"18446744073709551615.0.0", WindowPolicy{Grain: ReleaseMajor}, ">=18446744073709551615.0.0"},
+	}
+	for _, tc := range tests {
+		if s := SupportWindow(MustParse(tc.current), tc.policy).String(); s != tc.window {
+			t.Errorf("Invalid for case %q: Expected %q, got: %q", tc.current, tc.window, s)
+		}
+	}
+
+	r := SupportWindow(MustParse("1.5.2"), WindowPolicy{Grain: ReleaseMinor, Previous: 2})
+	for v, supported := range map[string]bool{"1.2.9": false, "1.3.0": true, "1.5.9": true, "1.6.0": false} {
+		if r(MustParse(v)) != supported {
+			t.Errorf("Invalid for case %q: Expected %t", v, supported)
+		}
+	}
+}
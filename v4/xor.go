@@ -0,0 +1,56 @@
+package semver
+
+import "fmt"
+
+// XOR combines the existing Range with another Range using logical exclusive
+// OR: a version matches if exactly one of both ranges matches it. The result
+// can not be inspected, as the comparators can not express it.
+func (rf Range) XOR(f Range) Range {
+	return Range(func(v Version) bool {
+		if isProbe(v) {
+			return false
+		}
+		return rf(v) != f(v)
+	})
+}
+
+// MatchingBranches returns the indexes of the sets joined by "||" which v
+// satisfies, e.g. 1.5.0 satisfies the branches 0 and 1 of
+// ">=1.0.0 <2.0.0 || >=1.5.0".
+func (c *Constraints) MatchingBranches(v Version) []int {
+	var branches []int
+	for i, set := range c.sets {
+		if checkSet(set, v) && (!c.npm || len(v.Pre) == 0 || hasPrereleaseAnchor(set, v)) {
+			branches = append(branches, i)
+		}
+	}
+	return branches
+}
+
+// BranchOverlapError is returned by CheckExclusive if a version satisfies
+// more than one branch of a range.
+type BranchOverlapError struct {
+	Version  Version
+	Branches []int // indexes of the satisfied branches
+}
+
+func (e *BranchOverlapError) Error() string {
+	return fmt.Sprintf("version %s matches %d branches of the range: %v", e.Version, len(e.Branches), e.Branches)
+}
+
+// CheckExclusive checks if v satisfies r in the exclusive OR mode, which
+// requires that at most one of the sets joined by "||" matches any version,
+// e.g. for policy validators requiring non-overlapping constraints. It
+// returns a *BranchOverlapError if v satisfies more than one branch, and
+// ErrRangeNotInspectable if r was not created by this package.
+func CheckExclusive(r Range, v Version) (bool, error) {
+	c, ok := constraintsOf(r)
+	if !ok {
+		return false, ErrRangeNotInspectable
+	}
+	branches := c.MatchingBranches(v)
+	if len(branches) > 1 {
+		return false, &BranchOverlapError{Version: v, Branches: branches}
+	}
+	return len(branches) == 1, nil
+}
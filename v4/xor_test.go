@@ -0,0 +1,75 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRangeXOR(t *testing.T) {
+	r := MustParseRange("<2.0.0").XOR(MustParseRange(">=1.0.0 <3.0.0"))
+	tests := []struct {
+		v string
+		b bool
+	}{
+		{"0.9.0", true},
+		{"1.0.0", false},
+		{"1.9.9", false},
+		{"2.0.0", true},
+		{"2.9.9", true},
+		{"3.0.0", false},
+	}
+	for _, tc := range tests {
+		if b := r(MustParse(tc.v)); b != tc.b {
+			t.Errorf("Invalid for case %q: Expected %t, got: %t", tc.v, tc.b, b)
+		}
+	}
+	if _, ok := constraintsOf(r); ok {
+		t.Errorf("XOR range must not be inspectable")
+	}
+}
+
+func TestCheckExclusive(t *testing.T) {
+	r := MustParseRange("1.x || >=1.5.0 <3.0.0 || 5.0.0")
+	tests := []struct {
+		v        string
+		b        bool
+		branches []int
+	}{
+		{"0.9.0", false, nil},
+		{"1.2.0", true, nil},
+		{"1.5.0", false, []int{0, 1}},
+		{"2.0.0", true, nil},
+		{"5.0.0", true, nil},
+	}
+	for _, tc := range tests {
+		b, err := CheckExclusive(r, MustParse(tc.v))
+		if b != tc.b {
+			t.Errorf("Invalid for case %q: Expected %t, got: %t", tc.v, tc.b, b)
+		}
+		overlap, _ := err.(*BranchOverlapError)
+		switch {
+		case tc.branches == nil && err != nil:
+			t.Errorf("Invalid for case %q: unexpected error: %s", tc.v, err)
+		case tc.branches != nil && (overlap == nil || fmtInts(overlap.Branches) != fmtInts(tc.branches)):
+			t.Errorf("Invalid for case %q: Expected overlap of %v, got: %v", tc.v, tc.branches, err)
+		}
+	}
+
+	if _, err := CheckExclusive(func(Version) bool { return true }, MustParse("1.0.0")); err != ErrRangeNotInspectable {
+		t.Errorf("Expected %v, got: %v", ErrRangeNotInspectable, err)
+	}
+}
+
+func TestMatchingBranchesNPM(t *testing.T) {
+	c, err := ParseConstraintsWithOptions(">=1.0.0 || >=1.2.3-beta.1", RangeOptions{NPMCompat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branches := c.MatchingBranches(MustParse("1.2.3-beta.2")); fmtInts(branches) != "[1]" {
+		t.Errorf("Expected [1], got: %v", branches)
+	}
+}
+
+func fmtInts(ints []int) string {
+	return fmt.Sprint(ints)
+}